@@ -0,0 +1,92 @@
+package main
+
+// Export the resources of a Graylog cluster as Terraform configuration.
+// The configuration and a shell script which runs "terraform import" are written to the output directory.
+//
+// The connection is configured with the same environment variables as the provider.
+//
+//   GRAYLOG_WEB_ENDPOINT_URI, GRAYLOG_AUTH_NAME, GRAYLOG_AUTH_PASSWORD,
+//   GRAYLOG_X_REQUESTED_BY and GRAYLOG_API_VERSION
+//
+// Usage:
+//
+//   graylog-terraform-export [-out DIR] [-types graylog_stream,graylog_stream_rule]
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/exporter"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/terraform"
+)
+
+func main() {
+	if err := Main(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func Main() error {
+	out := flag.String("out", ".", "the directory where graylog.tf and import.sh are written")
+	types := flag.String("types", "", "comma separated resource types to export. By default all types are exported: "+
+		strings.Join(exporter.SupportedTypes(), ", "))
+	flag.Parse()
+
+	config := &terraform.Config{
+		Endpoint:     os.Getenv("GRAYLOG_WEB_ENDPOINT_URI"),
+		AuthName:     os.Getenv("GRAYLOG_AUTH_NAME"),
+		AuthPassword: os.Getenv("GRAYLOG_AUTH_PASSWORD"),
+		XRequestedBy: os.Getenv("GRAYLOG_X_REQUESTED_BY"),
+		APIVersion:   os.Getenv("GRAYLOG_API_VERSION"),
+	}
+	if config.Endpoint == "" {
+		return errors.New("GRAYLOG_WEB_ENDPOINT_URI is required")
+	}
+	if config.XRequestedBy == "" {
+		config.XRequestedBy = "terraform-provider-graylog"
+	}
+	e := &exporter.Exporter{Config: config}
+	if *types != "" {
+		e.Types = strings.Split(*types, ",")
+	}
+
+	result, err := e.Export(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, s := range result.Skipped {
+		log.Printf("%s %s is skipped: %s", s.Type, s.ImportID, s.Reason)
+	}
+	for _, res := range result.Resources {
+		for _, k := range res.Omitted {
+			log.Printf("the sensitive attribute %s of %s.%s isn't exported", k, res.Type, res.Name)
+		}
+	}
+
+	if err := writeFile(filepath.Join(*out, "graylog.tf"), 0644, func(f *os.File) error {
+		return exporter.WriteHCL(f, result.Resources)
+	}); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(*out, "import.sh"), 0755, func(f *os.File) error {
+		return exporter.WriteImportScript(f, result.Resources)
+	})
+}
+
+func writeFile(path string, perm os.FileMode, write func(f *os.File) error) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
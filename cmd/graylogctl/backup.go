@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+)
+
+func runBackup(ctx context.Context, a *app, args []string) error {
+	var out string
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	fs.StringVar(&out, "out", ".", "the directory where the snapshot directory is created")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New("usage: graylogctl " + usageBackup)
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	snap, err := backup.Take(ctx, cl)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(out, backup.DirName(snap.Manifest.CreatedAt))
+	if err := snap.Write(dir); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "the snapshot is written to %s\n", dir)
+	return output(a.stdout, a.format, &snap.Manifest, func() *table {
+		t := &table{header: []string{"KIND", "FILE", "COUNT"}}
+		for _, f := range snap.Manifest.Files {
+			t.add(f.Kind, f.File, strconv.Itoa(f.Count))
+		}
+		return t
+	})
+}
+
+func runRestore(ctx context.Context, a *app, args []string) error {
+	r := &backup.Restorer{}
+	var policy, passwordFile string
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	fs.StringVar(&policy, "policy", string(backup.PolicySkip), "the policy for existing entities: skip or overwrite")
+	fs.StringVar(&passwordFile, "user-password-file", "",
+		"the file which has the password of created users. If it isn't given, users which don't exist aren't restored")
+	fs.StringVar(&r.Node, "node", "", "the node id which non global inputs are started on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: graylogctl " + usageRestore)
+	}
+	r.Policy = backup.Policy(policy)
+	if passwordFile != "" {
+		b, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return err
+		}
+		r.UserPassword = strings.TrimRight(string(b), "\r\n")
+	}
+	snap, err := backup.Read(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	r.Client = cl
+	result, err := r.Restore(ctx, snap)
+	if result != nil {
+		if e := output(a.stdout, a.format, restoreEntryViews(result), restoreTable(result)); e != nil {
+			return e
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
+type restoreEntryView struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	OldID  string `json:"old_id"`
+	NewID  string `json:"new_id"`
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func restoreEntryViews(result *backup.Result) []restoreEntryView {
+	views := make([]restoreEntryView, len(result.Entries))
+	for i, e := range result.Entries {
+		views[i] = restoreEntryView{
+			Kind: e.Kind, Key: e.Key, OldID: e.OldID, NewID: e.NewID, Action: string(e.Action), Reason: e.Reason,
+		}
+		if e.Err != nil {
+			views[i].Error = e.Err.Error()
+		}
+	}
+	return views
+}
+
+func restoreTable(result *backup.Result) func() *table {
+	return func() *table {
+		t := &table{header: []string{"KIND", "KEY", "ACTION", "NEW ID", "DETAIL"}}
+		for _, v := range restoreEntryViews(result) {
+			detail := v.Reason
+			if v.Error != "" {
+				detail = v.Error
+			}
+			t.add(v.Kind, v.Key, v.Action, v.NewID, detail)
+		}
+		return t
+	}
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func runContexts(ctx context.Context, a *app, args []string) error {
+	v, args, err := verb(usageContexts, args, map[string]int{"list": 0, "use": 1})
+	if err != nil {
+		return err
+	}
+	cfg, err := readConfig(a.configPath)
+	if err != nil {
+		return err
+	}
+	if v == "use" {
+		if _, ok := cfg.getContext(args[0]); !ok {
+			return fmt.Errorf("the context '%s' isn't found", args[0])
+		}
+		cfg.CurrentContext = args[0]
+		return writeConfig(a.configPath, cfg)
+	}
+	// passwords aren't written
+	type contextView struct {
+		Name       string `json:"name"`
+		Current    bool   `json:"current"`
+		Endpoint   string `json:"endpoint"`
+		AuthName   string `json:"auth_name"`
+		APIVersion string `json:"api_version"`
+	}
+	views := make([]contextView, len(cfg.Contexts))
+	for i, c := range cfg.Contexts {
+		views[i] = contextView{
+			Name: c.Name, Current: c.Name == cfg.CurrentContext,
+			Endpoint: c.Endpoint, AuthName: c.AuthName, APIVersion: c.APIVersion,
+		}
+	}
+	return output(a.stdout, a.format, views, func() *table {
+		t := &table{header: []string{"CURRENT", "NAME", "ENDPOINT", "AUTH NAME"}}
+		for _, c := range views {
+			current := ""
+			if c.Current {
+				current = "*"
+			}
+			t.add(current, c.Name, c.Endpoint, c.AuthName)
+		}
+		return t
+	})
+}
+
+func streamsTable(streams []graylog.Stream) func() *table {
+	return func() *table {
+		t := &table{header: []string{"ID", "TITLE", "DISABLED", "INDEX SET", "RULES"}}
+		for _, s := range streams {
+			t.add(s.ID, s.Title, strconv.FormatBool(s.Disabled), s.IndexSetID, strconv.Itoa(len(s.Rules)))
+		}
+		return t
+	}
+}
+
+func runStreams(ctx context.Context, a *app, args []string) error {
+	v, args, err := verb(usageStreams, args, map[string]int{"list": 0, "get": 1, "pause": 1, "resume": 1})
+	if err != nil {
+		return err
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	switch v {
+	case "list":
+		streams, _, _, err := cl.GetStreams(ctx)
+		if err != nil {
+			return err
+		}
+		return output(a.stdout, a.format, streams, streamsTable(streams))
+	case "get":
+		stream, _, err := cl.GetStream(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return output(a.stdout, a.format, stream, streamsTable([]graylog.Stream{*stream}))
+	case "pause":
+		if _, err := cl.PauseStream(ctx, args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stderr, "the stream %s is paused\n", args[0])
+		return nil
+	}
+	if _, err := cl.ResumeStream(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "the stream %s is resumed\n", args[0])
+	return nil
+}
+
+func inputsTable(inputs []graylog.Input) func() *table {
+	return func() *table {
+		t := &table{header: []string{"ID", "TITLE", "TYPE", "GLOBAL", "NODE"}}
+		for _, input := range inputs {
+			t.add(input.ID, input.Title, input.Type(), strconv.FormatBool(input.Global), input.Node)
+		}
+		return t
+	}
+}
+
+func runInputs(ctx context.Context, a *app, args []string) error {
+	v, args, err := verb(usageInputs, args, map[string]int{"list": 0, "get": 1, "start": 1, "stop": 1})
+	if err != nil {
+		return err
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	switch v {
+	case "list":
+		inputs, _, _, err := cl.GetInputs(ctx)
+		if err != nil {
+			return err
+		}
+		return output(a.stdout, a.format, inputs, inputsTable(inputs))
+	case "get":
+		input, _, err := cl.GetInput(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return output(a.stdout, a.format, input, inputsTable([]graylog.Input{*input}))
+	case "start":
+		if _, err := cl.StartInput(ctx, args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stderr, "the input %s is started\n", args[0])
+		return nil
+	}
+	if _, err := cl.StopInput(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "the input %s is stopped\n", args[0])
+	return nil
+}
+
+func usersTable(users []graylog.User) func() *table {
+	return func() *table {
+		t := &table{header: []string{"USERNAME", "FULL NAME", "EMAIL", "ROLES"}}
+		for _, user := range users {
+			t.add(user.Username, user.FullName, user.Email, joinStrings(user.Roles.ToList()))
+		}
+		return t
+	}
+}
+
+func runUsers(ctx context.Context, a *app, args []string) error {
+	v, args, err := verb(usageUsers, args, map[string]int{"list": 0, "get": 1})
+	if err != nil {
+		return err
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	if v == "list" {
+		users, _, err := cl.GetUsers(ctx)
+		if err != nil {
+			return err
+		}
+		return output(a.stdout, a.format, users, usersTable(users))
+	}
+	user, _, err := cl.GetUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return output(a.stdout, a.format, user, usersTable([]graylog.User{*user}))
+}
+
+func rolesTable(roles []graylog.Role) func() *table {
+	return func() *table {
+		t := &table{header: []string{"NAME", "DESCRIPTION", "READ ONLY"}}
+		for _, role := range roles {
+			t.add(role.Name, role.Description, strconv.FormatBool(role.ReadOnly))
+		}
+		return t
+	}
+}
+
+func runRoles(ctx context.Context, a *app, args []string) error {
+	v, args, err := verb(usageRoles, args, map[string]int{"list": 0, "get": 1})
+	if err != nil {
+		return err
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	if v == "list" {
+		roles, _, _, err := cl.GetRoles(ctx)
+		if err != nil {
+			return err
+		}
+		return output(a.stdout, a.format, roles, rolesTable(roles))
+	}
+	role, _, err := cl.GetRole(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return output(a.stdout, a.format, role, rolesTable([]graylog.Role{*role}))
+}
+
+func pipelinesTable(pipes []graylog.Pipeline) func() *table {
+	return func() *table {
+		t := &table{header: []string{"ID", "TITLE", "DESCRIPTION"}}
+		for _, pipe := range pipes {
+			t.add(pipe.ID, pipe.Title, pipe.Description)
+		}
+		return t
+	}
+}
+
+func runPipelines(ctx context.Context, a *app, args []string) error {
+	v, args, err := verb(usagePipelines, args, map[string]int{"list": 0, "get": 1})
+	if err != nil {
+		return err
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	if v == "list" {
+		pipes, _, err := cl.GetPipelines(ctx)
+		if err != nil {
+			return err
+		}
+		return output(a.stdout, a.format, pipes, pipelinesTable(pipes))
+	}
+	pipe, _, err := cl.GetPipeline(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return output(a.stdout, a.format, pipe, pipelinesTable([]graylog.Pipeline{*pipe}))
+}
+
+func indexSetsTable(indexSets []graylog.IndexSet) func() *table {
+	return func() *table {
+		t := &table{header: []string{"ID", "TITLE", "PREFIX", "DEFAULT", "WRITABLE"}}
+		for _, is := range indexSets {
+			t.add(is.ID, is.Title, is.IndexPrefix, strconv.FormatBool(is.Default), strconv.FormatBool(is.Writable))
+		}
+		return t
+	}
+}
+
+func runIndexSets(ctx context.Context, a *app, args []string) error {
+	v, args, err := verb(usageIndexSets, args, map[string]int{"list": 0, "get": 1})
+	if err != nil {
+		return err
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	if v == "list" {
+		// limit 0 means all index sets
+		indexSets, _, _, _, err := cl.GetIndexSets(ctx, 0, 0, false)
+		if err != nil {
+			return err
+		}
+		return output(a.stdout, a.format, indexSets, indexSetsTable(indexSets))
+	}
+	is, _, err := cl.GetIndexSet(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return output(a.stdout, a.format, is, indexSetsTable([]graylog.IndexSet{*is}))
+}
+
+func parseSearchArgs(args []string) (*graylog.SearchQuery, error) {
+	q := &graylog.SearchQuery{}
+	var from, to, fields string
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	fs.IntVar(&q.Range, "range", 300, "search messages of the last given seconds")
+	fs.StringVar(&from, "from", "", "the start of the absolute time range in RFC3339 such as 2020-01-01T00:00:00Z")
+	fs.StringVar(&to, "to", "", "the end of the absolute time range in RFC3339. The default is now")
+	fs.IntVar(&q.Limit, "limit", 50, "the maximum number of messages")
+	fs.StringVar(&fields, "fields", "", "comma separated fields of messages")
+	fs.StringVar(&q.Sort, "sort", "timestamp:desc", "the field and the order such as timestamp:desc")
+	fs.StringVar(&q.Filter, "filter", "", "the filter such as streams:<stream id>")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	q.Query = strings.Join(fs.Args(), " ")
+	if fields != "" {
+		q.Fields = strings.Split(fields, ",")
+	}
+	if to != "" && from == "" {
+		return nil, fmt.Errorf("-to requires -from")
+	}
+	if from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, fmt.Errorf("-from is invalid: %w", err)
+		}
+		q.From = t
+		q.To = time.Now()
+		if to != "" {
+			t, err := time.Parse(time.RFC3339, to)
+			if err != nil {
+				return nil, fmt.Errorf("-to is invalid: %w", err)
+			}
+			q.To = t
+		}
+	}
+	return q, nil
+}
+
+// searchTable returns a table whose columns are the fields.
+// If fields aren't specified, timestamp, source and message are shown.
+func searchTable(q *graylog.SearchQuery, result *graylog.SearchResult) func() *table {
+	return func() *table {
+		fields := q.Fields
+		if len(fields) == 0 {
+			fields = []string{"timestamp", "source", "message"}
+		}
+		header := make([]string, len(fields))
+		for i, f := range fields {
+			header[i] = strings.ToUpper(f)
+		}
+		t := &table{header: header}
+		for _, m := range result.Messages {
+			row := make([]string, len(fields))
+			for i, f := range fields {
+				if v, ok := m.Message[f]; ok && v != nil {
+					row[i] = fmt.Sprint(v)
+				}
+			}
+			t.add(row...)
+		}
+		return t
+	}
+}
+
+func runSearch(ctx context.Context, a *app, args []string) error {
+	q, err := parseSearchArgs(args)
+	if err != nil {
+		return err
+	}
+	cl, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	result, _, err := cl.Search(ctx, q)
+	if err != nil {
+		return err
+	}
+	if err := output(a.stdout, a.format, result, searchTable(q, result)); err != nil {
+		return err
+	}
+	if a.format == formatTable {
+		fmt.Fprintf(a.stderr, "%d of %d messages\n", len(result.Messages), result.TotalResults)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
+type (
+	// Config is the configuration file, which has named contexts like kubeconfig.
+	Config struct {
+		CurrentContext string    `yaml:"current_context"`
+		Contexts       []Context `yaml:"contexts"`
+	}
+
+	// Context is a set of connection settings.
+	Context struct {
+		Name         string `yaml:"name"`
+		Endpoint     string `yaml:"endpoint"`
+		AuthName     string `yaml:"auth_name"`
+		AuthPassword string `yaml:"auth_password"`
+		XRequestedBy string `yaml:"x_requested_by,omitempty"`
+		APIVersion   string `yaml:"api_version,omitempty"`
+	}
+)
+
+// defaultConfigPath returns $GRAYLOGCTL_CONFIG or "graylogctl/config.yaml" in the user's configuration directory.
+func defaultConfigPath() (string, error) {
+	if p := os.Getenv("GRAYLOGCTL_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "graylogctl", "config.yaml"), nil
+}
+
+// readConfig reads the configuration file.
+// If the file doesn't exist, the empty configuration is returned.
+func readConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read the configuration file %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the configuration file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func writeConfig(path string, cfg *Config) error {
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// the file has passwords
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+func (cfg *Config) getContext(name string) (*Context, bool) {
+	for i, c := range cfg.Contexts {
+		if c.Name == name {
+			return &cfg.Contexts[i], true
+		}
+	}
+	return nil, false
+}
+
+// resolveContext returns the connection settings.
+// The context is selected by name, $GRAYLOG_CONTEXT or current_context in this order.
+// The environment variables of the terraform provider such as $GRAYLOG_WEB_ENDPOINT_URI override the context
+// only if the context isn't selected explicitly by name or $GRAYLOG_CONTEXT,
+// so that the variables exported for the provider don't retarget the explicitly selected cluster.
+func (cfg *Config) resolveContext(name string) (*Context, error) {
+	if name == "" {
+		name = os.Getenv("GRAYLOG_CONTEXT")
+	}
+	explicit := name != ""
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	ctx := Context{}
+	if name != "" {
+		c, ok := cfg.getContext(name)
+		if !ok {
+			return nil, fmt.Errorf("the context '%s' isn't found", name)
+		}
+		ctx = *c
+	}
+	if !explicit {
+		for _, a := range []struct {
+			env   string
+			value *string
+		}{
+			{"GRAYLOG_WEB_ENDPOINT_URI", &ctx.Endpoint},
+			{"GRAYLOG_AUTH_NAME", &ctx.AuthName},
+			{"GRAYLOG_AUTH_PASSWORD", &ctx.AuthPassword},
+			{"GRAYLOG_X_REQUESTED_BY", &ctx.XRequestedBy},
+			{"GRAYLOG_API_VERSION", &ctx.APIVersion},
+		} {
+			if v := os.Getenv(a.env); v != "" {
+				*a.value = v
+			}
+		}
+	}
+	if ctx.Endpoint == "" {
+		return nil, errors.New("the endpoint is required. Set $GRAYLOG_WEB_ENDPOINT_URI or configure a context")
+	}
+	return &ctx, nil
+}
+
+func (c *Context) newClient() (*client.Client, error) {
+	var (
+		cl  *client.Client
+		err error
+	)
+	if c.APIVersion == "v3" {
+		cl, err = client.NewClientV3(c.Endpoint, c.AuthName, c.AuthPassword)
+	} else {
+		cl, err = client.NewClient(c.Endpoint, c.AuthName, c.AuthPassword)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.XRequestedBy != "" {
+		cl.SetXRequestedBy(c.XRequestedBy)
+	}
+	return cl, nil
+}
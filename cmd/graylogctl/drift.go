@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/drift"
+)
+
+const contextPrefix = "context:"
+
+func runDrift(ctx context.Context, a *app, args []string) error {
+	var exitCode bool
+	fs := flag.NewFlagSet("drift", flag.ContinueOnError)
+	fs.BoolVar(&exitCode, "exit-code", false, "return an error if the drift is detected")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return errors.New("usage: graylogctl " + usageDrift)
+	}
+	base, err := a.loadSnapshot(ctx, fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to get the base %s: %w", fs.Arg(0), err)
+	}
+	target, err := a.loadSnapshot(ctx, fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("failed to get the target %s: %w", fs.Arg(1), err)
+	}
+	report, err := drift.Compare(base, target)
+	if err != nil {
+		return err
+	}
+	if a.format == formatTable {
+		if _, err := report.WriteTo(a.stdout); err != nil {
+			return err
+		}
+	} else if err := output(a.stdout, a.format, report, nil); err != nil {
+		return err
+	}
+	if exitCode && !report.Empty() {
+		return errors.New("the drift is detected")
+	}
+	return nil
+}
+
+// loadSnapshot returns the snapshot of the source.
+// "context:NAME" is the cluster of the context, "context:" is the selected cluster and others are snapshot directories.
+func (a *app) loadSnapshot(ctx context.Context, src string) (*backup.Snapshot, error) {
+	if !strings.HasPrefix(src, contextPrefix) {
+		return backup.Read(src)
+	}
+	var (
+		cl  *client.Client
+		err error
+	)
+	if name := strings.TrimPrefix(src, contextPrefix); name == "" {
+		cl, err = a.getClient()
+	} else {
+		cl, err = a.getNamedClient(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return backup.Take(ctx, cl)
+}
+
+// getNamedClient returns the client of the context.
+// Unlike getClient the environment variables don't override the context,
+// because two clusters are compared.
+func (a *app) getNamedClient(name string) (*client.Client, error) {
+	cfg, err := readConfig(a.configPath)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := cfg.getContext(name)
+	if !ok {
+		return nil, fmt.Errorf("the context '%s' isn't found", name)
+	}
+	return c.newClient()
+}
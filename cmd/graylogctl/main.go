@@ -0,0 +1,192 @@
+package main
+
+// graylogctl is a command line tool for day-to-day operations of Graylog.
+//
+// Usage:
+//
+//   graylogctl [-config FILE] [-context NAME] [-o table|json|yaml] RESOURCE VERB [ARGS]
+//
+// The connection settings are read from the configuration file, whose default path is
+// "graylogctl/config.yaml" in the user's configuration directory or $GRAYLOGCTL_CONFIG.
+//
+//   current_context: production
+//   contexts:
+//   - name: production
+//     endpoint: https://graylog.example.com/api
+//     auth_name: admin
+//     auth_password: password
+//     api_version: v3
+//
+// The environment variables of the terraform provider such as $GRAYLOG_WEB_ENDPOINT_URI override current_context,
+// but they don't override the context which is selected with -context or $GRAYLOG_CONTEXT.
+//
+// "diff", "apply" and "prune" reconcile the cluster with a spec file of the package reconciler.
+// "diff" writes the plan, "apply" creates and updates resources (and deletes them with -prune),
+// and "prune" only deletes resources which aren't in the spec.
+//
+// "backup" writes a snapshot of the package backup to a new directory named after the time,
+// and "restore" restores a snapshot directory.
+//
+// "drift" compares two clusters or snapshots with the package drift.
+// BASE and TARGET are snapshot directories, "context:NAME" for the cluster of the context
+// or "context:" for the selected cluster.
+// With -exit-code the command fails if the drift is detected.
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
+type (
+	app struct {
+		stdout      io.Writer
+		stderr      io.Writer
+		format      string
+		configPath  string
+		contextName string
+		client      *client.Client
+	}
+
+	command struct {
+		usage string
+		run   func(ctx context.Context, a *app, args []string) error
+	}
+)
+
+const (
+	usageContexts  = "contexts list|use NAME"
+	usageStreams   = "streams list|get ID|pause ID|resume ID"
+	usageInputs    = "inputs list|get ID|start ID|stop ID"
+	usageUsers     = "users list|get USERNAME"
+	usageRoles     = "roles list|get NAME"
+	usagePipelines = "pipelines list|get ID"
+	usageIndexSets = "index-sets list|get ID"
+	usageApply     = "apply -f FILE [-prune]"
+	usageDiff      = "diff -f FILE [-prune]"
+	usagePrune     = "prune -f FILE"
+	usageBackup    = "backup [-out DIR]"
+	usageRestore   = "restore [-policy skip|overwrite] [-user-password-file FILE] [-node ID] DIR"
+	usageDrift     = "drift [-exit-code] BASE TARGET"
+	usageSearch    = "search [-range SECONDS | -from TIME -to TIME] [-limit N] [-fields a,b] [-sort FIELD:asc|desc] [-filter FILTER] QUERY"
+)
+
+var commands = map[string]command{
+	"contexts":   {usageContexts, runContexts},
+	"streams":    {usageStreams, runStreams},
+	"inputs":     {usageInputs, runInputs},
+	"users":      {usageUsers, runUsers},
+	"roles":      {usageRoles, runRoles},
+	"pipelines":  {usagePipelines, runPipelines},
+	"index-sets": {usageIndexSets, runIndexSets},
+	"search":     {usageSearch, runSearch},
+	"apply":      {usageApply, runApply},
+	"diff":       {usageDiff, runDiff},
+	"prune":      {usagePrune, runPrune},
+	"backup":     {usageBackup, runBackup},
+	"restore":    {usageRestore, runRestore},
+	"drift":      {usageDrift, runDrift},
+}
+
+func main() {
+	if err := Main(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "Usage: graylogctl [-config FILE] [-context NAME] [-o table|json|yaml] RESOURCE VERB [ARGS]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, name := range names {
+		fmt.Fprintln(w, "  "+commands[name].usage)
+	}
+}
+
+// Main runs the command with arguments except for the command name.
+func Main(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	configPath, err := defaultConfigPath()
+	if err != nil {
+		return err
+	}
+	a := &app{stdout: stdout, stderr: stderr}
+	fs := flag.NewFlagSet("graylogctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		usage(stderr)
+		fmt.Fprintln(stderr, "\nOptions:")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&a.configPath, "config", configPath, "the configuration file")
+	fs.StringVar(&a.contextName, "context", "", "the context name. The default is $GRAYLOG_CONTEXT or current_context")
+	fs.StringVar(&a.format, "o", formatTable, "the output format: table, json or yaml")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := validateFormat(a.format); err != nil {
+		return err
+	}
+	args = fs.Args()
+	if len(args) == 0 {
+		usage(stderr)
+		return fmt.Errorf("a command is required")
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(stderr)
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+	return cmd.run(ctx, a, args[1:])
+}
+
+// getClient returns the client of the selected context.
+func (a *app) getClient() (*client.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	cfg, err := readConfig(a.configPath)
+	if err != nil {
+		return nil, err
+	}
+	c, err := cfg.resolveContext(a.contextName)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+	a.client = cl
+	return cl, nil
+}
+
+// verb splits arguments to the verb and the rest, and checks the number of the rest.
+// verbs maps verbs to the numbers of their arguments.
+func verb(usage string, args []string, verbs map[string]int) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("usage: graylogctl %s", usage)
+	}
+	n, ok := verbs[args[0]]
+	if !ok || len(args)-1 != n {
+		return "", nil, fmt.Errorf("usage: graylogctl %s", usage)
+	}
+	return args[0], args[1:], nil
+}
+
+func joinStrings(a []string) string {
+	b := make([]string, len(a))
+	copy(b, a)
+	sort.Strings(b)
+	return strings.Join(b, ",")
+}
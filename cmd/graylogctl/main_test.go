@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestConfig_resolveContext(t *testing.T) {
+	for _, k := range []string{
+		"GRAYLOG_CONTEXT", "GRAYLOG_WEB_ENDPOINT_URI", "GRAYLOG_AUTH_NAME",
+		"GRAYLOG_AUTH_PASSWORD", "GRAYLOG_X_REQUESTED_BY", "GRAYLOG_API_VERSION",
+	} {
+		defer os.Setenv(k, os.Getenv(k))
+		os.Unsetenv(k)
+	}
+	cfg := &Config{
+		CurrentContext: "prod",
+		Contexts: []Context{
+			{Name: "prod", Endpoint: "https://prod.example.com/api", AuthName: "admin", AuthPassword: "prod"},
+			{Name: "dev", Endpoint: "https://dev.example.com/api", AuthName: "admin", AuthPassword: "dev"},
+		},
+	}
+
+	c, err := cfg.resolveContext("")
+	require.Nil(t, err)
+	require.Equal(t, "https://prod.example.com/api", c.Endpoint)
+
+	c, err = cfg.resolveContext("dev")
+	require.Nil(t, err)
+	require.Equal(t, "dev", c.AuthPassword)
+
+	_, err = cfg.resolveContext("foo")
+	require.NotNil(t, err)
+
+	// the environment variables override current_context
+	os.Setenv("GRAYLOG_WEB_ENDPOINT_URI", "https://local.example.com/api")
+	os.Setenv("GRAYLOG_AUTH_PASSWORD", "token")
+	c, err = cfg.resolveContext("")
+	require.Nil(t, err)
+	require.Equal(t, "https://local.example.com/api", c.Endpoint)
+	require.Equal(t, "token", c.AuthPassword)
+
+	// the environment variables don't override the context which is selected explicitly
+	c, err = cfg.resolveContext("prod")
+	require.Nil(t, err)
+	require.Equal(t, "https://prod.example.com/api", c.Endpoint)
+	require.Equal(t, "prod", c.AuthPassword)
+
+	os.Setenv("GRAYLOG_CONTEXT", "dev")
+	c, err = cfg.resolveContext("")
+	require.Nil(t, err)
+	require.Equal(t, "https://dev.example.com/api", c.Endpoint)
+	require.Equal(t, "dev", c.AuthPassword)
+	os.Unsetenv("GRAYLOG_CONTEXT")
+	os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	os.Unsetenv("GRAYLOG_AUTH_PASSWORD")
+
+	_, err = (&Config{}).resolveContext("")
+	require.NotNil(t, err, "endpoint is required")
+}
+
+func TestRunContexts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "graylogctl")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+	require.Nil(t, writeConfig(path, &Config{
+		CurrentContext: "prod",
+		Contexts: []Context{
+			{Name: "prod", Endpoint: "https://prod.example.com/api", AuthName: "admin", AuthPassword: "prod"},
+			{Name: "dev", Endpoint: "https://dev.example.com/api", AuthName: "admin", AuthPassword: "dev"},
+		},
+	}))
+
+	ctx := context.Background()
+	stdout := &bytes.Buffer{}
+	require.Nil(t, Main(ctx, []string{"-config", path, "contexts", "use", "dev"}, stdout, ioutil.Discard))
+	require.NotNil(t, Main(ctx, []string{"-config", path, "contexts", "use", "foo"}, stdout, ioutil.Discard))
+	require.Nil(t, Main(ctx, []string{"-config", path, "contexts", "list"}, stdout, ioutil.Discard))
+	require.Equal(t, `CURRENT   NAME   ENDPOINT                       AUTH NAME
+          prod   https://prod.example.com/api   admin
+*         dev    https://dev.example.com/api    admin
+`, stdout.String())
+}
+
+func TestRunStreams(t *testing.T) {
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/streams",
+							},
+							Response: &flute.Response{
+								Base: http.Response{StatusCode: 200},
+								BodyString: `{"total": 1, "streams": [{
+  "id": "5d84c1a92ab79c000d35d6ca",
+  "title": "Error Logs",
+  "index_set_id": "5d84bf242ab79c000d691b7f",
+  "disabled": true,
+  "rules": [{"id": "5d84c1a92ab79c000d35d6d7", "field": "level", "value": "error", "type": 1}]
+}]}`,
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/streams/5d84c1a92ab79c000d35d6ca/resume",
+							},
+							Response: &flute.Response{
+								Base: http.Response{StatusCode: 204},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	ctx := context.Background()
+	stdout := &bytes.Buffer{}
+	a := &app{stdout: stdout, stderr: ioutil.Discard, format: formatTable, client: cl}
+	require.Nil(t, runStreams(ctx, a, []string{"list"}))
+	require.Equal(t, `ID                         TITLE        DISABLED   INDEX SET                  RULES
+5d84c1a92ab79c000d35d6ca   Error Logs   true       5d84bf242ab79c000d691b7f   1
+`, stdout.String())
+
+	stdout.Reset()
+	a.format = formatYAML
+	require.Nil(t, runStreams(ctx, a, []string{"list"}))
+	require.Contains(t, stdout.String(), "  id: 5d84c1a92ab79c000d35d6ca\n")
+
+	require.Nil(t, runStreams(ctx, a, []string{"resume", "5d84c1a92ab79c000d35d6ca"}))
+	require.NotNil(t, runStreams(ctx, a, []string{"get"}), "get requires an id")
+	require.NotNil(t, runStreams(ctx, a, []string{"delete", "5d84c1a92ab79c000d35d6ca"}), "unknown verb")
+}
+
+func TestRunDiff(t *testing.T) {
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/roles",
+							},
+							Response: &flute.Response{
+								Base: http.Response{StatusCode: 200},
+								BodyString: `{"total": 2, "roles": [
+  {"name": "Admin", "permissions": ["*"], "read_only": true},
+  {"name": "viewer", "description": "old", "permissions": ["streams:read"], "read_only": false}
+]}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	dir, err := ioutil.TempDir("", "graylogctl")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spec.yaml")
+	require.Nil(t, ioutil.WriteFile(path, []byte(`roles:
+- name: viewer
+  description: new
+  permissions: ["streams:read"]
+`), 0644))
+
+	ctx := context.Background()
+	stdout := &bytes.Buffer{}
+	a := &app{stdout: stdout, stderr: ioutil.Discard, format: formatTable, client: cl}
+	require.Nil(t, runDiff(ctx, a, []string{"-f", path, "-prune"}))
+	require.Equal(t, `~ role "viewer"
+    description: "old" => "new"
+0 to create, 1 to update, 0 to delete
+`, stdout.String())
+
+	require.NotNil(t, runDiff(ctx, a, []string{}), "-f is required")
+}
+
+func TestRunDrift(t *testing.T) {
+	dir, err := ioutil.TempDir("", "graylogctl")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	base := filepath.Join(dir, "base")
+	target := filepath.Join(dir, "target")
+	require.Nil(t, (&backup.Snapshot{
+		IndexSets: []graylog.IndexSet{{ID: "is1", Title: "app", IndexPrefix: "app", Shards: 4}},
+	}).Write(base))
+	require.Nil(t, (&backup.Snapshot{
+		IndexSets: []graylog.IndexSet{{ID: "is2", Title: "app", IndexPrefix: "app", Shards: 2}},
+	}).Write(target))
+
+	ctx := context.Background()
+	stdout := &bytes.Buffer{}
+	a := &app{stdout: stdout, stderr: ioutil.Discard, format: formatTable}
+	require.Nil(t, runDrift(ctx, a, []string{base, target}))
+	require.Equal(t, `~ index_sets "app"
+    shards: 4 => 2
+0 added, 0 removed, 1 changed
+`, stdout.String())
+
+	require.NotNil(t, runDrift(ctx, a, []string{"-exit-code", base, target}), "the drift is detected")
+	require.Nil(t, runDrift(ctx, a, []string{"-exit-code", base, base}))
+	require.NotNil(t, runDrift(ctx, a, []string{base}), "two arguments are required")
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// table is the table format of a value.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return nil
+	}
+	return fmt.Errorf("the output format must be one of table, json and yaml: %s", format)
+}
+
+// output writes a value in the format.
+// newTable is called only if the format is table.
+// YAML keys are the same as JSON keys because the value is converted through JSON.
+func output(w io.Writer, format string, v interface{}, newTable func() *table) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case formatYAML:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var a interface{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(a); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	return writeTable(w, newTable())
+}
+
+func writeTable(w io.Writer, t *table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(tw, strings.Join(t.header, "\t")); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			// a line break breaks the table
+			cells[i] = strings.NewReplacer("\n", " ", "\t", " ").Replace(cell)
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
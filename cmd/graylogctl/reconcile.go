@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/reconciler"
+)
+
+// parseSpecArgs parses the arguments of apply, diff and prune and reads the spec.
+func parseSpecArgs(name, usage string, args []string, prune *bool) (*reconciler.Spec, error) {
+	var path string
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&path, "f", "", "the spec file in YAML or JSON")
+	if prune != nil {
+		fs.BoolVar(prune, "prune", false, "delete resources of the kinds in the spec which aren't in the spec")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if path == "" || fs.NArg() != 0 {
+		return nil, errors.New("usage: graylogctl " + usage)
+	}
+	return reconciler.ReadSpecFile(path)
+}
+
+// plan returns the plan of the spec.
+func (a *app) plan(ctx context.Context, spec *reconciler.Spec, prune bool) (*reconciler.Reconciler, *reconciler.Plan, error) {
+	cl, err := a.getClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &reconciler.Reconciler{Client: cl, Prune: prune}
+	plan, err := r.Plan(ctx, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, plan, nil
+}
+
+// writePlan writes the plan as the diff in the table format.
+func (a *app) writePlan(plan *reconciler.Plan) error {
+	if a.format == formatTable {
+		_, err := plan.WriteTo(a.stdout)
+		return err
+	}
+	return output(a.stdout, a.format, plan, nil)
+}
+
+// applyPlan writes and applies the plan.
+// In the table format the diff is written to stdout and the progress is written to stderr.
+func (a *app) applyPlan(ctx context.Context, r *reconciler.Reconciler, plan *reconciler.Plan) error {
+	if err := a.writePlan(plan); err != nil {
+		return err
+	}
+	if plan.Empty() {
+		return nil
+	}
+	if err := r.Apply(ctx, plan); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "applied %d changes\n", len(plan.Changes))
+	return nil
+}
+
+func runDiff(ctx context.Context, a *app, args []string) error {
+	prune := false
+	spec, err := parseSpecArgs("diff", usageDiff, args, &prune)
+	if err != nil {
+		return err
+	}
+	_, plan, err := a.plan(ctx, spec, prune)
+	if err != nil {
+		return err
+	}
+	return a.writePlan(plan)
+}
+
+func runApply(ctx context.Context, a *app, args []string) error {
+	prune := false
+	spec, err := parseSpecArgs("apply", usageApply, args, &prune)
+	if err != nil {
+		return err
+	}
+	r, plan, err := a.plan(ctx, spec, prune)
+	if err != nil {
+		return err
+	}
+	return a.applyPlan(ctx, r, plan)
+}
+
+// runPrune only deletes resources which aren't in the spec.
+func runPrune(ctx context.Context, a *app, args []string) error {
+	spec, err := parseSpecArgs("prune", usagePrune, args, nil)
+	if err != nil {
+		return err
+	}
+	r, plan, err := a.plan(ctx, spec, true)
+	if err != nil {
+		return err
+	}
+	return a.applyPlan(ctx, r, plan.Filter(reconciler.ActionDelete))
+}
@@ -1,16 +1,62 @@
 package main
 
+// terraform-provider-graylog serves the terraform provider for Graylog.
+//
+// The provider serves the plugin protocol version 5, which Terraform v0.12.26 and later support.
+// With -protocol 6 or $GRAYLOG_PROVIDER_PROTOCOL=6 it serves the protocol version 6 for Terraform v1.0 and later.
+// With -debug it runs in the debug mode and prints $TF_REATTACH_PROVIDERS for Terraform.
+
 import (
-	"github.com/hashicorp/terraform/plugin"
-	"github.com/hashicorp/terraform/terraform"
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
 	graylog "github.com/suzuki-shunsuke/go-graylog/v11/graylog/terraform"
 )
 
+const providerAddr = "registry.terraform.io/suzuki-shunsuke/graylog"
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() terraform.ResourceProvider {
-			return graylog.Provider()
-		},
-	})
+	var (
+		debug    bool
+		protocol string
+	)
+	defaultProtocol := os.Getenv("GRAYLOG_PROVIDER_PROTOCOL")
+	if defaultProtocol == "" {
+		defaultProtocol = "5"
+	}
+	flag.BoolVar(&debug, "debug", false, "run the provider in the debug mode for debuggers such as delve")
+	flag.StringVar(&protocol, "protocol", defaultProtocol, "the plugin protocol version: 5 or 6")
+	flag.Parse()
+
+	switch protocol {
+	case "5":
+		plugin.Serve(&plugin.ServeOpts{
+			ProviderFunc: graylog.Provider,
+			ProviderAddr: providerAddr,
+			Debug:        debug,
+		})
+	case "6":
+		server, err := tf5to6server.UpgradeServer(context.Background(), graylog.Provider().GRPCProvider)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var opts []tf6server.ServeOpt
+		if debug {
+			opts = append(opts, tf6server.WithManagedDebug())
+		}
+		if err := tf6server.Serve(providerAddr, func() tfprotov6.ProviderServer {
+			return server
+		}, opts...); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("the protocol version must be 5 or 6: %s", protocol)
+	}
 }
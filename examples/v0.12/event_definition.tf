@@ -88,3 +88,38 @@ resource "graylog_event_definition" "test2" {
     backlog_size    = 0
   }
 }
+
+resource "graylog_event_definition" "test3" {
+  title    = "new-event-definition 3"
+  priority = 2
+
+  aggregation {
+    query         = "test"
+    streams       = [graylog_stream.test.id]
+    group_by      = ["alert"]
+    search_within = "5m"
+    execute_every = "1m"
+
+    series {
+      id       = "avg-alert"
+      function = "avg"
+      field    = "alert"
+    }
+
+    conditions = "avg-alert > 0"
+  }
+
+  field {
+    name = "test"
+
+    provider {
+      type     = "template-v1"
+      template = "test"
+    }
+  }
+
+  notification_settings {
+    grace_period_ms = 0
+    backlog_size    = 0
+  }
+}
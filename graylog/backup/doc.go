@@ -0,0 +1,22 @@
+/*
+Package backup takes snapshots of a cluster's configuration through the API client and restores them.
+
+A snapshot is written to a directory which has a JSON file per kind and "manifest.json".
+The manifest has the format version, the creation time and the checksum and the number of entities of each file.
+
+	20200101T000000Z/
+	  manifest.json
+	  index_sets.json
+	  streams.json
+	  ...
+
+Restorer recreates entities in the dependency order:
+index sets, streams, stream rules, outputs, stream outputs, grok patterns, inputs, extractors,
+pipeline rules, pipelines, pipeline connections, roles, users, event notifications and event definitions.
+Entities are matched with existing ones by the title or the name, and ids in references are remapped to the new ids.
+Existing entities are skipped or overwritten according to the policy.
+
+Dashboards, legacy alert conditions and alarm callbacks, LDAP settings and user tokens aren't included.
+Passwords of users aren't included because Graylog doesn't return them.
+*/
+package backup
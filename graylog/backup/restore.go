@@ -0,0 +1,208 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
+// Policy decides how existing entities are restored.
+type Policy string
+
+const (
+	// PolicySkip keeps existing entities and their sub entities such as stream rules.
+	PolicySkip Policy = "skip"
+	// PolicyOverwrite updates existing entities with the snapshot.
+	// Stream rules, stream outputs and pipeline connections of existing streams are replaced.
+	PolicyOverwrite Policy = "overwrite"
+)
+
+// Action is the result of restoring an entity.
+type Action string
+
+const (
+	// ActionCreated means the entity was created.
+	ActionCreated Action = "created"
+	// ActionOverwritten means the existing entity was updated.
+	ActionOverwritten Action = "overwritten"
+	// ActionSkipped means the entity wasn't restored.
+	ActionSkipped Action = "skipped"
+	// ActionFailed means it failed to restore the entity.
+	ActionFailed Action = "failed"
+)
+
+const (
+	allEventsStreamID             = "000000000000000000000002"
+	allSystemEventsStreamID       = "000000000000000000000003"
+	systemNotificationsConfigType = "system-notifications-v1"
+)
+
+type (
+	// Restorer restores snapshots through the client.
+	Restorer struct {
+		Client *client.Client
+		// Policy is the policy for existing entities. The default is PolicySkip.
+		Policy Policy
+		// UserPassword is the password of created users, because snapshots don't have passwords.
+		// If it is empty, users which don't exist aren't restored.
+		UserPassword string
+		// Node is the node id which non global inputs are started on.
+		// If it is empty, the node in the snapshot is used, which doesn't exist on another cluster.
+		Node string
+	}
+
+	// Result is the result of restoring.
+	Result struct {
+		Entries []Entry
+	}
+
+	// Entry is the result of restoring an entity.
+	Entry struct {
+		Kind   string
+		Key    string
+		OldID  string
+		NewID  string
+		Action Action
+		// Reason is the reason why the entity was skipped.
+		Reason string
+		Err    error
+	}
+
+	// restorer has the state of a restore.
+	restorer struct {
+		*Restorer
+		snap   *Snapshot
+		result *Result
+		// ids maps the kind and the old id to the new id.
+		ids map[string]map[string]string
+		// actions maps the kind and the old id to the action, which sub entities follow.
+		actions map[string]map[string]Action
+	}
+)
+
+// Failed returns the entries which failed.
+func (result *Result) Failed() []Entry {
+	entries := []Entry{}
+	for _, e := range result.Entries {
+		if e.Action == ActionFailed {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Err returns an error if any entity failed.
+func (result *Result) Err() error {
+	failed := result.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"failed to restore %d entities. the first error: %s '%s': %w",
+		len(failed), failed[0].Kind, failed[0].Key, failed[0].Err)
+}
+
+// Restore restores the snapshot.
+// Failures of entities are recorded in the result and the restore continues,
+// so that as many entities as possible are restored.
+// Entities which refer to failed entities fail too.
+// An error is returned only if it fails to get the current entities.
+func (r *Restorer) Restore(ctx context.Context, snap *Snapshot) (*Result, error) {
+	if r.Client == nil {
+		return nil, errors.New("client is nil")
+	}
+	if snap == nil {
+		return nil, errors.New("snapshot is nil")
+	}
+	switch r.Policy {
+	case "", PolicySkip, PolicyOverwrite:
+	default:
+		return nil, fmt.Errorf("invalid policy: %s", r.Policy)
+	}
+	rs := &restorer{
+		Restorer: r,
+		snap:     snap,
+		result:   &Result{Entries: []Entry{}},
+		ids:      map[string]map[string]string{},
+		actions:  map[string]map[string]Action{},
+	}
+	steps := []struct {
+		kind string
+		fn   func(context.Context) error
+	}{
+		{"index_sets", rs.restoreIndexSets},
+		{"streams", rs.restoreStreams},
+		{"stream_rules", rs.restoreStreamRules},
+		{"outputs", rs.restoreOutputs},
+		{"stream_outputs", rs.restoreStreamOutputs},
+		{"grok_patterns", rs.restoreGrokPatterns},
+		{"inputs", rs.restoreInputs},
+		{"extractors", rs.restoreExtractors},
+		{"pipeline_rules", rs.restorePipelineRules},
+		{"pipelines", rs.restorePipelines},
+		{"pipeline_connections", rs.restorePipelineConnections},
+		{"roles", rs.restoreRoles},
+		{"users", rs.restoreUsers},
+		{"event_notifications", rs.restoreEventNotifications},
+		{"event_definitions", rs.restoreEventDefinitions},
+	}
+	for _, step := range steps {
+		if err := step.fn(ctx); err != nil {
+			return rs.result, fmt.Errorf("failed to restore %s: %w", step.kind, err)
+		}
+	}
+	return rs.result, nil
+}
+
+func (rs *restorer) overwrite() bool {
+	return rs.Policy == PolicyOverwrite
+}
+
+// record records the result of an entity and the id mapping.
+func (rs *restorer) record(kind, key, oldID, newID string, action Action, err error) {
+	if err != nil {
+		action = ActionFailed
+	}
+	rs.result.Entries = append(rs.result.Entries, Entry{
+		Kind: kind, Key: key, OldID: oldID, NewID: newID, Action: action, Err: err,
+	})
+	if action == ActionFailed || oldID == "" {
+		return
+	}
+	if _, ok := rs.ids[kind]; !ok {
+		rs.ids[kind] = map[string]string{}
+		rs.actions[kind] = map[string]Action{}
+	}
+	rs.ids[kind][oldID] = newID
+	rs.actions[kind][oldID] = action
+}
+
+// skip records a skipped entity which isn't mapped to any entity.
+func (rs *restorer) skip(kind, key, oldID, reason string) {
+	rs.result.Entries = append(rs.result.Entries, Entry{
+		Kind: kind, Key: key, OldID: oldID, Action: ActionSkipped, Reason: reason,
+	})
+}
+
+// id returns the new id of the entity.
+func (rs *restorer) id(kind, oldID string) (string, error) {
+	if id, ok := rs.ids[kind][oldID]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("the referred entity of %s %s isn't restored", kind, oldID)
+}
+
+// action returns the action of the entity or "" if the entity isn't restored.
+func (rs *restorer) action(kind, oldID string) Action {
+	return rs.actions[kind][oldID]
+}
+
+// existingAction returns the action for an existing entity.
+func (rs *restorer) existingAction() Action {
+	if rs.overwrite() {
+		return ActionOverwritten
+	}
+	return ActionSkipped
+}
@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func (rs *restorer) restoreEventNotifications(ctx context.Context) error {
+	body, _, err := rs.Client.GetEventNotifications(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.EventNotification, len(body.EventNotifications))
+	for _, notif := range body.EventNotifications {
+		cur[notif.Title] = notif
+	}
+	for _, notif := range rs.snap.EventNotifications {
+		oldID := notif.ID
+		if c, ok := cur[notif.Title]; ok {
+			var err error
+			if rs.overwrite() {
+				notif.ID = c.ID
+				_, err = rs.Client.UpdateEventNotification(ctx, &notif)
+			}
+			rs.record("event_notifications", notif.Title, oldID, c.ID, rs.existingAction(), err)
+			continue
+		}
+		notif.ID = ""
+		_, err := rs.Client.CreateEventNotification(ctx, &notif)
+		rs.record("event_notifications", notif.Title, oldID, notif.ID, ActionCreated, err)
+	}
+	return nil
+}
+
+// remapEventDefinition replaces the ids of streams and notifications with the new ids.
+func (rs *restorer) remapEventDefinition(definition *graylog.EventDefinition) error {
+	notifs := make([]graylog.EventDefinitionNotification, len(definition.Notifications))
+	for i, n := range definition.Notifications {
+		id, err := rs.id("event_notifications", n.NotificationID)
+		if err != nil {
+			return err
+		}
+		n.NotificationID = id
+		notifs[i] = n
+	}
+	definition.Notifications = notifs
+	cfg, ok := definition.Config.(*graylog.EventDefinitionConfigAggregation)
+	if !ok {
+		return nil
+	}
+	c := *cfg
+	c.Streams = make([]string, len(cfg.Streams))
+	for i, oldID := range cfg.Streams {
+		id, err := rs.id("streams", oldID)
+		if err != nil {
+			return err
+		}
+		c.Streams[i] = id
+	}
+	definition.Config = &c
+	return nil
+}
+
+// restoreEventDefinitions restores event definitions except for the built-in system notifications.
+func (rs *restorer) restoreEventDefinitions(ctx context.Context) error {
+	body, _, err := rs.Client.GetEventDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.EventDefinition, len(body.EventDefinitions))
+	for _, definition := range body.EventDefinitions {
+		cur[definition.Title] = definition
+	}
+	for _, definition := range rs.snap.EventDefinitions {
+		oldID := definition.ID
+		if definition.Config != nil && definition.Config.Type() == systemNotificationsConfigType {
+			rs.skip("event_definitions", definition.Title, oldID, "built-in system notifications")
+			continue
+		}
+		c, exists := cur[definition.Title]
+		if exists && !rs.overwrite() {
+			rs.record("event_definitions", definition.Title, oldID, c.ID, ActionSkipped, nil)
+			continue
+		}
+		if err := rs.remapEventDefinition(&definition); err != nil {
+			rs.record("event_definitions", definition.Title, oldID, "", ActionFailed, err)
+			continue
+		}
+		if exists {
+			definition.ID = c.ID
+			_, err := rs.Client.UpdateEventDefinition(ctx, &definition)
+			rs.record("event_definitions", definition.Title, oldID, c.ID, ActionOverwritten, err)
+			continue
+		}
+		definition.ID = ""
+		_, err := rs.Client.CreateEventDefinition(ctx, &definition)
+		rs.record("event_definitions", definition.Title, oldID, definition.ID, ActionCreated, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+func (rs *restorer) restoreGrokPatterns(ctx context.Context) error {
+	patterns, _, err := rs.Client.GetGrokPatterns(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.GrokPattern, len(patterns))
+	for _, pattern := range patterns {
+		cur[pattern.Name] = pattern
+	}
+	for _, pattern := range rs.snap.GrokPatterns {
+		oldID := pattern.ID
+		if c, ok := cur[pattern.Name]; ok {
+			var err error
+			if rs.overwrite() {
+				pattern.ID = c.ID
+				_, err = rs.Client.UpdateGrokPattern(ctx, &pattern)
+			}
+			rs.record("grok_patterns", pattern.Name, oldID, c.ID, rs.existingAction(), err)
+			continue
+		}
+		pattern.ID = ""
+		_, err := rs.Client.CreateGrokPattern(ctx, &pattern)
+		rs.record("grok_patterns", pattern.Name, oldID, pattern.ID, ActionCreated, err)
+	}
+	return nil
+}
+
+// newInput returns the input to be created or updated.
+func (rs *restorer) newInput(input *graylog.Input) (*graylog.Input, error) {
+	d, err := input.ToData()
+	if err != nil {
+		return nil, err
+	}
+	d.ID = ""
+	d.CreatedAt = ""
+	d.CreatorUserID = ""
+	if !d.Global && rs.Node != "" {
+		d.Node = rs.Node
+	}
+	in := &graylog.Input{}
+	if err := d.ToInput(in); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+func (rs *restorer) restoreInputs(ctx context.Context) error {
+	inputs, _, _, err := rs.Client.GetInputs(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.Input, len(inputs))
+	for _, input := range inputs {
+		cur[input.Title] = input
+	}
+	for i := range rs.snap.Inputs {
+		input := &rs.snap.Inputs[i]
+		c, exists := cur[input.Title]
+		if exists && !rs.overwrite() {
+			rs.record("inputs", input.Title, input.ID, c.ID, ActionSkipped, nil)
+			continue
+		}
+		in, err := rs.newInput(input)
+		if err != nil {
+			rs.record("inputs", input.Title, input.ID, "", ActionFailed, err)
+			continue
+		}
+		action := ActionCreated
+		if exists {
+			action = ActionOverwritten
+			in.ID = c.ID
+			_, _, err = rs.Client.UpdateInput(ctx, in.NewUpdateParams())
+		} else {
+			_, err = rs.Client.CreateInput(ctx, in)
+		}
+		if err == nil {
+			err = rs.restoreStaticFields(ctx, in.ID, input.StaticFields)
+		}
+		rs.record("inputs", input.Title, input.ID, in.ID, action, err)
+	}
+	return nil
+}
+
+func (rs *restorer) restoreStaticFields(ctx context.Context, inputID string, fields map[string]string) error {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	for _, k := range util.SortedStrings(keys) {
+		if _, err := rs.Client.CreateInputStaticField(ctx, inputID, k, fields[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreExtractors creates the extractors of created inputs.
+// Extractors of overwritten inputs are matched by the title and updated or created.
+func (rs *restorer) restoreExtractors(ctx context.Context) error {
+	for _, input := range rs.snap.Inputs {
+		action := rs.action("inputs", input.ID)
+		if action != ActionCreated && action != ActionOverwritten {
+			continue
+		}
+		inputID, err := rs.id("inputs", input.ID)
+		if err != nil {
+			return err
+		}
+		cur := map[string]string{}
+		if action == ActionOverwritten {
+			extractors, _, _, err := rs.Client.GetExtractors(ctx, inputID)
+			if err != nil {
+				return err
+			}
+			for _, extractor := range extractors {
+				cur[extractor.Title] = extractor.ID
+			}
+		}
+		for _, extractor := range rs.snap.Extractors[input.ID] {
+			oldID := extractor.ID
+			key := input.Title + "/" + extractor.Title
+			extractor.Metrics = nil
+			extractor.Exceptions = 0
+			extractor.ConverterExceptions = 0
+			extractor.CreatorUserID = ""
+			if id, ok := cur[extractor.Title]; ok {
+				extractor.ID = id
+				_, err := rs.Client.UpdateExtractor(ctx, inputID, &extractor)
+				rs.record("extractors", key, oldID, id, ActionOverwritten, err)
+				continue
+			}
+			extractor.ID = ""
+			_, err := rs.Client.CreateExtractor(ctx, inputID, &extractor)
+			rs.record("extractors", key, oldID, extractor.ID, ActionCreated, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+func (rs *restorer) restorePipelineRules(ctx context.Context) error {
+	rules, _, err := rs.Client.GetPipelineRules(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.PipelineRule, len(rules))
+	for _, rule := range rules {
+		cur[rule.Title] = rule
+	}
+	for _, rule := range rs.snap.PipelineRules {
+		oldID := rule.ID
+		if c, ok := cur[rule.Title]; ok {
+			var err error
+			if rs.overwrite() {
+				rule.ID = c.ID
+				_, err = rs.Client.UpdatePipelineRule(ctx, &rule)
+			}
+			rs.record("pipeline_rules", rule.Title, oldID, c.ID, rs.existingAction(), err)
+			continue
+		}
+		rule.ID = ""
+		_, err := rs.Client.CreatePipelineRule(ctx, &rule)
+		rs.record("pipeline_rules", rule.Title, oldID, rule.ID, ActionCreated, err)
+	}
+	return nil
+}
+
+func (rs *restorer) restorePipelines(ctx context.Context) error {
+	pipes, _, err := rs.Client.GetPipelines(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.Pipeline, len(pipes))
+	for _, pipe := range pipes {
+		cur[pipe.Title] = pipe
+	}
+	for _, pipe := range rs.snap.Pipelines {
+		oldID := pipe.ID
+		// stages are created from the source
+		pipe.Stages = nil
+		if c, ok := cur[pipe.Title]; ok {
+			var err error
+			if rs.overwrite() {
+				pipe.ID = c.ID
+				_, err = rs.Client.UpdatePipeline(ctx, &pipe)
+			}
+			rs.record("pipelines", pipe.Title, oldID, c.ID, rs.existingAction(), err)
+			continue
+		}
+		pipe.ID = ""
+		_, err := rs.Client.CreatePipeline(ctx, &pipe)
+		rs.record("pipelines", pipe.Title, oldID, pipe.ID, ActionCreated, err)
+	}
+	return nil
+}
+
+// restorePipelineConnections connects pipelines to created and overwritten streams.
+// The connected pipelines of overwritten streams are replaced.
+func (rs *restorer) restorePipelineConnections(ctx context.Context) error {
+	for _, conn := range rs.snap.PipelineConnections {
+		action := rs.action("streams", conn.StreamID)
+		if action != ActionCreated && action != ActionOverwritten {
+			continue
+		}
+		streamID, err := rs.id("streams", conn.StreamID)
+		if err != nil {
+			return err
+		}
+		ids := make([]string, 0, len(conn.PipelineIDs))
+		for _, oldID := range conn.PipelineIDs {
+			id, e := rs.id("pipelines", oldID)
+			if e != nil {
+				err = e
+				break
+			}
+			ids = append(ids, id)
+		}
+		if err == nil {
+			_, err = rs.Client.ConnectPipelinesToStream(ctx, &graylog.PipelineConnection{
+				StreamID: streamID, PipelineIDs: util.SortedStrings(ids),
+			})
+		}
+		rs.record("pipeline_connections", rs.streamTitle(conn.StreamID), "", streamID, action, err)
+	}
+	return nil
+}
+
+func (rs *restorer) streamTitle(id string) string {
+	for _, stream := range rs.snap.Streams {
+		if stream.ID == id {
+			return stream.Title
+		}
+	}
+	return id
+}
@@ -0,0 +1,238 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+func (rs *restorer) restoreIndexSets(ctx context.Context) error {
+	indexSets, _, _, _, err := rs.Client.GetIndexSets(ctx, 0, 0, false)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.IndexSet, len(indexSets))
+	for _, is := range indexSets {
+		cur[is.Title] = is
+	}
+	for _, is := range rs.snap.IndexSets {
+		oldID := is.ID
+		if c, ok := cur[is.Title]; ok {
+			var err error
+			if rs.overwrite() {
+				is.ID = c.ID
+				_, _, err = rs.Client.UpdateIndexSet(ctx, is.NewUpdateParams())
+			}
+			rs.record("index_sets", is.Title, oldID, c.ID, rs.existingAction(), err)
+			continue
+		}
+		// the default index set isn't changed
+		is.ID = ""
+		is.CreationDate = ""
+		is.Default = false
+		_, err := rs.Client.CreateIndexSet(ctx, &is)
+		rs.record("index_sets", is.Title, oldID, is.ID, ActionCreated, err)
+	}
+	return nil
+}
+
+func isBuiltinStream(stream *graylog.Stream) bool {
+	return stream.IsDefault || stream.ID == allEventsStreamID || stream.ID == allSystemEventsStreamID
+}
+
+func (rs *restorer) restoreStreams(ctx context.Context) error {
+	streams, _, _, err := rs.Client.GetStreams(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.Stream, len(streams))
+	for _, stream := range streams {
+		cur[stream.Title] = stream
+	}
+	for _, stream := range rs.snap.Streams {
+		if isBuiltinStream(&stream) {
+			// built-in streams have the same ids in all clusters.
+			// Their settings aren't changed, but their outputs and pipeline connections follow the policy.
+			rs.record("streams", stream.Title, stream.ID, stream.ID, rs.existingAction(), nil)
+			continue
+		}
+		c, exists := cur[stream.Title]
+		if exists && !rs.overwrite() {
+			rs.record("streams", stream.Title, stream.ID, c.ID, ActionSkipped, nil)
+			continue
+		}
+		isID, err := rs.id("index_sets", stream.IndexSetID)
+		if err != nil {
+			rs.record("streams", stream.Title, stream.ID, "", ActionFailed, err)
+			continue
+		}
+		s := &graylog.Stream{
+			Title:                          stream.Title,
+			Description:                    stream.Description,
+			IndexSetID:                     isID,
+			MatchingType:                   stream.MatchingType,
+			RemoveMatchesFromDefaultStream: stream.RemoveMatchesFromDefaultStream,
+		}
+		if exists {
+			s.ID = c.ID
+			err := rs.updateStream(ctx, s, c.Disabled, stream.Disabled)
+			rs.record("streams", stream.Title, stream.ID, c.ID, ActionOverwritten, err)
+			continue
+		}
+		if _, err := rs.Client.CreateStream(ctx, s); err != nil {
+			rs.record("streams", stream.Title, stream.ID, "", ActionFailed, err)
+			continue
+		}
+		if !stream.Disabled {
+			_, err = rs.Client.ResumeStream(ctx, s.ID)
+		}
+		rs.record("streams", stream.Title, stream.ID, s.ID, ActionCreated, err)
+	}
+	return nil
+}
+
+func (rs *restorer) updateStream(ctx context.Context, stream *graylog.Stream, disabled, desired bool) error {
+	if _, err := rs.Client.UpdateStream(ctx, stream); err != nil {
+		return err
+	}
+	if disabled == desired {
+		return nil
+	}
+	var err error
+	if desired {
+		_, err = rs.Client.PauseStream(ctx, stream.ID)
+	} else {
+		_, err = rs.Client.ResumeStream(ctx, stream.ID)
+	}
+	return err
+}
+
+// restoreStreamRules creates the rules of created streams and replaces the rules of overwritten streams.
+func (rs *restorer) restoreStreamRules(ctx context.Context) error {
+	for _, stream := range rs.snap.Streams {
+		action := rs.action("streams", stream.ID)
+		if action != ActionCreated && (action != ActionOverwritten || isBuiltinStream(&stream)) {
+			continue
+		}
+		streamID, err := rs.id("streams", stream.ID)
+		if err != nil {
+			return err
+		}
+		if action == ActionOverwritten {
+			if err := rs.deleteStreamRules(ctx, streamID); err != nil {
+				// the rules aren't created, otherwise they are duplicated
+				rs.record("stream_rules", stream.Title, "", "", ActionFailed, err)
+				continue
+			}
+		}
+		for _, rule := range stream.Rules {
+			oldID := rule.ID
+			rule.ID = ""
+			rule.StreamID = streamID
+			_, err := rs.Client.CreateStreamRule(ctx, &rule)
+			key := fmt.Sprintf("%s/%s %d %s", stream.Title, rule.Field, rule.Type, rule.Value)
+			rs.record("stream_rules", key, oldID, rule.ID, ActionCreated, err)
+		}
+	}
+	return nil
+}
+
+// deleteStreamRules deletes the current rules of an overwritten stream.
+func (rs *restorer) deleteStreamRules(ctx context.Context, streamID string) error {
+	rules, _, _, err := rs.Client.GetStreamRules(ctx, streamID)
+	if err != nil {
+		return fmt.Errorf("failed to get the current stream rules: %w", err)
+	}
+	for _, rule := range rules {
+		if _, err := rs.Client.DeleteStreamRule(ctx, streamID, rule.ID); err != nil {
+			return fmt.Errorf("failed to delete the current stream rule %s: %w", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+func (rs *restorer) restoreOutputs(ctx context.Context) error {
+	outputs, _, _, err := rs.Client.GetOutputs(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.Output, len(outputs))
+	for _, output := range outputs {
+		cur[output.Title] = output
+	}
+	for _, output := range rs.snap.Outputs {
+		oldID := output.ID
+		if c, ok := cur[output.Title]; ok {
+			var err error
+			if rs.overwrite() {
+				output.ID = c.ID
+				_, err = rs.Client.UpdateOutput(ctx, &output)
+			}
+			rs.record("outputs", output.Title, oldID, c.ID, rs.existingAction(), err)
+			continue
+		}
+		output.ID = ""
+		_, err := rs.Client.CreateOutput(ctx, &output)
+		rs.record("outputs", output.Title, oldID, output.ID, ActionCreated, err)
+	}
+	return nil
+}
+
+// restoreStreamOutputs adds outputs to created and overwritten streams.
+// Outputs which aren't in the snapshot are removed from overwritten streams.
+func (rs *restorer) restoreStreamOutputs(ctx context.Context) error {
+	for _, stream := range rs.snap.Streams {
+		action := rs.action("streams", stream.ID)
+		if action != ActionCreated && action != ActionOverwritten {
+			continue
+		}
+		streamID, err := rs.id("streams", stream.ID)
+		if err != nil {
+			return err
+		}
+		desired := map[string]struct{}{}
+		var refErr error
+		for _, oldID := range rs.snap.StreamOutputs[stream.ID] {
+			id, err := rs.id("outputs", oldID)
+			if err != nil {
+				refErr = err
+				continue
+			}
+			desired[id] = struct{}{}
+		}
+		current := map[string]struct{}{}
+		if action == ActionOverwritten {
+			outputs, _, _, err := rs.Client.GetStreamOutputs(ctx, streamID)
+			if err != nil {
+				rs.record("stream_outputs", stream.Title, "", streamID, ActionFailed, err)
+				continue
+			}
+			for _, output := range outputs {
+				current[output.ID] = struct{}{}
+			}
+		}
+		added := []string{}
+		for id := range desired {
+			if _, ok := current[id]; !ok {
+				added = append(added, id)
+			}
+		}
+		if len(current) == 0 && len(added) == 0 && refErr == nil {
+			continue
+		}
+		err = refErr
+		if err == nil && len(added) != 0 {
+			_, err = rs.Client.CreateStreamOutputs(ctx, streamID, util.SortedStrings(added))
+		}
+		for id := range current {
+			if _, ok := desired[id]; ok || err != nil {
+				continue
+			}
+			_, err = rs.Client.DeleteStreamOutput(ctx, streamID, id)
+		}
+		rs.record("stream_outputs", stream.Title, "", streamID, action, err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package backup_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+const pipelinePrefix = "/api/plugins/org.graylog.plugins.pipelineprocessor/system/pipelines"
+
+func route(method, path, reqBody string, status int, body string) flute.Route {
+	return flute.Route{
+		Matcher:  &flute.Matcher{Method: method, Path: path, BodyJSONString: reqBody},
+		Response: &flute.Response{Base: http.Response{StatusCode: status}, BodyString: body},
+	}
+}
+
+func TestRestorer_Restore(t *testing.T) {
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						route("GET", "/api/system/indices/index_sets", "", 200,
+							`{"total": 1, "index_sets": [{"id": "new-is", "title": "app"}], "stats": {}}`),
+						route("GET", "/api/streams", "", 200, `{"total": 0, "streams": []}`),
+						// the index set id is remapped
+						route("POST", "/api/streams",
+							`{"title": "app", "index_set_id": "new-is", "matching_type": "AND", "remove_matches_from_default_stream": false}`,
+							201, `{"stream_id": "new-s"}`),
+						route("POST", "/api/streams/new-s/resume", "", 204, ""),
+						route("POST", "/api/streams/new-s/rules",
+							`{"field": "tag", "value": "app", "type": 1, "inverted": false}`,
+							201, `{"streamrule_id": "new-r"}`),
+						route("GET", "/api/system/outputs", "", 200, `{"total": 0, "outputs": []}`),
+						route("GET", "/api/system/grok", "", 200, `{"patterns": []}`),
+						route("GET", "/api/system/inputs", "", 200, `{"total": 0, "inputs": []}`),
+						route("GET", pipelinePrefix+"/rule", "", 200, `[]`),
+						route("GET", pipelinePrefix+"/pipeline", "", 200, `[]`),
+						route("POST", pipelinePrefix+"/pipeline", "", 200,
+							`{"id": "new-p", "title": "p", "source": "pipeline \"p\"\nend"}`),
+						// the stream id and the pipeline id are remapped
+						route("POST", pipelinePrefix+"/connections/to_stream",
+							`{"id": "", "stream_id": "new-s", "pipeline_ids": ["new-p"]}`,
+							200, `{"id": "c1", "stream_id": "new-s", "pipeline_ids": ["new-p"]}`),
+						route("GET", "/api/roles", "", 200, `{"total": 0, "roles": []}`),
+						route("GET", "/api/users", "", 200, `{"users": []}`),
+						route("GET", "/api/events/notifications", "", 200, `{"notifications": []}`),
+						route("GET", "/api/events/definitions", "", 200, `{"event_definitions": []}`),
+					},
+				},
+			},
+		},
+	})
+
+	snap := &backup.Snapshot{
+		IndexSets: []graylog.IndexSet{{ID: "old-is", Title: "app", IndexPrefix: "app"}},
+		Streams: []graylog.Stream{{
+			ID: "old-s", Title: "app", IndexSetID: "old-is", MatchingType: "AND",
+			Rules: []graylog.StreamRule{{ID: "old-r", StreamID: "old-s", Field: "tag", Value: "app", Type: 1}},
+		}},
+		Pipelines:           []graylog.Pipeline{{ID: "old-p", Title: "p", Source: "pipeline \"p\"\nend"}},
+		PipelineConnections: []graylog.PipelineConnection{{StreamID: "old-s", PipelineIDs: []string{"old-p"}}},
+		Roles:               []graylog.Role{{Name: "Admin", ReadOnly: true}},
+		Users:               []graylog.User{{ID: "old-u", Username: "foo"}},
+	}
+	r := &backup.Restorer{Client: cl}
+	result, err := r.Restore(context.Background(), snap)
+	require.Nil(t, err)
+	require.Nil(t, result.Err())
+
+	type entry struct {
+		kind   string
+		key    string
+		newID  string
+		action backup.Action
+	}
+	entries := make([]entry, len(result.Entries))
+	for i, e := range result.Entries {
+		entries[i] = entry{e.Kind, e.Key, e.NewID, e.Action}
+	}
+	require.Equal(t, []entry{
+		{"index_sets", "app", "new-is", backup.ActionSkipped},
+		{"streams", "app", "new-s", backup.ActionCreated},
+		{"stream_rules", "app/tag 1 app", "new-r", backup.ActionCreated},
+		{"pipelines", "p", "new-p", backup.ActionCreated},
+		{"pipeline_connections", "app", "new-s", backup.ActionCreated},
+		{"roles", "Admin", "", backup.ActionSkipped},
+		{"users", "foo", "", backup.ActionSkipped},
+	}, entries)
+
+	_, err = (&backup.Restorer{Client: cl, Policy: "foo"}).Restore(context.Background(), snap)
+	require.NotNil(t, err, "invalid policy")
+}
+
+func TestRestorer_Restore_streamRulesFailure(t *testing.T) {
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						route("GET", "/api/system/indices/index_sets", "", 200,
+							`{"total": 1, "index_sets": [{"id": "cur-is", "title": "app"}], "stats": {}}`),
+						route("PUT", "/api/system/indices/index_sets/cur-is", "", 200, `{"id": "cur-is", "title": "app"}`),
+						route("GET", "/api/streams", "", 200,
+							`{"total": 1, "streams": [{"id": "cur-s", "title": "app", "index_set_id": "cur-is"}]}`),
+						route("PUT", "/api/streams/cur-s", "", 200, `{"id": "cur-s", "title": "app"}`),
+						// it fails to get the current rules of the overwritten stream
+						route("GET", "/api/streams/cur-s/rules", "", 500, `{"message": "internal server error"}`),
+						route("GET", "/api/streams/cur-s/outputs", "", 200, `{"total": 0, "outputs": []}`),
+						route("GET", "/api/system/outputs", "", 200, `{"total": 0, "outputs": []}`),
+						route("GET", "/api/system/grok", "", 200, `{"patterns": []}`),
+						route("GET", "/api/system/inputs", "", 200, `{"total": 0, "inputs": []}`),
+						route("GET", pipelinePrefix+"/rule", "", 200, `[]`),
+						route("GET", pipelinePrefix+"/pipeline", "", 200, `[]`),
+						route("GET", "/api/roles", "", 200, `{"total": 0, "roles": []}`),
+						route("GET", "/api/users", "", 200, `{"users": []}`),
+						route("GET", "/api/events/notifications", "", 200, `{"notifications": []}`),
+						route("GET", "/api/events/definitions", "", 200, `{"event_definitions": []}`),
+					},
+				},
+			},
+		},
+	})
+
+	snap := &backup.Snapshot{
+		IndexSets: []graylog.IndexSet{{ID: "old-is", Title: "app", IndexPrefix: "app"}},
+		Streams: []graylog.Stream{{
+			ID: "old-s", Title: "app", IndexSetID: "old-is", MatchingType: "AND",
+			Rules: []graylog.StreamRule{{ID: "old-r", StreamID: "old-s", Field: "tag", Value: "app", Type: 1}},
+		}},
+		Roles: []graylog.Role{{Name: "Admin", ReadOnly: true}},
+	}
+	r := &backup.Restorer{Client: cl, Policy: backup.PolicyOverwrite}
+	result, err := r.Restore(context.Background(), snap)
+	require.Nil(t, err, "the restore continues")
+	require.NotNil(t, result.Err())
+
+	actions := map[string]backup.Action{}
+	for _, e := range result.Entries {
+		actions[e.Kind+" "+e.Key] = e.Action
+	}
+	require.Equal(t, map[string]backup.Action{
+		"index_sets app":   backup.ActionOverwritten,
+		"streams app":      backup.ActionOverwritten,
+		"stream_rules app": backup.ActionFailed,
+		"roles Admin":      backup.ActionSkipped,
+	}, actions)
+}
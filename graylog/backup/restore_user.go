@@ -0,0 +1,72 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func (rs *restorer) restoreRoles(ctx context.Context) error {
+	roles, _, _, err := rs.Client.GetRoles(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.Role, len(roles))
+	for _, role := range roles {
+		cur[role.Name] = role
+	}
+	for _, role := range rs.snap.Roles {
+		if role.ReadOnly {
+			rs.skip("roles", role.Name, "", "built-in read only role")
+			continue
+		}
+		if _, ok := cur[role.Name]; ok {
+			var err error
+			if rs.overwrite() {
+				_, _, err = rs.Client.UpdateRole(ctx, role.Name, role.NewUpdateParams())
+			}
+			rs.record("roles", role.Name, "", "", rs.existingAction(), err)
+			continue
+		}
+		_, err := rs.Client.CreateRole(ctx, &role)
+		rs.record("roles", role.Name, "", "", ActionCreated, err)
+	}
+	return nil
+}
+
+// restoreUsers restores users except for read only and external users.
+// Created users have UserPassword, and passwords of existing users aren't changed.
+func (rs *restorer) restoreUsers(ctx context.Context) error {
+	users, _, err := rs.Client.GetUsers(ctx)
+	if err != nil {
+		return err
+	}
+	cur := make(map[string]graylog.User, len(users))
+	for _, user := range users {
+		cur[user.Username] = user
+	}
+	for _, user := range rs.snap.Users {
+		if user.ReadOnly || user.External {
+			rs.skip("users", user.Username, user.ID, "read only or external user")
+			continue
+		}
+		oldID := user.ID
+		if c, ok := cur[user.Username]; ok {
+			var err error
+			if rs.overwrite() {
+				_, err = rs.Client.UpdateUser(ctx, user.NewUpdateParams())
+			}
+			rs.record("users", user.Username, oldID, c.ID, rs.existingAction(), err)
+			continue
+		}
+		if rs.UserPassword == "" {
+			rs.skip("users", user.Username, oldID, "the password of created users isn't given")
+			continue
+		}
+		user.ID = ""
+		user.Password = rs.UserPassword
+		_, err := rs.Client.CreateUser(ctx, &user)
+		rs.record("users", user.Username, oldID, user.ID, ActionCreated, err)
+	}
+	return nil
+}
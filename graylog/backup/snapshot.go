@@ -0,0 +1,187 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+const (
+	// FormatVersion is the version of the snapshot format.
+	// Snapshots of newer versions can't be read.
+	FormatVersion = 1
+
+	manifestFile  = "manifest.json"
+	dirNameFormat = "20060102T150405Z"
+)
+
+type (
+	// Snapshot is the configuration of a cluster.
+	Snapshot struct {
+		Manifest  Manifest
+		IndexSets []graylog.IndexSet
+		// Streams have their rules.
+		Streams []graylog.Stream
+		// StreamOutputs maps stream ids to output ids.
+		StreamOutputs map[string][]string
+		Outputs       []graylog.Output
+		// Inputs have their static fields.
+		Inputs []graylog.Input
+		// Extractors maps input ids to the extractors.
+		Extractors          map[string][]graylog.Extractor
+		GrokPatterns        []graylog.GrokPattern
+		PipelineRules       []graylog.PipelineRule
+		Pipelines           []graylog.Pipeline
+		PipelineConnections []graylog.PipelineConnection
+		Roles               []graylog.Role
+		Users               []graylog.User
+		EventNotifications  []graylog.EventNotification
+		EventDefinitions    []graylog.EventDefinition
+	}
+
+	// Manifest describes the files of a snapshot.
+	Manifest struct {
+		FormatVersion int            `json:"format_version"`
+		CreatedAt     time.Time      `json:"created_at"`
+		Files         []ManifestFile `json:"files"`
+	}
+
+	// ManifestFile describes a file of a snapshot.
+	ManifestFile struct {
+		Kind   string `json:"kind"`
+		File   string `json:"file"`
+		Count  int    `json:"count"`
+		SHA256 string `json:"sha256"`
+	}
+
+	entity struct {
+		kind string
+		// v is the pointer to the field of the snapshot.
+		v interface{}
+	}
+)
+
+// DirName returns the name of the snapshot directory created at the time, such as "20200101T000000Z".
+func DirName(t time.Time) string {
+	return t.UTC().Format(dirNameFormat)
+}
+
+func (snap *Snapshot) entities() []entity {
+	return []entity{
+		{"index_sets", &snap.IndexSets},
+		{"streams", &snap.Streams},
+		{"stream_outputs", &snap.StreamOutputs},
+		{"outputs", &snap.Outputs},
+		{"inputs", &snap.Inputs},
+		{"extractors", &snap.Extractors},
+		{"grok_patterns", &snap.GrokPatterns},
+		{"pipeline_rules", &snap.PipelineRules},
+		{"pipelines", &snap.Pipelines},
+		{"pipeline_connections", &snap.PipelineConnections},
+		{"roles", &snap.Roles},
+		{"users", &snap.Users},
+		{"event_notifications", &snap.EventNotifications},
+		{"event_definitions", &snap.EventDefinitions},
+	}
+}
+
+func count(v interface{}) int {
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() == reflect.Map {
+		n := 0
+		for _, k := range rv.MapKeys() {
+			n += rv.MapIndex(k).Len()
+		}
+		return n
+	}
+	return rv.Len()
+}
+
+// Write writes the snapshot to the directory and updates the manifest.
+// The directory is created and must not exist.
+func (snap *Snapshot) Write(dir string) error {
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("the directory already exists: %s", dir)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	snap.Manifest.FormatVersion = FormatVersion
+	snap.Manifest.Files = []ManifestFile{}
+	for _, e := range snap.entities() {
+		b, err := json.MarshalIndent(e.v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", e.kind, err)
+		}
+		b = append(b, '\n')
+		file := e.kind + ".json"
+		// the configuration may have secrets such as passwords of outputs
+		if err := ioutil.WriteFile(filepath.Join(dir, file), b, 0600); err != nil {
+			return err
+		}
+		sum := sha256.Sum256(b)
+		snap.Manifest.Files = append(snap.Manifest.Files, ManifestFile{
+			Kind: e.kind, File: file, Count: count(e.v), SHA256: hex.EncodeToString(sum[:]),
+		})
+	}
+	b, err := json.MarshalIndent(&snap.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, manifestFile), append(b, '\n'), 0600)
+}
+
+// Read reads a snapshot from the directory and verifies the files with the manifest.
+// Kinds which aren't in the manifest are empty.
+func Read(dir string) (*Snapshot, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, err
+	}
+	snap := &Snapshot{}
+	if err := json.Unmarshal(b, &snap.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestFile, err)
+	}
+	if snap.Manifest.FormatVersion == 0 {
+		return nil, errors.New("format_version of the manifest is required")
+	}
+	if snap.Manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf(
+			"the format version %d isn't supported. Please update the tool which supports the format version %d or earlier",
+			snap.Manifest.FormatVersion, FormatVersion)
+	}
+	entities := map[string]interface{}{}
+	for _, e := range snap.entities() {
+		entities[e.kind] = e.v
+	}
+	for _, f := range snap.Manifest.Files {
+		v, ok := entities[f.Kind]
+		if !ok {
+			return nil, fmt.Errorf("unknown kind in the manifest: %s", f.Kind)
+		}
+		b, err := ioutil.ReadFile(filepath.Join(dir, filepath.Base(f.File)))
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(b)
+		if hex.EncodeToString(sum[:]) != f.SHA256 {
+			return nil, fmt.Errorf("the checksum of %s doesn't match the manifest", f.File)
+		}
+		if err := json.Unmarshal(b, v); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.File, err)
+		}
+		if n := count(v); n != f.Count {
+			return nil, fmt.Errorf("%s has %d entities but the manifest says %d", f.File, n, f.Count)
+		}
+	}
+	return snap, nil
+}
@@ -0,0 +1,60 @@
+package backup_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestDirName(t *testing.T) {
+	require.Equal(t, "20200102T030405Z", backup.DirName(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)))
+}
+
+func TestSnapshot_Write(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "backup")
+	require.Nil(t, err)
+	defer os.RemoveAll(tmp)
+	dir := filepath.Join(tmp, "20200101T000000Z")
+
+	snap := &backup.Snapshot{
+		Manifest:  backup.Manifest{CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		IndexSets: []graylog.IndexSet{{ID: "is1", Title: "app", IndexPrefix: "app"}},
+		Streams: []graylog.Stream{{
+			ID: "s1", Title: "app", IndexSetID: "is1",
+			Rules: []graylog.StreamRule{{ID: "r1", Field: "tag", Value: "app", Type: 1}},
+		}},
+		StreamOutputs: map[string][]string{"s1": {"o1", "o2"}},
+	}
+	require.Nil(t, snap.Write(dir))
+	require.NotNil(t, snap.Write(dir), "the directory already exists")
+
+	got, err := backup.Read(dir)
+	require.Nil(t, err)
+	require.Equal(t, backup.FormatVersion, got.Manifest.FormatVersion)
+	require.Equal(t, snap.Manifest, got.Manifest)
+	require.Equal(t, snap.IndexSets, got.IndexSets)
+	require.Equal(t, snap.Streams, got.Streams)
+	require.Equal(t, snap.StreamOutputs, got.StreamOutputs)
+	for _, f := range got.Manifest.Files {
+		if f.Kind == "stream_outputs" {
+			require.Equal(t, 2, f.Count)
+		}
+	}
+
+	// a modified file is detected with the checksum
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "streams.json"), []byte("[]\n"), 0600))
+	_, err = backup.Read(dir)
+	require.NotNil(t, err)
+
+	// a newer format isn't supported
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "manifest.json"), []byte(`{"format_version": 100}`), 0600))
+	_, err = backup.Read(dir)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,92 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// Take gets all entities through the client and returns the snapshot.
+func Take(ctx context.Context, cl *client.Client) (*Snapshot, error) {
+	if cl == nil {
+		return nil, errors.New("client is nil")
+	}
+	snap := &Snapshot{
+		Manifest:      Manifest{FormatVersion: FormatVersion, CreatedAt: time.Now().UTC()},
+		StreamOutputs: map[string][]string{},
+		Extractors:    map[string][]graylog.Extractor{},
+	}
+	var err error
+
+	if snap.IndexSets, _, _, _, err = cl.GetIndexSets(ctx, 0, 0, false); err != nil {
+		return nil, fmt.Errorf("failed to get index sets: %w", err)
+	}
+	if snap.Streams, _, _, err = cl.GetStreams(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get streams: %w", err)
+	}
+	for i := range snap.Streams {
+		stream := &snap.Streams[i]
+		// outputs are saved as stream_outputs
+		stream.Outputs = nil
+		outputs, _, _, err := cl.GetStreamOutputs(ctx, stream.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get outputs of the stream %s: %w", stream.ID, err)
+		}
+		if len(outputs) == 0 {
+			continue
+		}
+		ids := make([]string, len(outputs))
+		for j, output := range outputs {
+			ids[j] = output.ID
+		}
+		snap.StreamOutputs[stream.ID] = ids
+	}
+	if snap.Outputs, _, _, err = cl.GetOutputs(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get outputs: %w", err)
+	}
+	if snap.Inputs, _, _, err = cl.GetInputs(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get inputs: %w", err)
+	}
+	for _, input := range snap.Inputs {
+		extractors, _, _, err := cl.GetExtractors(ctx, input.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get extractors of the input %s: %w", input.ID, err)
+		}
+		if len(extractors) != 0 {
+			snap.Extractors[input.ID] = extractors
+		}
+	}
+	if snap.GrokPatterns, _, err = cl.GetGrokPatterns(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get grok patterns: %w", err)
+	}
+	if snap.PipelineRules, _, err = cl.GetPipelineRules(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get pipeline rules: %w", err)
+	}
+	if snap.Pipelines, _, err = cl.GetPipelines(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get pipelines: %w", err)
+	}
+	if snap.PipelineConnections, _, err = cl.GetPipelineConnections(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get pipeline connections: %w", err)
+	}
+	if snap.Roles, _, _, err = cl.GetRoles(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+	if snap.Users, _, err = cl.GetUsers(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	notifs, _, err := cl.GetEventNotifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event notifications: %w", err)
+	}
+	snap.EventNotifications = notifs.EventNotifications
+	definitions, _, err := cl.GetEventDefinitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event definitions: %w", err)
+	}
+	snap.EventDefinitions = definitions.EventDefinitions
+	return snap, nil
+}
@@ -0,0 +1,70 @@
+package backup_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
+func pageRoute(path, page, body string) flute.Route {
+	r := route("GET", path, "", 200, body)
+	r.Matcher.PartOfQuery = map[string][]string{"page": {page}}
+	return r
+}
+
+func TestTake(t *testing.T) {
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						route("GET", "/api/system/indices/index_sets", "", 200, `{"total": 0, "index_sets": [], "stats": {}}`),
+						route("GET", "/api/streams", "", 200, `{"total": 0, "streams": []}`),
+						route("GET", "/api/system/outputs", "", 200, `{"total": 0, "outputs": []}`),
+						route("GET", "/api/system/inputs", "", 200, `{"total": 0, "inputs": []}`),
+						route("GET", "/api/system/grok", "", 200, `{"patterns": []}`),
+						route("GET", pipelinePrefix+"/rule", "", 200, `[]`),
+						route("GET", pipelinePrefix+"/pipeline", "", 200, `[]`),
+						route("GET", pipelinePrefix+"/connections", "", 200, `[]`),
+						route("GET", "/api/roles", "", 200, `{"total": 0, "roles": []}`),
+						route("GET", "/api/users", "", 200, `{"users": []}`),
+						// the event APIs are paginated
+						pageRoute("/api/events/notifications", "1", `{
+  "notifications": [{"id": "n1", "title": "foo", "config": {"type": "http-notification-v1", "url": "http://example.com/foo"}}],
+  "total": 2, "page": 1, "per_page": 1
+}`),
+						pageRoute("/api/events/notifications", "2", `{
+  "notifications": [{"id": "n2", "title": "bar", "config": {"type": "http-notification-v1", "url": "http://example.com/bar"}}],
+  "total": 2, "page": 2, "per_page": 1
+}`),
+						pageRoute("/api/events/definitions", "1", `{
+  "event_definitions": [{"id": "d1", "title": "foo"}],
+  "total": 2, "page": 1, "per_page": 1
+}`),
+						pageRoute("/api/events/definitions", "2", `{
+  "event_definitions": [{"id": "d2", "title": "bar"}],
+  "total": 2, "page": 2, "per_page": 1
+}`),
+					},
+				},
+			},
+		},
+	})
+
+	snap, err := backup.Take(context.Background(), cl)
+	require.Nil(t, err)
+	require.Len(t, snap.EventNotifications, 2)
+	require.Equal(t, "n2", snap.EventNotifications[1].ID)
+	require.Len(t, snap.EventDefinitions, 2)
+	require.Equal(t, "d2", snap.EventDefinitions[1].ID)
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// GetAuthenticationBackends returns all authentication backends.
+func (client *Client) GetAuthenticationBackends(ctx context.Context) (
+	*graylog.AuthenticationBackendsBody, *ErrorInfo, error,
+) {
+	backends := &graylog.AuthenticationBackendsBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().AuthenticationBackends(), nil, backends)
+	return backends, ei, err
+}
+
+// GetAuthenticationBackend returns a given authentication backend.
+func (client *Client) GetAuthenticationBackend(
+	ctx context.Context, id string,
+) (*graylog.AuthenticationBackend, *ErrorInfo, error) {
+	if id == "" {
+		return nil, nil, errIDRequired
+	}
+	body := &graylog.AuthenticationBackendBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().AuthenticationBackend(id), nil, body)
+	if err != nil {
+		return nil, ei, err
+	}
+	if body.Backend == nil {
+		return nil, ei, errors.New("the response doesn't have the backend")
+	}
+	return body.Backend, ei, nil
+}
+
+// CreateAuthenticationBackend creates a new authentication backend.
+// The created backend's id is set to backend.ID.
+func (client *Client) CreateAuthenticationBackend(
+	ctx context.Context, backend *graylog.AuthenticationBackend,
+) (*ErrorInfo, error) {
+	if backend == nil {
+		return nil, errors.New("authentication backend is nil")
+	}
+	body := &graylog.AuthenticationBackendBody{}
+	ei, err := client.callPost(ctx, client.Endpoints().AuthenticationBackends(), backend, body)
+	if err != nil {
+		return ei, err
+	}
+	if body.Backend != nil {
+		backend.ID = body.Backend.ID
+	}
+	return ei, nil
+}
+
+// UpdateAuthenticationBackend updates a given authentication backend.
+// To keep the secrets such as the system user's password, set KeepValue of the secrets.
+func (client *Client) UpdateAuthenticationBackend(
+	ctx context.Context, backend *graylog.AuthenticationBackend,
+) (*ErrorInfo, error) {
+	if backend == nil {
+		return nil, errors.New("authentication backend is nil")
+	}
+	if backend.ID == "" {
+		return nil, errIDRequired
+	}
+	return client.callPut(ctx, client.Endpoints().AuthenticationBackend(backend.ID), backend, nil)
+}
+
+// DeleteAuthenticationBackend deletes a given authentication backend.
+// The active backend can't be deleted.
+func (client *Client) DeleteAuthenticationBackend(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	if id == "" {
+		return nil, errIDRequired
+	}
+	return client.callDelete(ctx, client.Endpoints().AuthenticationBackend(id), nil, nil)
+}
+
+// GetAuthenticationServiceConfig returns the configuration of the authentication service,
+// which has the id of the active backend.
+func (client *Client) GetAuthenticationServiceConfig(ctx context.Context) (
+	*graylog.AuthenticationServiceConfig, *ErrorInfo, error,
+) {
+	body := &graylog.AuthenticationServiceConfigBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().AuthenticationServiceConfig(), nil, body)
+	if err != nil {
+		return nil, ei, err
+	}
+	return &body.Configuration, ei, nil
+}
+
+// ActivateAuthenticationBackend activates a given authentication backend.
+// If id is empty, the active backend is deactivated.
+func (client *Client) ActivateAuthenticationBackend(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	var activeBackend interface{}
+	if id != "" {
+		activeBackend = id
+	}
+	return client.callPost(
+		ctx, client.Endpoints().AuthenticationServiceConfig(),
+		map[string]interface{}{"active_backend": activeBackend}, nil)
+}
+
+// TestAuthenticationBackendConnection tests the connection to the server of a backend.
+func (client *Client) TestAuthenticationBackendConnection(
+	ctx context.Context, req *graylog.AuthenticationBackendTestRequest,
+) (*graylog.AuthenticationBackendTestResult, *ErrorInfo, error) {
+	if req == nil || req.Backend == nil {
+		return nil, nil, errors.New("authentication backend is nil")
+	}
+	result := &graylog.AuthenticationBackendTestResult{}
+	ei, err := client.callPost(ctx, client.Endpoints().AuthenticationBackendConnectionTest(), req, result)
+	return result, ei, err
+}
+
+// TestAuthenticationBackendLogin tests the login of a user with a backend.
+func (client *Client) TestAuthenticationBackendLogin(
+	ctx context.Context, req *graylog.AuthenticationBackendTestRequest,
+) (*graylog.AuthenticationBackendTestResult, *ErrorInfo, error) {
+	if req == nil || req.Backend == nil {
+		return nil, nil, errors.New("authentication backend is nil")
+	}
+	if req.UserLogin == nil {
+		return nil, nil, errors.New("user login is nil")
+	}
+	result := &graylog.AuthenticationBackendTestResult{}
+	ei, err := client.callPost(ctx, client.Endpoints().AuthenticationBackendLoginTest(), req, result)
+	return result, ei, err
+}
+
+// GetAuthenticationBackendGroupSync returns the group synchronization of a given backend.
+// The API is provided by Graylog Enterprise, and it returns 404 if the group synchronization isn't configured.
+func (client *Client) GetAuthenticationBackendGroupSync(
+	ctx context.Context, id string,
+) (*graylog.AuthenticationBackendGroupSync, *ErrorInfo, error) {
+	if id == "" {
+		return nil, nil, errIDRequired
+	}
+	groupSync := &graylog.AuthenticationBackendGroupSync{}
+	ei, err := client.callGet(ctx, client.Endpoints().AuthenticationBackendGroupSync(id), nil, groupSync)
+	return groupSync, ei, err
+}
+
+// CreateAuthenticationBackendGroupSync configures the group synchronization of a given backend.
+func (client *Client) CreateAuthenticationBackendGroupSync(
+	ctx context.Context, id string, groupSync *graylog.AuthenticationBackendGroupSync,
+) (*ErrorInfo, error) {
+	if id == "" {
+		return nil, errIDRequired
+	}
+	if groupSync == nil {
+		return nil, errors.New("group sync is nil")
+	}
+	return client.callPost(ctx, client.Endpoints().AuthenticationBackendGroupSync(id), groupSync, nil)
+}
+
+// UpdateAuthenticationBackendGroupSync updates the group synchronization of a given backend.
+func (client *Client) UpdateAuthenticationBackendGroupSync(
+	ctx context.Context, id string, groupSync *graylog.AuthenticationBackendGroupSync,
+) (*ErrorInfo, error) {
+	if id == "" {
+		return nil, errIDRequired
+	}
+	if groupSync == nil {
+		return nil, errors.New("group sync is nil")
+	}
+	return client.callPut(ctx, client.Endpoints().AuthenticationBackendGroupSync(id), groupSync, nil)
+}
+
+// DeleteAuthenticationBackendGroupSync removes the group synchronization of a given backend.
+func (client *Client) DeleteAuthenticationBackendGroupSync(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	if id == "" {
+		return nil, errIDRequired
+	}
+	return client.callDelete(ctx, client.Endpoints().AuthenticationBackendGroupSync(id), nil, nil)
+}
@@ -0,0 +1,301 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+const authenticationBackendID = "5fb5c8c32ab79c0012a6c4e1"
+
+func newTestAuthenticationBackend() *graylog.AuthenticationBackend {
+	return &graylog.AuthenticationBackend{
+		Title:        "ldap",
+		Description:  "",
+		DefaultRoles: []string{"Reader"},
+		Config: &graylog.AuthenticationBackendConfig{
+			Type:                  graylog.AuthenticationBackendTypeLDAP,
+			Servers:               []graylog.AuthenticationServer{{Host: "ldap.example.com", Port: 636}},
+			TransportSecurity:     graylog.TransportSecurityTLS,
+			VerifyCertificates:    true,
+			SystemUserDN:          "cn=admin,dc=example,dc=com",
+			SystemUserPassword:    &graylog.EncryptedValue{SetValue: "password"},
+			UserSearchBase:        "ou=users,dc=example,dc=com",
+			UserSearchPattern:     "(&(objectClass=person)(uid={0}))",
+			UserUniqueIDAttribute: "entryUUID",
+			UserNameAttribute:     "uid",
+			UserFullNameAttribute: "cn",
+		},
+	}
+}
+
+func TestClient_CreateAuthenticationBackend(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.CreateAuthenticationBackend(ctx, nil)
+	require.NotNil(t, err, "backend is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/system/authentication/services/backends",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								BodyJSONString: `{
+  "title": "ldap",
+  "description": "",
+  "default_roles": ["Reader"],
+  "config": {
+    "type": "ldap",
+    "servers": [{"host": "ldap.example.com", "port": 636}],
+    "transport_security": "tls",
+    "verify_certificates": true,
+    "system_user_dn": "cn=admin,dc=example,dc=com",
+    "system_user_password": {"set_value": "password"},
+    "user_search_base": "ou=users,dc=example,dc=com",
+    "user_search_pattern": "(&(objectClass=person)(uid={0}))",
+    "user_unique_id_attribute": "entryUUID",
+    "user_name_attribute": "uid",
+    "user_full_name_attribute": "cn"
+  }
+}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{"backend": {"id": "` + authenticationBackendID + `", "title": "ldap"}}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	backend := newTestAuthenticationBackend()
+	_, err = cl.CreateAuthenticationBackend(ctx, backend)
+	require.Nil(t, err)
+	require.Equal(t, authenticationBackendID, backend.ID)
+}
+
+func TestClient_GetAuthenticationBackend(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, _, err = cl.GetAuthenticationBackend(ctx, "")
+	require.NotNil(t, err, "id is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/system/authentication/services/backends/" + authenticationBackendID,
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{
+  "backend": {
+    "id": "` + authenticationBackendID + `",
+    "title": "ad",
+    "description": "",
+    "default_roles": ["Reader"],
+    "config": {
+      "type": "active-directory",
+      "servers": [{"host": "ad.example.com", "port": 389}],
+      "transport_security": "start_tls",
+      "verify_certificates": false,
+      "system_user_dn": "cn=admin,dc=example,dc=com",
+      "system_user_password": {"is_set": true},
+      "user_search_base": "dc=example,dc=com",
+      "user_search_pattern": "(&(objectClass=user)(sAMAccountName={0}))",
+      "user_name_attribute": "sAMAccountName",
+      "user_full_name_attribute": "displayName"
+    }
+  },
+  "context": {}
+}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	backend, _, err := cl.GetAuthenticationBackend(ctx, authenticationBackendID)
+	require.Nil(t, err)
+	require.Equal(t, graylog.AuthenticationBackendTypeActiveDirectory, backend.Config.Type)
+	require.Equal(t, "ad.example.com", backend.Config.Servers[0].Host)
+	require.True(t, backend.Config.SystemUserPassword.IsSet)
+}
+
+func TestClient_GetAuthenticationServiceConfig(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/system/authentication/services/configuration",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{
+  "configuration": {"active_backend": "` + authenticationBackendID + `"},
+  "context": {
+    "backends": {
+      "` + authenticationBackendID + `": {"id": "` + authenticationBackendID + `", "title": "ad", "description": ""}
+    }
+  }
+}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	cfg, _, err := cl.GetAuthenticationServiceConfig(ctx)
+	require.Nil(t, err)
+	require.Equal(t, authenticationBackendID, cfg.ActiveBackend)
+}
+
+func TestClient_ActivateAuthenticationBackend(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	for _, id := range []string{authenticationBackendID, ""} {
+		body := `{"active_backend": null}`
+		if id != "" {
+			body = `{"active_backend": "` + id + `"}`
+		}
+		cl.SetHTTPClient(&http.Client{
+			Transport: &flute.Transport{
+				T: t,
+				Services: []flute.Service{
+					{
+						Endpoint: "http://example.com",
+						Routes: []flute.Route{
+							{
+								Matcher: &flute.Matcher{
+									Method: "POST",
+									Path:   "/api/system/authentication/services/configuration",
+								},
+								Tester: &flute.Tester{
+									PartOfHeader:   getTestHeader(),
+									BodyJSONString: body,
+								},
+								Response: &flute.Response{
+									Base: http.Response{
+										StatusCode: 200,
+									},
+									BodyString: `{"configuration": ` + body + `, "context": {"backends": {}}}`,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+		_, err = cl.ActivateAuthenticationBackend(ctx, id)
+		require.Nil(t, err)
+	}
+}
+
+func TestClient_TestAuthenticationBackendLogin(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, _, err = cl.TestAuthenticationBackendLogin(ctx, &graylog.AuthenticationBackendTestRequest{
+		Backend: newTestAuthenticationBackend(),
+	})
+	require.NotNil(t, err, "user login is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/system/authentication/services/test/backend/login",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{
+  "success": false,
+  "message": "Login for user <foo> failed",
+  "errors": ["invalid credentials"],
+  "result": {}
+}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	result, _, err := cl.TestAuthenticationBackendLogin(ctx, &graylog.AuthenticationBackendTestRequest{
+		BackendID: authenticationBackendID,
+		Backend:   newTestAuthenticationBackend(),
+		UserLogin: &graylog.AuthenticationBackendUser{Username: "foo", Password: "bar"},
+	})
+	require.Nil(t, err)
+	require.False(t, result.Success)
+	require.Equal(t, []string{"invalid credentials"}, result.Errors)
+}
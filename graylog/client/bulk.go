@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+type (
+	// BulkOperation is an operation of Bulk.
+	// Run is required and Rollback is optional.
+	// Rollback is called with the result of Run to compensate a succeeded operation
+	// such as deleting a created resource.
+	BulkOperation struct {
+		Run      func(ctx context.Context, client *Client) (interface{}, *ErrorInfo, error)
+		Rollback func(ctx context.Context, client *Client, result interface{}) error
+	}
+
+	// BulkOption is the option of Bulk.
+	// Concurrency is the number of operations run at the same time. The default is 1.
+	// RequestsPerSecond limits the rate of operations started against the Graylog host. 0 means no limit.
+	// If StopOnError is true, operations which haven't started when an operation fails are skipped.
+	// If Rollback is true and an operation fails or is skipped because of the canceled context,
+	// succeeded operations are rolled back in the reverse order.
+	// Rollback isn't canceled with the context, so rollbacks are run even if the context is canceled or times out.
+	BulkOption struct {
+		Concurrency       int
+		RequestsPerSecond float64
+		StopOnError       bool
+		Rollback          bool
+	}
+
+	// BulkResult is the result of Bulk.
+	// Items has the result of each operation in the same order as the operations.
+	BulkResult struct {
+		Items []BulkItem
+	}
+
+	// BulkItem is the result of an operation of Bulk.
+	// Skipped is true if the operation isn't run because of StopOnError or the canceled context.
+	BulkItem struct {
+		Result      interface{}
+		ErrorInfo   *ErrorInfo
+		Err         error
+		Skipped     bool
+		RolledBack  bool
+		RollbackErr error
+	}
+)
+
+// Failed returns the indexes of failed operations.
+func (result *BulkResult) Failed() []int {
+	a := []int{}
+	for i, item := range result.Items {
+		if item.Err != nil {
+			a = append(a, i)
+		}
+	}
+	return a
+}
+
+func (result *BulkResult) skipped() bool {
+	for _, item := range result.Items {
+		if item.Skipped {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns an error if some operations or rollbacks fail.
+func (result *BulkResult) Err() error {
+	failed := result.Failed()
+	rollbackFailed := 0
+	for _, item := range result.Items {
+		if item.RollbackErr != nil {
+			rollbackFailed++
+		}
+	}
+	if len(failed) == 0 && rollbackFailed == 0 {
+		return nil
+	}
+	if len(failed) == 0 {
+		return fmt.Errorf("failed to roll back %d operations", rollbackFailed)
+	}
+	err := result.Items[failed[0]].Err
+	if rollbackFailed != 0 {
+		return fmt.Errorf("%d of %d operations fail and failed to roll back %d operations: %w",
+			len(failed), len(result.Items), rollbackFailed, err)
+	}
+	return fmt.Errorf("%d of %d operations fail: %w", len(failed), len(result.Items), err)
+}
+
+// Bulk runs operations concurrently and returns the result of each operation.
+func (client *Client) Bulk(ctx context.Context, ops []BulkOperation, opt *BulkOption) *BulkResult {
+	if opt == nil {
+		opt = &BulkOption{}
+	}
+	concurrency := opt.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var limiter *tokenBucket
+	if opt.RequestsPerSecond > 0 {
+		limiter = newTokenBucket(opt.RequestsPerSecond, concurrency)
+	}
+
+	result := &BulkResult{Items: make([]BulkItem, len(ops))}
+	var (
+		mutex   sync.Mutex
+		stopped bool
+		wg      sync.WaitGroup
+	)
+	indexes := make(chan int, len(ops))
+	for i := range ops {
+		indexes <- i
+	}
+	close(indexes)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				item := &result.Items[idx]
+				mutex.Lock()
+				skip := stopped
+				mutex.Unlock()
+				if skip || ctx.Err() != nil {
+					item.Skipped = true
+					continue
+				}
+				if limiter != nil {
+					if err := limiter.wait(ctx); err != nil {
+						item.Skipped = true
+						continue
+					}
+				}
+				item.Result, item.ErrorInfo, item.Err = ops[idx].Run(ctx, client)
+				if item.Err != nil && opt.StopOnError {
+					mutex.Lock()
+					stopped = true
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if opt.Rollback && (len(result.Failed()) != 0 || ctx.Err() != nil && result.skipped()) {
+		// the batch may fail because the context is canceled
+		rollbackCtx := context.WithoutCancel(ctx)
+		for i := len(ops) - 1; i >= 0; i-- {
+			item := &result.Items[i]
+			if item.Skipped || item.Err != nil || ops[i].Rollback == nil {
+				continue
+			}
+			item.RollbackErr = ops[i].Rollback(rollbackCtx, client, item.Result)
+			item.RolledBack = item.RollbackErr == nil
+		}
+	}
+	return result
+}
+
+// CreateStreamRules creates stream rules with Bulk.
+// The ids of created rules are set to the rules.
+// Created rules are deleted when they are rolled back.
+func (client *Client) CreateStreamRules(
+	ctx context.Context, rules []*graylog.StreamRule, opt *BulkOption,
+) *BulkResult {
+	ops := make([]BulkOperation, len(rules))
+	for i, rule := range rules {
+		rule := rule
+		ops[i] = BulkOperation{
+			Run: func(ctx context.Context, client *Client) (interface{}, *ErrorInfo, error) {
+				ei, err := client.CreateStreamRule(ctx, rule)
+				return rule, ei, err
+			},
+			Rollback: func(ctx context.Context, client *Client, result interface{}) error {
+				_, err := client.DeleteStreamRule(ctx, rule.StreamID, rule.ID)
+				return err
+			},
+		}
+	}
+	return client.Bulk(ctx, ops, opt)
+}
+
+// UpdateExtractors updates extractors of an input with Bulk.
+// Updates can't be rolled back.
+func (client *Client) UpdateExtractors(
+	ctx context.Context, inputID string, extractors []*graylog.Extractor, opt *BulkOption,
+) *BulkResult {
+	ops := make([]BulkOperation, len(extractors))
+	for i, extractor := range extractors {
+		extractor := extractor
+		ops[i] = BulkOperation{
+			Run: func(ctx context.Context, client *Client) (interface{}, *ErrorInfo, error) {
+				if extractor == nil {
+					return nil, nil, errors.New("extractor is required")
+				}
+				ei, err := client.UpdateExtractor(ctx, inputID, extractor)
+				return extractor, ei, err
+			},
+		}
+	}
+	return client.Bulk(ctx, ops, opt)
+}
@@ -0,0 +1,192 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestClient_CreateStreamRules(t *testing.T) {
+	ctx := context.Background()
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	streamID := "5d84c1a92ab79c000d35d6ca"
+	invalidStreamID := "5d84c1a92ab79c000d35d6cb"
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method:         "POST",
+								Path:           "/api/streams/" + streamID + "/rules",
+								BodyJSONString: `{"field": "tag", "value": "a", "type": 1, "inverted": false}`,
+							},
+							Response: &flute.Response{
+								Base:       http.Response{StatusCode: 201},
+								BodyString: `{"streamrule_id": "5d84c1a92ab79c000d35d6d7"}`,
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/streams/" + invalidStreamID + "/rules",
+							},
+							Response: &flute.Response{
+								Base:       http.Response{StatusCode: 400},
+								BodyString: `{"type": "ApiError", "message": "invalid rule"}`,
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "DELETE",
+								Path:   "/api/streams/" + streamID + "/rules/5d84c1a92ab79c000d35d6d7",
+							},
+							Response: &flute.Response{
+								Base: http.Response{StatusCode: 204},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	rules := []*graylog.StreamRule{
+		{StreamID: streamID, Field: "tag", Value: "a", Type: 1},
+		{StreamID: invalidStreamID, Field: "tag", Value: "b", Type: 1},
+		{StreamID: streamID, Field: "tag", Value: "c", Type: 1},
+	}
+	result := cl.CreateStreamRules(ctx, rules, &client.BulkOption{StopOnError: true, Rollback: true})
+	require.NotNil(t, result.Err())
+	require.Equal(t, []int{1}, result.Failed())
+	require.Equal(t, "5d84c1a92ab79c000d35d6d7", rules[0].ID)
+	require.True(t, result.Items[0].RolledBack)
+	require.Equal(t, "invalid rule", result.Items[1].ErrorInfo.Message)
+	require.True(t, result.Items[2].Skipped)
+}
+
+func TestClient_Bulk(t *testing.T) {
+	ctx := context.Background()
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	var running, maxRunning int32
+	ops := make([]client.BulkOperation, 10)
+	for i := range ops {
+		i := i
+		ops[i] = client.BulkOperation{
+			Run: func(ctx context.Context, cl *client.Client) (interface{}, *client.ErrorInfo, error) {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				if i == 3 {
+					return nil, nil, errors.New("failure")
+				}
+				return i, nil, nil
+			},
+		}
+	}
+	// continue on error
+	result := cl.Bulk(ctx, ops, &client.BulkOption{Concurrency: 3})
+	require.Equal(t, []int{3}, result.Failed())
+	require.LessOrEqual(t, maxRunning, int32(3))
+	for i, item := range result.Items {
+		require.False(t, item.Skipped)
+		if i != 3 {
+			require.Equal(t, i, item.Result)
+		}
+	}
+
+	// the rate limit
+	start := time.Now()
+	result = cl.Bulk(ctx, ops[:4], &client.BulkOption{RequestsPerSecond: 20})
+	require.Equal(t, []int{3}, result.Failed())
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(150*time.Millisecond))
+}
+
+func TestClient_Bulk_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	var count int32
+	ops := make([]client.BulkOperation, 3)
+	for i := range ops {
+		ops[i] = client.BulkOperation{
+			Run: func(ctx context.Context, cl *client.Client) (interface{}, *client.ErrorInfo, error) {
+				atomic.AddInt32(&count, 1)
+				// the context is canceled while the first operation runs
+				cancel()
+				return nil, nil, nil
+			},
+		}
+	}
+	// the operations are skipped without the rate limit
+	result := cl.Bulk(ctx, ops, nil)
+	require.Equal(t, int32(1), count)
+	require.False(t, result.Items[0].Skipped)
+	require.True(t, result.Items[1].Skipped)
+	require.True(t, result.Items[2].Skipped)
+}
+
+func TestClient_Bulk_rollbackAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	var rollbackErrs []error
+	ops := []client.BulkOperation{
+		{
+			Run: func(ctx context.Context, cl *client.Client) (interface{}, *client.ErrorInfo, error) {
+				return nil, nil, nil
+			},
+			Rollback: func(ctx context.Context, cl *client.Client, result interface{}) error {
+				rollbackErrs = append(rollbackErrs, ctx.Err())
+				return ctx.Err()
+			},
+		},
+		{
+			Run: func(ctx context.Context, cl *client.Client) (interface{}, *client.ErrorInfo, error) {
+				// the batch times out
+				cancel()
+				return nil, nil, nil
+			},
+			Rollback: func(ctx context.Context, cl *client.Client, result interface{}) error {
+				rollbackErrs = append(rollbackErrs, ctx.Err())
+				return ctx.Err()
+			},
+		},
+		{
+			Run: func(ctx context.Context, cl *client.Client) (interface{}, *client.ErrorInfo, error) {
+				return nil, nil, errors.New("not run")
+			},
+		},
+	}
+	result := cl.Bulk(ctx, ops, &client.BulkOption{Rollback: true})
+	require.True(t, result.Items[2].Skipped)
+	// the succeeded operations are rolled back with the context which isn't canceled
+	require.Equal(t, []error{nil, nil}, rollbackErrs)
+	require.True(t, result.Items[0].RolledBack)
+	require.True(t, result.Items[1].RolledBack)
+}
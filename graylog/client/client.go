@@ -14,6 +14,8 @@ type Client struct {
 	apiVersion   string
 	endpoints    *endpoint.Endpoints
 	httpClient   *http.Client
+	limiter      *tokenBucket
+	semaphore    chan struct{}
 }
 
 // NewClient returns a new Graylog API Client.
@@ -79,3 +81,26 @@ func (client *Client) SetXRequestedBy(x string) {
 func (client *Client) SetHTTPClient(c *http.Client) {
 	client.httpClient = c
 }
+
+// SetRateLimit limits the rate of requests with a token bucket.
+// The client sends requestsPerSecond requests per second on average and at most burst requests at once.
+// If requestsPerSecond isn't positive, the rate isn't limited.
+// The limit is shared by goroutines which use the client, so call this before the client is used.
+func (client *Client) SetRateLimit(requestsPerSecond float64, burst int) {
+	if requestsPerSecond <= 0 {
+		client.limiter = nil
+		return
+	}
+	client.limiter = newTokenBucket(requestsPerSecond, burst)
+}
+
+// SetMaxConcurrency limits the number of requests in flight.
+// If n isn't positive, the number isn't limited.
+// The limit is shared by goroutines which use the client, so call this before the client is used.
+func (client *Client) SetMaxConcurrency(n int) {
+	if n <= 0 {
+		client.semaphore = nil
+		return
+	}
+	client.semaphore = make(chan struct{}, n)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// GetClusterConfigClasses returns the classes of the stored cluster configurations.
+func (client *Client) GetClusterConfigClasses(ctx context.Context) ([]string, *ErrorInfo, error) {
+	body := &struct {
+		Classes []string `json:"classes"`
+	}{}
+	ei, err := client.callGet(ctx, client.Endpoints().ClusterConfigs(), nil, body)
+	return body.Classes, ei, err
+}
+
+// GetClusterConfig returns the cluster configuration of a given class.
+// If the class is unknown, graylog.RawClusterConfig is returned.
+func (client *Client) GetClusterConfig(
+	ctx context.Context, class string,
+) (graylog.ClusterConfig, *ErrorInfo, error) {
+	if class == "" {
+		return nil, nil, errors.New("class is empty")
+	}
+	raw := json.RawMessage{}
+	ei, err := client.callGet(ctx, client.Endpoints().ClusterConfig(class), nil, &raw)
+	if err != nil {
+		return nil, ei, err
+	}
+	cfg, err := graylog.UnmarshalClusterConfig(class, raw, false)
+	return cfg, ei, err
+}
+
+// GetRawClusterConfig returns the cluster configuration of a given class as raw fields even if the class is known,
+// so that the fields which the typed configuration doesn't have aren't dropped.
+func (client *Client) GetRawClusterConfig(
+	ctx context.Context, class string,
+) (*graylog.RawClusterConfig, *ErrorInfo, error) {
+	if class == "" {
+		return nil, nil, errors.New("class is empty")
+	}
+	cfg := &graylog.RawClusterConfig{ClassName: class}
+	ei, err := client.callGet(ctx, client.Endpoints().ClusterConfig(class), nil, cfg)
+	return cfg, ei, err
+}
+
+// UpdateClusterConfig replaces the cluster configuration of the class of cfg.
+func (client *Client) UpdateClusterConfig(
+	ctx context.Context, cfg graylog.ClusterConfig,
+) (*ErrorInfo, error) {
+	if cfg == nil {
+		return nil, errors.New("cluster config is nil")
+	}
+	if cfg.Class() == "" {
+		return nil, errors.New("class is empty")
+	}
+	return client.callPut(ctx, client.Endpoints().ClusterConfig(cfg.Class()), cfg, nil)
+}
+
+// DeleteClusterConfig deletes the cluster configuration of a given class,
+// then Graylog uses the default configuration.
+func (client *Client) DeleteClusterConfig(
+	ctx context.Context, class string,
+) (*ErrorInfo, error) {
+	if class == "" {
+		return nil, errors.New("class is empty")
+	}
+	return client.callDelete(ctx, client.Endpoints().ClusterConfig(class), nil, nil)
+}
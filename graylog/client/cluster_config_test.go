@@ -0,0 +1,220 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestClient_GetClusterConfig(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, _, err = cl.GetClusterConfig(ctx, "")
+	require.NotNil(t, err, "class is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/system/cluster_config/" + graylog.ClusterConfigClassMessageProcessors,
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{
+  "processor_order": [
+    "org.graylog2.messageprocessors.MessageFilterChainProcessor",
+    "org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter"
+  ],
+  "disabled_processors": [],
+  "unknown_field": true
+}`,
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/system/cluster_config/org.example.FooConfig",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{"foo": "bar"}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	cfg, _, err := cl.GetClusterConfig(ctx, graylog.ClusterConfigClassMessageProcessors)
+	require.Nil(t, err)
+	require.Equal(t, &graylog.MessageProcessorsConfig{
+		ProcessorOrder: []string{
+			"org.graylog2.messageprocessors.MessageFilterChainProcessor",
+			"org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter",
+		},
+		DisabledProcessors: []string{},
+	}, cfg)
+
+	cfg, _, err = cl.GetClusterConfig(ctx, "org.example.FooConfig")
+	require.Nil(t, err)
+	require.Equal(t, &graylog.RawClusterConfig{
+		ClassName: "org.example.FooConfig",
+		Fields:    map[string]interface{}{"foo": "bar"},
+	}, cfg)
+}
+
+func TestClient_GetRawClusterConfig(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, _, err = cl.GetRawClusterConfig(ctx, "")
+	require.NotNil(t, err, "class is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/system/cluster_config/" + graylog.ClusterConfigClassEvents,
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{"events_search_timeout": 60000, "unknown_field": true}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	// the field which the typed configuration doesn't have is kept
+	cfg, _, err := cl.GetRawClusterConfig(ctx, graylog.ClusterConfigClassEvents)
+	require.Nil(t, err)
+	require.Equal(t, &graylog.RawClusterConfig{
+		ClassName: graylog.ClusterConfigClassEvents,
+		Fields:    map[string]interface{}{"events_search_timeout": float64(60000), "unknown_field": true},
+	}, cfg)
+}
+
+func TestClient_UpdateClusterConfig(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.UpdateClusterConfig(ctx, nil)
+	require.NotNil(t, err, "cluster config is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "PUT",
+								Path:   "/api/system/cluster_config/" + graylog.ClusterConfigClassURLWhitelist,
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								BodyJSONString: `{
+  "entries": [{"id": "1", "type": "literal", "title": "slack", "value": "https://hooks.slack.com/services/xxx"}],
+  "disabled": false
+}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 202,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, err = cl.UpdateClusterConfig(ctx, &graylog.URLWhitelistConfig{
+		Entries: []graylog.URLWhitelistEntry{
+			{ID: "1", Type: "literal", Title: "slack", Value: "https://hooks.slack.com/services/xxx"},
+		},
+	})
+	require.Nil(t, err)
+}
+
+func TestClient_DeleteClusterConfig(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.DeleteClusterConfig(ctx, "")
+	require.NotNil(t, err, "class is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "DELETE",
+								Path:   "/api/system/cluster_config/" + graylog.ClusterConfigClassEvents,
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 204,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, err = cl.DeleteClusterConfig(ctx, graylog.ClusterConfigClassEvents)
+	require.Nil(t, err)
+}
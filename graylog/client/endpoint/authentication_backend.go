@@ -0,0 +1,39 @@
+package endpoint
+
+// AuthenticationBackends returns Authentication Backends API's endpoint url.
+func (ep *Endpoints) AuthenticationBackends() string {
+	// /system/authentication/services/backends
+	return ep.authenticationServices + "/backends"
+}
+
+// AuthenticationBackend returns Authentication Backend API's endpoint url.
+func (ep *Endpoints) AuthenticationBackend(id string) string {
+	// /system/authentication/services/backends/{backendId}
+	return ep.authenticationServices + "/backends/" + id
+}
+
+// AuthenticationServiceConfig returns the endpoint url of the authentication service configuration,
+// which has the active backend.
+func (ep *Endpoints) AuthenticationServiceConfig() string {
+	// /system/authentication/services/configuration
+	return ep.authenticationServices + "/configuration"
+}
+
+// AuthenticationBackendConnectionTest returns the endpoint url to test the connection of a backend.
+func (ep *Endpoints) AuthenticationBackendConnectionTest() string {
+	// /system/authentication/services/test/backend/connection
+	return ep.authenticationServices + "/test/backend/connection"
+}
+
+// AuthenticationBackendLoginTest returns the endpoint url to test the login with a backend.
+func (ep *Endpoints) AuthenticationBackendLoginTest() string {
+	// /system/authentication/services/test/backend/login
+	return ep.authenticationServices + "/test/backend/login"
+}
+
+// AuthenticationBackendGroupSync returns the endpoint url of a backend's group synchronization.
+// The API is provided by Graylog Enterprise.
+func (ep *Endpoints) AuthenticationBackendGroupSync(id string) string {
+	// /plugins/org.graylog.plugins.security/team-sync/backend/{backendId}/config
+	return ep.teamSync + "/backend/" + id + "/config"
+}
@@ -0,0 +1,13 @@
+package endpoint
+
+// ClusterConfigs returns Cluster Config API's endpoint url.
+func (ep *Endpoints) ClusterConfigs() string {
+	// /system/cluster_config
+	return ep.clusterConfigs
+}
+
+// ClusterConfig returns the endpoint url of a given cluster config class.
+func (ep *Endpoints) ClusterConfig(class string) string {
+	// /system/cluster_config/{configClass}
+	return ep.clusterConfigs + "/" + class
+}
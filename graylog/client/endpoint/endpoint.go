@@ -10,22 +10,30 @@ type Endpoints struct {
 	alarmCallbacks           string
 	alerts                   string
 	alertConditions          string
+	authenticationServices   string
+	clusterConfigs           string
 	collectorConfigurations  string
 	dashboards               string
 	enabledStreams           string
+	entityShares             string
 	eventDefinitions         string
+	events                   string
 	eventNotifications       string
 	indexSets                string
 	indexSetStats            string
 	inputs                   string
+	inputStates              string
+	messageProcessorsConfig  string
 	outputs                  string
 	availableOutputs         string
 	pipelines                string
 	pipelineConnections      string
 	pipelineRules            string
 	roles                    string
+	search                   string
 	streams                  string
 	users                    string
+	teamSync                 string
 	grokPatterns             string
 	grokPatternsTest         string
 	ldapSetting              string
@@ -71,17 +79,23 @@ func newEndpoints(endpoint, version string) (*Endpoints, error) {
 		alarmCallbacks:          endpoint + "/alerts/callbacks",
 		alerts:                  endpoint + "/streams/alerts",
 		alertConditions:         endpoint + "/alerts/conditions",
+		authenticationServices:  endpoint + "/system/authentication/services",
+		clusterConfigs:          endpoint + "/system/cluster_config",
 		collectorConfigurations: endpoint + "/plugins/org.graylog.plugins.collector/configurations",
 		dashboards:              endpoint + "/dashboards",
 		enabledStreams:          endpoint + "/streams/enabled",
+		entityShares:            endpoint + "/authz/shares/entities",
 		eventDefinitions:        endpoint + "/events/definitions",
+		events:                  endpoint + "/events",
 		eventNotifications:      endpoint + "/events/notifications",
 		indexSets:               endpoint + "/system/indices/index_sets",
 		indexSetStats:           endpoint + "/system/indices/index_sets/stats",
 		inputs:                  endpoint + "/system/inputs",
+		inputStates:             endpoint + "/system/inputstates",
 		ldapGroups:              endpoint + "/system/ldap/groups",
 		ldapGroupRoleMapping:    endpoint + "/system/ldap/settings/groups",
 		ldapSetting:             endpoint + "/system/ldap/settings",
+		messageProcessorsConfig: endpoint + "/system/messageprocessors/config",
 
 		outputs:          endpoint + "/system/outputs",
 		availableOutputs: endpoint + "/system/outputs/available",
@@ -92,8 +106,10 @@ func newEndpoints(endpoint, version string) (*Endpoints, error) {
 		connectPipelinesToStream: connectPipelinesToStream,
 		pipelineRules:            pipelineRules,
 		roles:                    endpoint + "/roles",
+		search:                   endpoint + "/search/universal",
 		streams:                  endpoint + "/streams",
 		users:                    endpoint + "/users",
+		teamSync:                 endpoint + "/plugins/org.graylog.plugins.security/team-sync",
 		grokPatterns:             endpoint + "/system/grok",
 		grokPatternsTest:         endpoint + "/system/grok/test",
 		apiVersion:               version,
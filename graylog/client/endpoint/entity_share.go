@@ -0,0 +1,13 @@
+package endpoint
+
+// EntityShares returns the endpoint url to update the shares of a given entity.
+func (ep *Endpoints) EntityShares(grn string) string {
+	// /authz/shares/entities/{entityGRN}
+	return ep.entityShares + "/" + grn
+}
+
+// EntitySharesPrepare returns the endpoint url to prepare the shares of a given entity.
+func (ep *Endpoints) EntitySharesPrepare(grn string) string {
+	// /authz/shares/entities/{entityGRN}/prepare
+	return ep.entityShares + "/" + grn + "/prepare"
+}
@@ -0,0 +1,6 @@
+package endpoint
+
+// EventsSearch returns an Events Search API's endpoint url.
+func (ep *Endpoints) EventsSearch() string {
+	return ep.events + "/search"
+}
@@ -9,3 +9,13 @@ func (ep *Endpoints) EventNotifications() string {
 func (ep *Endpoints) EventNotification(id string) string {
 	return ep.eventNotifications + "/" + id
 }
+
+// EventNotificationTest returns an API's endpoint url to test a given event notification.
+func (ep *Endpoints) EventNotificationTest(id string) string {
+	return ep.eventNotifications + "/" + id + "/test"
+}
+
+// EventNotificationsTest returns an API's endpoint url to test an event notification which isn't saved.
+func (ep *Endpoints) EventNotificationsTest() string {
+	return ep.eventNotifications + "/test"
+}
@@ -0,0 +1,23 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client/endpoint"
+)
+
+func TestEndpoints_EventsSearch(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/events/search", apiURL), ep.EventsSearch())
+}
+
+func TestEndpoints_EventNotificationTest(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/events/notifications/%s/test", apiURL, ID), ep.EventNotificationTest(ID))
+	require.Equal(t, fmt.Sprintf("%s/events/notifications/test", apiURL), ep.EventNotificationsTest())
+}
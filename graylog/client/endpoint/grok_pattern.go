@@ -10,7 +10,7 @@ func (ep *Endpoints) GrokPattern(id string) string {
 	return ep.grokPatterns + "/" + id
 }
 
-// GrokPatternMembers returns /system/grok/test endpoint url.
+// GrokPatternTest returns /system/grok/test endpoint url.
 func (ep *Endpoints) GrokPatternTest() string {
 	return ep.grokPatternsTest
 }
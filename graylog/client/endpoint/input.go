@@ -9,3 +9,8 @@ func (ep *Endpoints) Inputs() string {
 func (ep *Endpoints) Input(id string) string {
 	return ep.inputs + "/" + id
 }
+
+// InputState returns an Input State API's endpoint url, which starts and stops an input.
+func (ep *Endpoints) InputState(id string) string {
+	return ep.inputStates + "/" + id
+}
@@ -20,3 +20,9 @@ func TestEndpoints_Input(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, fmt.Sprintf("%s/system/inputs/%s", apiURL, ID), ep.Input(ID))
 }
+
+func TestEndpoints_InputState(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/system/inputstates/%s", apiURL, ID), ep.InputState(ID))
+}
@@ -0,0 +1,7 @@
+package endpoint
+
+// MessageProcessorsConfig returns Message Processors Config API's endpoint url.
+func (ep *Endpoints) MessageProcessorsConfig() string {
+	// /system/messageprocessors/config
+	return ep.messageProcessorsConfig
+}
@@ -0,0 +1,11 @@
+package endpoint
+
+// SearchRelative returns a Relative Universal Search API's endpoint url.
+func (ep *Endpoints) SearchRelative() string {
+	return ep.search + "/relative"
+}
+
+// SearchAbsolute returns an Absolute Universal Search API's endpoint url.
+func (ep *Endpoints) SearchAbsolute() string {
+	return ep.search + "/absolute"
+}
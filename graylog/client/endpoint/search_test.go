@@ -0,0 +1,22 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client/endpoint"
+)
+
+func TestEndpoints_SearchRelative(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/search/universal/relative", apiURL), ep.SearchRelative())
+}
+
+func TestEndpoints_SearchAbsolute(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("%s/search/universal/absolute", apiURL), ep.SearchAbsolute())
+}
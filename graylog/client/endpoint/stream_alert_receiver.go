@@ -0,0 +1,7 @@
+package endpoint
+
+// StreamAlertReceivers returns Stream Alert Receivers API's endpoint url.
+func (ep *Endpoints) StreamAlertReceivers(streamID string) string {
+	// /streams/{streamId}/alerts/receivers
+	return ep.streams + "/" + streamID + "/alerts/receivers"
+}
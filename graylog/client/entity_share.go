@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// GetEntityShares returns the shares of a given entity such as "grn::::stream:<id>".
+// The effective grantees are returned by EntityShareResponse.Grantees.
+func (client *Client) GetEntityShares(
+	ctx context.Context, grn string,
+) (*graylog.EntityShareResponse, *ErrorInfo, error) {
+	return client.PrepareEntityShares(ctx, grn, nil)
+}
+
+// PrepareEntityShares validates the selected grantees of a given entity without saving them.
+// If req is nil, the current shares are returned.
+func (client *Client) PrepareEntityShares(
+	ctx context.Context, grn string, req *graylog.EntityShareRequest,
+) (*graylog.EntityShareResponse, *ErrorInfo, error) {
+	if grn == "" {
+		return nil, nil, errors.New("grn is empty")
+	}
+	if req == nil {
+		req = &graylog.EntityShareRequest{}
+	}
+	resp := &graylog.EntityShareResponse{}
+	ei, err := client.callPost(ctx, client.Endpoints().EntitySharesPrepare(grn), req, resp)
+	return resp, ei, err
+}
+
+// UpdateEntityShares replaces the grantees of a given entity with the selected grantees.
+// If the validation of the selected grantees fails, an error is returned.
+func (client *Client) UpdateEntityShares(
+	ctx context.Context, grn string, req *graylog.EntityShareRequest,
+) (*graylog.EntityShareResponse, *ErrorInfo, error) {
+	if grn == "" {
+		return nil, nil, errors.New("grn is empty")
+	}
+	if req == nil {
+		return nil, nil, errors.New("request is nil")
+	}
+	if req.SelectedGranteeCapabilities == nil {
+		// an empty map removes all grantees
+		req = &graylog.EntityShareRequest{SelectedGranteeCapabilities: map[string]string{}}
+	}
+	resp := &graylog.EntityShareResponse{}
+	ei, err := client.callPost(ctx, client.Endpoints().EntityShares(grn), req, resp)
+	if err != nil {
+		return resp, ei, err
+	}
+	if resp.ValidationResult != nil && resp.ValidationResult.Failed {
+		return resp, ei, fmt.Errorf("failed to share the entity %s: %s", grn, formatShareValidationErrors(resp.ValidationResult.Errors))
+	}
+	return resp, ei, nil
+}
+
+func formatShareValidationErrors(errs map[string][]string) string {
+	msgs := make([]string, 0, len(errs))
+	for k, v := range errs {
+		msgs = append(msgs, k+": "+strings.Join(v, ", "))
+	}
+	sort.Strings(msgs)
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,122 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestClient_GetEntityShares(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, _, err = cl.GetEntityShares(ctx, "")
+	require.NotNil(t, err, "grn is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/authz/shares/entities/grn::::stream:" + streamID + "/prepare",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader:   getTestHeader(),
+								BodyJSONString: `{"selected_grantee_capabilities": null}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{
+  "entity": "grn::::stream:` + streamID + `",
+  "sharing_user": "grn::::user:local:admin",
+  "available_grantees": [
+    {"grn": "grn::::builtin-team:everyone", "type": "builtin-team", "title": "Everyone"},
+    {"grn": "grn::::user:5d84c1a92ab79c000d35d6cb", "type": "user", "title": "test"}
+  ],
+  "available_capabilities": [
+    {"id": "view", "title": "Viewer"},
+    {"id": "manage", "title": "Manager"},
+    {"id": "own", "title": "Owner"}
+  ],
+  "active_shares": [
+    {"grant": "grn::::grant:5fb5c8c32ab79c0012a6c4e2", "grantee": "grn::::user:5d84c1a92ab79c000d35d6cb", "capability": "view"}
+  ],
+  "selected_grantee_capabilities": {"grn::::user:5d84c1a92ab79c000d35d6cb": "view"},
+  "missing_permissions_on_dependencies": {},
+  "validation_result": {"failed": false, "errors": {}}
+}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	resp, _, err := cl.GetEntityShares(ctx, graylog.NewGRN(graylog.GRNTypeStream, streamID).String())
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"grn::::user:5d84c1a92ab79c000d35d6cb": graylog.CapabilityView}, resp.Grantees())
+	require.Len(t, resp.AvailableCapabilities, 3)
+}
+
+func TestClient_UpdateEntityShares(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	grn := graylog.NewGRN(graylog.GRNTypeStream, streamID).String()
+	_, _, err = cl.UpdateEntityShares(ctx, grn, nil)
+	require.NotNil(t, err, "request is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/authz/shares/entities/" + grn,
+							},
+							Tester: &flute.Tester{
+								PartOfHeader:   getTestHeader(),
+								BodyJSONString: `{"selected_grantee_capabilities": {"grn::::user:foo": "own"}}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{
+  "entity": "` + grn + `",
+  "active_shares": [],
+  "validation_result": {"failed": true, "errors": {"selected_grantee_capabilities": ["Removing the following owners <[admin]> will leave the entity ownerless."]}}
+}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, _, err = cl.UpdateEntityShares(ctx, grn, &graylog.EntityShareRequest{
+		SelectedGranteeCapabilities: map[string]string{"grn::::user:foo": graylog.CapabilityOwn},
+	})
+	require.EqualError(t, err, "failed to share the entity "+grn+": selected_grantee_capabilities: Removing the following owners <[admin]> will leave the entity ownerless.")
+}
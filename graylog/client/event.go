@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// SearchEvents searches events.
+// Graylog's API doesn't support filtering by priority, so if params.Filter.Priorities is set,
+// the client gets all events in the time range page by page and filters them.
+// Then TotalEvents is the number of the matched events and Page and PerPage are applied to the matched events.
+// If PerPage is 0, all matched events are returned.
+func (client *Client) SearchEvents(
+	ctx context.Context, params *graylog.EventsSearchParameters,
+) (*graylog.EventsSearchResult, *ErrorInfo, error) {
+	if params == nil {
+		return nil, nil, errors.New("parameters are nil")
+	}
+	p := *params
+	if p.Filter.Alerts == "" {
+		p.Filter.Alerts = graylog.EventsSearchAlertsInclude
+	}
+	if p.Filter.EventDefinitions == nil {
+		p.Filter.EventDefinitions = []string{}
+	}
+	if p.TimeRange.Type == "" {
+		return nil, nil, errors.New("timerange is required")
+	}
+	if len(p.Filter.Priorities) != 0 {
+		return client.searchEventsByPriority(ctx, &p)
+	}
+	result := &graylog.EventsSearchResult{}
+	ei, err := client.callPost(ctx, client.Endpoints().EventsSearch(), &p, result)
+	if err != nil {
+		return nil, ei, err
+	}
+	return result, ei, nil
+}
+
+func (client *Client) searchEventsByPriority(
+	ctx context.Context, params *graylog.EventsSearchParameters,
+) (*graylog.EventsSearchResult, *ErrorInfo, error) {
+	p := *params
+	p.PerPage = listPerPage
+	var (
+		result *graylog.EventsSearchResult
+		ei     *ErrorInfo
+	)
+	events := []graylog.EventsSearchResultEvent{}
+	err := getAllPages(func(page int) (int, int, error) {
+		p.Page = page
+		r := &graylog.EventsSearchResult{}
+		var err error
+		ei, err = client.callPost(ctx, client.Endpoints().EventsSearch(), &p, r)
+		if err != nil {
+			return 0, 0, err
+		}
+		if result == nil {
+			result = r
+		} else {
+			mergeEventsSearchContext(&result.Context, &r.Context)
+		}
+		for _, e := range r.Events {
+			if p.Filter.Match(&e.Event) {
+				events = append(events, e)
+			}
+		}
+		return len(r.Events), r.TotalEvents, nil
+	})
+	if err != nil {
+		return nil, ei, err
+	}
+	result.TotalEvents = len(events)
+	result.Parameters = *params
+	if params.PerPage > 0 {
+		page := params.Page
+		if page < 1 {
+			page = 1
+		}
+		start := (page - 1) * params.PerPage
+		if start > len(events) {
+			start = len(events)
+		}
+		end := start + params.PerPage
+		if end > len(events) {
+			end = len(events)
+		}
+		events = events[start:end]
+	}
+	result.Events = events
+	return result, ei, nil
+}
+
+func mergeEventsSearchContext(dest, src *graylog.EventsSearchContext) {
+	if dest.EventDefinitions == nil {
+		dest.EventDefinitions = map[string]graylog.EventsSearchContextEntity{}
+	}
+	for k, v := range src.EventDefinitions {
+		dest.EventDefinitions[k] = v
+	}
+	if dest.Streams == nil {
+		dest.Streams = map[string]graylog.EventsSearchContextEntity{}
+	}
+	for k, v := range src.Streams {
+		dest.Streams[k] = v
+	}
+}
+
+// GetAlertHistory returns alerts, which are events whose alert flag is true.
+// Other filters such as the event definition ids and priorities are kept.
+func (client *Client) GetAlertHistory(
+	ctx context.Context, params *graylog.EventsSearchParameters,
+) (*graylog.EventsSearchResult, *ErrorInfo, error) {
+	if params == nil {
+		return nil, nil, errors.New("parameters are nil")
+	}
+	p := *params
+	p.Filter.Alerts = graylog.EventsSearchAlertsOnly
+	return client.SearchEvents(ctx, &p)
+}
@@ -40,11 +40,25 @@ func (client *Client) CreateEventDefinition(
 }
 
 // GetEventDefinitions returns all event definitions.
+// The API is paginated, so all pages are got.
 func (client *Client) GetEventDefinitions(ctx context.Context) (
 	*graylog.EventDefinitionsBody, *ErrorInfo, error,
 ) {
 	definitions := &graylog.EventDefinitionsBody{}
-	ei, err := client.callGet(ctx, client.Endpoints().EventDefinitions(), nil, definitions)
+	var ei *ErrorInfo
+	err := getAllPages(func(page int) (int, int, error) {
+		body := &graylog.EventDefinitionsBody{}
+		var err error
+		ei, err = client.callGet(ctx, pageEndpoint(client.Endpoints().EventDefinitions(), page), nil, body)
+		if err != nil {
+			return 0, 0, err
+		}
+		all := append(definitions.EventDefinitions, body.EventDefinitions...)
+		*definitions = *body
+		definitions.EventDefinitions = all
+		return len(body.EventDefinitions), body.Total, nil
+	})
+	definitions.Count = len(definitions.EventDefinitions)
 	return definitions, ei, err
 }
 
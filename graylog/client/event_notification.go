@@ -25,11 +25,25 @@ func (client *Client) CreateEventNotification(
 }
 
 // GetEventNotifications returns all event notifications.
+// The API is paginated, so all pages are got.
 func (client *Client) GetEventNotifications(ctx context.Context) (
 	*graylog.EventNotificationsBody, *ErrorInfo, error,
 ) {
 	notifs := &graylog.EventNotificationsBody{}
-	ei, err := client.callGet(ctx, client.Endpoints().EventNotifications(), nil, notifs)
+	var ei *ErrorInfo
+	err := getAllPages(func(page int) (int, int, error) {
+		body := &graylog.EventNotificationsBody{}
+		var err error
+		ei, err = client.callGet(ctx, pageEndpoint(client.Endpoints().EventNotifications(), page), nil, body)
+		if err != nil {
+			return 0, 0, err
+		}
+		all := append(notifs.EventNotifications, body.EventNotifications...)
+		*notifs = *body
+		notifs.EventNotifications = all
+		return len(body.EventNotifications), body.Total, nil
+	})
+	notifs.Count = len(notifs.EventNotifications)
 	return notifs, ei, err
 }
 
@@ -74,3 +88,29 @@ func (client *Client) DeleteEventNotification(
 	}
 	return client.callDelete(ctx, client.Endpoints().EventNotification(id), nil, nil)
 }
+
+// TestEventNotification sends a test notification with a given saved event notification.
+func (client *Client) TestEventNotification(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	return client.callPost(ctx, client.Endpoints().EventNotificationTest(id), nil, nil)
+}
+
+// TestUnsavedEventNotification sends a test notification with a given event notification which isn't saved.
+func (client *Client) TestUnsavedEventNotification(
+	ctx context.Context, notif *graylog.EventNotification,
+) (*ErrorInfo, error) {
+	if notif == nil {
+		return nil, errors.New("event notification is nil")
+	}
+	return client.callPost(
+		ctx, client.Endpoints().EventNotificationsTest(),
+		map[string]interface{}{
+			"title":       notif.Title,
+			"description": notif.Description,
+			"config":      notif.Config,
+		}, nil)
+}
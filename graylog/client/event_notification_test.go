@@ -65,9 +65,8 @@ func TestClient_CreateEventNotification(t *testing.T) {
 	notification := &graylog.EventNotification{
 		Title:       "http",
 		Description: "",
-		Config: map[string]interface{}{
-			"type": "http-notification-v1",
-			"url":  "http://example.com",
+		Config: &graylog.EventNotificationConfigHTTP{
+			URL: "http://example.com",
 		},
 	}
 	_, err = cl.CreateEventNotification(ctx, notification)
@@ -257,3 +256,124 @@ func TestClient_UpdateEventNotification(t *testing.T) {
 	_, err = cl.UpdateEventNotification(ctx, testdata.EventNotification())
 	require.Nil(t, err)
 }
+
+func TestClient_TestEventNotification(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.TestEventNotification(ctx, "")
+	require.NotNil(t, err, "id is required")
+
+	_, err = cl.TestUnsavedEventNotification(ctx, nil)
+	require.NotNil(t, err, "event notification should not be nil")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/events/notifications/" + testdata.EventNotification().ID + "/test",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/events/notifications/test",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								BodyJSONString: `{
+  "title": "http",
+  "description": "",
+  "config": {
+    "type": "http-notification-v1",
+    "url": "http://example.com"
+  }
+}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, err = cl.TestEventNotification(ctx, testdata.EventNotification().ID)
+	require.Nil(t, err)
+	_, err = cl.TestUnsavedEventNotification(ctx, testdata.RequestCreateEventNotification())
+	require.Nil(t, err)
+}
+
+func TestClient_GetEventNotifications_pages(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	genRoute := func(page, body string) flute.Route {
+		return flute.Route{
+			Matcher: &flute.Matcher{
+				Method:      "GET",
+				Path:        "/api/events/notifications",
+				PartOfQuery: map[string][]string{"page": {page}, "per_page": {"100"}},
+			},
+			Response: &flute.Response{
+				Base: http.Response{
+					StatusCode: 200,
+				},
+				BodyString: body,
+			},
+		}
+	}
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						genRoute("1", `{
+  "notifications": [
+    {"id": "1", "title": "foo", "config": {"type": "http-notification-v1", "url": "http://example.com/foo"}},
+    {"id": "2", "title": "bar", "config": {"type": "http-notification-v1", "url": "http://example.com/bar"}}
+  ],
+  "total": 3, "page": 1, "per_page": 2, "count": 2, "grand_total": 3
+}`),
+						genRoute("2", `{
+  "notifications": [
+    {"id": "3", "title": "zoo", "config": {"type": "http-notification-v1", "url": "http://example.com/zoo"}}
+  ],
+  "total": 3, "page": 2, "per_page": 2, "count": 1, "grand_total": 3
+}`),
+					},
+				},
+			},
+		},
+	})
+
+	notifs, _, err := cl.GetEventNotifications(ctx)
+	require.Nil(t, err)
+	require.Len(t, notifs.EventNotifications, 3)
+	require.Equal(t, "zoo", notifs.EventNotifications[2].Title)
+	require.Equal(t, 3, notifs.Count)
+	require.Equal(t, 3, notifs.Total)
+}
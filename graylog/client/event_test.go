@@ -0,0 +1,185 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestClient_SearchEvents(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	respBuf, err := ioutil.ReadFile("../testdata/event/events_search.json")
+	require.Nil(t, err)
+
+	_, _, err = cl.SearchEvents(ctx, nil)
+	require.NotNil(t, err, "parameters should not be nil")
+
+	_, _, err = cl.SearchEvents(ctx, &graylog.EventsSearchParameters{})
+	require.NotNil(t, err, "timerange is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/events/search",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								BodyJSONString: `{
+  "query": "",
+  "page": 1,
+  "per_page": 100,
+  "timerange": {
+    "type": "relative",
+    "range": 300
+  },
+  "filter": {
+    "alerts": "only",
+    "event_definitions": [
+      "5de5a9e7a1de18000cdfe192"
+    ]
+  },
+  "sort_by": "timestamp",
+  "sort_direction": "desc"
+}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: string(respBuf),
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	params := &graylog.EventsSearchParameters{
+		Page:      1,
+		PerPage:   25,
+		TimeRange: graylog.NewRelativeTimeRange(300),
+		Filter: graylog.EventsSearchFilter{
+			EventDefinitions: []string{"5de5a9e7a1de18000cdfe192"},
+			Priorities:       []int{2},
+		},
+		SortBy:        "timestamp",
+		SortDirection: "desc",
+	}
+	result, _, err := cl.GetAlertHistory(ctx, params)
+	require.Nil(t, err)
+	// the total is the number of the events which match the priorities
+	require.Equal(t, 1, result.TotalEvents)
+	require.Len(t, result.Events, 1)
+	require.Equal(t, 25, result.Parameters.PerPage)
+	event := result.Events[0].Event
+	require.Equal(t, "01DV4K7RJ9Y3WZ5P8M7A4VGS1E", event.ID)
+	require.Equal(t, 2, event.Priority)
+	require.True(t, event.Alert)
+	require.Equal(t, map[string]string{"test": "test"}, event.Fields)
+	require.Equal(t, "new-event-definition", result.Context.EventDefinitions[event.EventDefinitionID].Title)
+	// the given parameters aren't changed
+	require.Equal(t, "", params.Filter.Alerts)
+}
+
+func TestClient_SearchEvents_priorityPages(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	// the response bodies of the pages
+	pages := map[int]string{
+		1: `{
+  "events": [
+    {"event": {"id": "1", "event_definition_id": "d1", "priority": 3}},
+    {"event": {"id": "2", "event_definition_id": "d1", "priority": 1}},
+    {"event": {"id": "3", "event_definition_id": "d1", "priority": 3}}
+  ],
+  "total_events": 5,
+  "context": {"event_definitions": {"d1": {"id": "d1", "title": "foo"}}}
+}`,
+		2: `{
+  "events": [
+    {"event": {"id": "4", "event_definition_id": "d2", "priority": 3}},
+    {"event": {"id": "5", "event_definition_id": "d2", "priority": 2}}
+  ],
+  "total_events": 5,
+  "context": {"event_definitions": {"d2": {"id": "d2", "title": "bar"}}}
+}`,
+	}
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/events/search",
+							},
+							Response: &flute.Response{
+								Response: func(req *http.Request) (*http.Response, error) {
+									p := &graylog.EventsSearchParameters{}
+									if err := json.NewDecoder(req.Body).Decode(p); err != nil {
+										return nil, err
+									}
+									require.Equal(t, 100, p.PerPage)
+									return &http.Response{
+										StatusCode: 200,
+										Body:       ioutil.NopCloser(strings.NewReader(pages[p.Page])),
+									}, nil
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	params := &graylog.EventsSearchParameters{
+		Page:      2,
+		PerPage:   2,
+		TimeRange: graylog.NewRelativeTimeRange(300),
+		Filter: graylog.EventsSearchFilter{
+			Priorities: []int{3},
+		},
+	}
+	result, _, err := cl.SearchEvents(ctx, params)
+	require.Nil(t, err)
+	require.Equal(t, 3, result.TotalEvents)
+	require.Len(t, result.Events, 1)
+	require.Equal(t, "4", result.Events[0].Event.ID)
+	require.Equal(t, 2, result.Parameters.Page)
+	require.Equal(t, 2, result.Parameters.PerPage)
+	require.Equal(t, "bar", result.Context.EventDefinitions["d2"].Title)
+
+	// all matched events are returned if PerPage is 0
+	params.PerPage = 0
+	result, _, err = cl.SearchEvents(ctx, params)
+	require.Nil(t, err)
+	require.Equal(t, 3, result.TotalEvents)
+	require.Len(t, result.Events, 3)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
@@ -106,3 +107,37 @@ func (client *Client) DeleteExtractor(
 	}
 	return client.callDelete(ctx, client.Endpoints().Extractor(inputID, extractorID), nil, nil)
 }
+
+// ExportExtractors returns an input's extractors in the format of Graylog Web UI's export.
+func (client *Client) ExportExtractors(
+	ctx context.Context, inputID string,
+) (*graylog.ExtractorsExport, *ErrorInfo, error) {
+	extractors, _, ei, err := client.GetExtractors(ctx, inputID)
+	if err != nil {
+		return nil, ei, err
+	}
+	return graylog.NewExtractorsExport(extractors), ei, nil
+}
+
+// ImportExtractors adds exported extractors to an input.
+// The extractors are created in order, and the created extractors are returned.
+// If it fails to create an extractor, the extractors which have been created are returned with the error.
+func (client *Client) ImportExtractors(
+	ctx context.Context, inputID string, exp *graylog.ExtractorsExport,
+) ([]graylog.Extractor, *ErrorInfo, error) {
+	if inputID == "" {
+		return nil, nil, errors.New("input id is required")
+	}
+	if exp == nil {
+		return nil, nil, errors.New("extractors export is nil")
+	}
+	extractors := make([]graylog.Extractor, 0, len(exp.Extractors))
+	for _, e := range exp.Extractors {
+		extractor := e.Extractor()
+		if ei, err := client.CreateExtractor(ctx, inputID, extractor); err != nil {
+			return extractors, ei, fmt.Errorf("failed to create an extractor %s: %w", extractor.Title, err)
+		}
+		extractors = append(extractors, *extractor)
+	}
+	return extractors, nil, nil
+}
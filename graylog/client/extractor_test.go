@@ -776,3 +776,73 @@ func TestClient_DeleteExtractor(t *testing.T) {
 		require.Nil(t, err)
 	}
 }
+
+func TestClient_ImportExtractors(t *testing.T) {
+	ctx := context.Background()
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, _, err = cl.ImportExtractors(ctx, "", &graylog.ExtractorsExport{})
+	require.NotNil(t, err, "input id is required")
+	_, _, err = cl.ImportExtractors(ctx, "xxx", nil)
+	require.NotNil(t, err, "extractors export is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/system/inputs/xxx/extractors",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								BodyJSONString: `{
+								  "title": "status",
+								  "cut_or_copy": "copy",
+								  "source_field": "message",
+								  "target_field": "status",
+								  "extractor_type": "regex",
+								  "extractor_config": {"regex_value": "status=(\\d+)"},
+								  "converters": {},
+								  "condition_type": "none",
+								  "condition_value": "",
+								  "order": 0
+								}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 201,
+								},
+								BodyString: `{"extractor_id": "e9f5e010-4315-11e9-964f-020000000000"}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	extractors, _, err := cl.ImportExtractors(ctx, "xxx", &graylog.ExtractorsExport{
+		Extractors: []graylog.ExtractorExport{
+			{
+				Title:          "status",
+				ExtractorType:  "regex",
+				Converters:     []graylog.ExtractorConverter{},
+				CursorStrategy: "copy",
+				SourceField:    "message",
+				TargetField:    "status",
+				ExtractorConfig: &graylog.ExtractorTypeRegexConfig{
+					RegexValue: `status=(\d+)`,
+				},
+				ConditionType: "none",
+			},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, extractors, 1)
+	require.Equal(t, "e9f5e010-4315-11e9-964f-020000000000", extractors[0].ID)
+}
@@ -1,10 +1,18 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/grok"
 )
 
 // CreateGrokPattern creates a new grok pattern.
@@ -63,3 +71,73 @@ func (client *Client) DeleteGrokPattern(
 	}
 	return client.callDelete(ctx, client.Endpoints().GrokPattern(id), nil, nil)
 }
+
+// TestGrokPattern tests a grok pattern against a sample data on the server side
+// and returns the extracted fields.
+func (client *Client) TestGrokPattern(
+	ctx context.Context, grokPattern *graylog.GrokPattern, sampleData string,
+) (map[string]interface{}, *ErrorInfo, error) {
+	if grokPattern == nil {
+		return nil, nil, errors.New("grok pattern is nil")
+	}
+	body := &graylog.GrokPatternTestBody{
+		GrokPattern: *grokPattern,
+		SampleData:  sampleData,
+	}
+	fields := map[string]interface{}{}
+	ei, err := client.callPost(ctx, client.Endpoints().GrokPatternTest(), body, &fields)
+	return fields, ei, err
+}
+
+// ImportGrokPatterns parses a grok pattern file and creates or updates the grok patterns in bulk.
+// Each line of the file is "NAME PATTERN".
+// If replaceAll is true, all existing grok patterns are removed before the import.
+func (client *Client) ImportGrokPatterns(
+	ctx context.Context, r io.Reader, replaceAll bool,
+) (*ErrorInfo, error) {
+	if r == nil {
+		return nil, errors.New("reader is nil")
+	}
+	patterns, err := grok.ParsePatterns(r)
+	if err != nil {
+		return nil, err
+	}
+	return client.UpdateGrokPatterns(ctx, patterns, replaceAll)
+}
+
+// UpdateGrokPatterns creates or updates grok patterns in bulk.
+// If replaceAll is true, all existing grok patterns are removed before the update.
+func (client *Client) UpdateGrokPatterns(
+	ctx context.Context, patterns []graylog.GrokPattern, replaceAll bool,
+) (*ErrorInfo, error) {
+	v := url.Values{
+		"replace": []string{strconv.FormatBool(replaceAll)},
+	}
+	return client.callPut(
+		ctx, client.Endpoints().GrokPatterns()+"?"+v.Encode(),
+		&graylog.GrokPatternsBody{Patterns: patterns}, nil)
+}
+
+// ImportGrokPatternsText uploads a grok pattern file as a text.
+// The file is validated before it is uploaded.
+// If replaceAll is true, all existing grok patterns are removed before the import.
+func (client *Client) ImportGrokPatternsText(
+	ctx context.Context, r io.Reader, replaceAll bool,
+) (*ErrorInfo, error) {
+	if r == nil {
+		return nil, errors.New("reader is nil")
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read grok patterns: %w", err)
+	}
+	if _, err := grok.ParsePatterns(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	v := url.Values{
+		"replace": []string{strconv.FormatBool(replaceAll)},
+	}
+	return client.callAPIWithBody(
+		ctx, http.MethodPost, client.Endpoints().GrokPatterns()+"?"+v.Encode(),
+		"text/plain", bytes.NewReader(b), nil)
+}
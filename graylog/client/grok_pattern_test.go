@@ -3,6 +3,8 @@ package client_test
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -282,3 +284,157 @@ func TestClient_UpdateGrokPattern(t *testing.T) {
 	require.Equal(t, "grok pattern name", pattern.Name)
 	require.Equal(t, "grok pattern", pattern.Pattern)
 }
+
+func TestClient_TestGrokPattern(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, _, err = cl.TestGrokPattern(ctx, nil, "")
+	require.NotNil(t, err, "grok pattern should not be nil")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/system/grok/test",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								BodyJSONString: `{
+								  "grok_pattern": {
+								    "name": "NUMBERS",
+								    "pattern": "%{INT:count}"
+								  },
+								  "sample_data": "42"
+								}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+									Header: http.Header{
+										"Content-Type": []string{"application/json"},
+									},
+								},
+								BodyString: `{"count": "42"}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	fields, _, err := cl.TestGrokPattern(ctx, &graylog.GrokPattern{
+		Name:    "NUMBERS",
+		Pattern: "%{INT:count}",
+	}, "42")
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"count": "42"}, fields)
+}
+
+func TestClient_ImportGrokPatterns(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.ImportGrokPatterns(ctx, nil, false)
+	require.NotNil(t, err, "reader should not be nil")
+	_, err = cl.ImportGrokPatterns(ctx, strings.NewReader("INT"), false)
+	require.NotNil(t, err, "pattern file is invalid")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "PUT",
+								Path:   "/api/system/grok",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								Query: url.Values{
+									"replace": []string{"true"},
+								},
+								BodyJSONString: `{
+								  "patterns": [
+								    {"name": "INT", "pattern": "(?:[+-]?(?:[0-9]+))"},
+								    {"name": "WORD", "pattern": "\\b\\w+\\b"}
+								  ]
+								}`,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 204,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, err = cl.ImportGrokPatterns(ctx, strings.NewReader(`# comment
+INT (?:[+-]?(?:[0-9]+))
+WORD \b\w+\b
+`), true)
+	require.Nil(t, err)
+}
+
+func TestClient_ImportGrokPatternsText(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.ImportGrokPatternsText(ctx, strings.NewReader("INT"), false)
+	require.NotNil(t, err, "pattern file is invalid")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/system/grok",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: http.Header{
+									"Content-Type":   []string{"text/plain"},
+									"X-Requested-By": []string{"go-graylog"},
+									"Authorization":  nil,
+								},
+								Query: url.Values{
+									"replace": []string{"false"},
+								},
+								BodyString: "INT (?:[+-]?(?:[0-9]+))\n",
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 204,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, err = cl.ImportGrokPatternsText(ctx, strings.NewReader("INT (?:[+-]?(?:[0-9]+))\n"), false)
+	require.Nil(t, err)
+}
@@ -88,3 +88,23 @@ func (client *Client) DeleteInput(
 	}
 	return client.callDelete(ctx, client.Endpoints().Input(id), nil, nil)
 }
+
+// StartInput starts a given input on the node which the client connects to.
+func (client *Client) StartInput(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	return client.callPut(ctx, client.Endpoints().InputState(id), nil, nil)
+}
+
+// StopInput stops a given input on the node which the client connects to.
+func (client *Client) StopInput(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	return client.callDelete(ctx, client.Endpoints().InputState(id), nil, nil)
+}
@@ -263,3 +263,81 @@ func TestClient_DeleteInput(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestClient_StartInput(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.StartInput(ctx, "")
+	require.NotNil(t, err, "id is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Tester: &flute.Tester{
+								Method:       "PUT",
+								Path:         "/api/system/inputstates/" + testdata.Input().ID,
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{"id": "` + testdata.Input().ID + `"}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	_, err = cl.StartInput(ctx, testdata.Input().ID)
+	require.Nil(t, err)
+}
+
+func TestClient_StopInput(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.StopInput(ctx, "")
+	require.NotNil(t, err, "id is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Tester: &flute.Tester{
+								Method:       "DELETE",
+								Path:         "/api/system/inputstates/" + testdata.Input().ID,
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: `{"id": "` + testdata.Input().ID + `"}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	_, err = cl.StopInput(ctx, testdata.Input().ID)
+	require.Nil(t, err)
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a token bucket rate limiter.
+// The bucket is refilled at rate tokens per second up to burst tokens.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	mutex  sync.Mutex
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// reserve takes a token and returns the duration to wait until the token is available.
+// The token is taken even if the caller gives up waiting.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// wait blocks until a token is available or the context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	d := b.reserve()
+	if d == 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquire waits for a slot of the requests in flight and a token of the rate limit.
+// release must be called when the request finishes.
+func (client *Client) acquire(ctx context.Context) (release func(), err error) {
+	release = func() {}
+	if client.semaphore != nil {
+		select {
+		case client.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		sem := client.semaphore
+		release = func() { <-sem }
+	}
+	if client.limiter != nil {
+		if err := client.limiter.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,107 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
+func TestClient_SetMaxConcurrency(t *testing.T) {
+	ctx := context.Background()
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	var running, maxRunning int32
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/roles/foo",
+							},
+							Response: &flute.Response{
+								Response: func(req *http.Request) (*http.Response, error) {
+									n := atomic.AddInt32(&running, 1)
+									defer atomic.AddInt32(&running, -1)
+									for {
+										m := atomic.LoadInt32(&maxRunning)
+										if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+											break
+										}
+									}
+									time.Sleep(10 * time.Millisecond)
+									return &http.Response{StatusCode: 404, Body: http.NoBody}, nil
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	cl.SetMaxConcurrency(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cl.GetRole(ctx, "foo")
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(2), maxRunning)
+}
+
+func TestClient_SetRateLimit(t *testing.T) {
+	ctx := context.Background()
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/roles/foo",
+							},
+							Response: &flute.Response{
+								Base:       http.Response{StatusCode: 200},
+								BodyString: `{"name": "foo", "permissions": ["*"]}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	cl.SetRateLimit(20, 1)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		_, _, err := cl.GetRole(ctx, "foo")
+		require.Nil(t, err)
+	}
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(150*time.Millisecond))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err = cl.GetRole(canceled, "foo")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// GetMessageProcessors returns the order of message processors and the disabled processors.
+// The order includes all processors which are available on the server.
+func (client *Client) GetMessageProcessors(ctx context.Context) (*graylog.MessageProcessors, *ErrorInfo, error) {
+	processors := &graylog.MessageProcessors{}
+	ei, err := client.callGet(ctx, client.Endpoints().MessageProcessorsConfig(), nil, processors)
+	return processors, ei, err
+}
+
+// UpdateMessageProcessors updates the order of message processors and the disabled processors.
+// Graylog appends the available processors which aren't included in the order.
+// processors is updated with the response.
+func (client *Client) UpdateMessageProcessors(
+	ctx context.Context, processors *graylog.MessageProcessors,
+) (*ErrorInfo, error) {
+	if processors == nil {
+		return nil, errors.New("message processors is nil")
+	}
+	if processors.DisabledProcessors == nil {
+		processors.DisabledProcessors = []string{}
+	}
+	return client.callPut(ctx, client.Endpoints().MessageProcessorsConfig(), processors, processors)
+}
@@ -0,0 +1,119 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+const testMessageProcessorsBody = `{
+  "processor_order": [
+    {"name": "Pipeline Processor", "class_name": "org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter"},
+    {"name": "Message Filter Chain", "class_name": "org.graylog2.messageprocessors.MessageFilterChainProcessor"},
+    {"name": "GeoIP Resolver", "class_name": "org.graylog.plugins.map.geoip.processor.GeoIpProcessor"}
+  ],
+  "disabled_processors": ["org.graylog.plugins.map.geoip.processor.GeoIpProcessor"]
+}`
+
+func testMessageProcessors() *graylog.MessageProcessors {
+	return &graylog.MessageProcessors{
+		ProcessorOrder: []graylog.MessageProcessor{
+			{Name: "Pipeline Processor", ClassName: "org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter"},
+			{Name: "Message Filter Chain", ClassName: "org.graylog2.messageprocessors.MessageFilterChainProcessor"},
+			{Name: "GeoIP Resolver", ClassName: "org.graylog.plugins.map.geoip.processor.GeoIpProcessor"},
+		},
+		DisabledProcessors: []string{"org.graylog.plugins.map.geoip.processor.GeoIpProcessor"},
+	}
+}
+
+func TestClient_GetMessageProcessors(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/system/messageprocessors/config",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: testMessageProcessorsBody,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	processors, _, err := cl.GetMessageProcessors(ctx)
+	require.Nil(t, err)
+	require.Equal(t, testMessageProcessors(), processors)
+	require.Equal(t, []string{
+		"org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter",
+		"org.graylog2.messageprocessors.MessageFilterChainProcessor",
+		"org.graylog.plugins.map.geoip.processor.GeoIpProcessor",
+	}, processors.ClassNames())
+}
+
+func TestClient_UpdateMessageProcessors(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.UpdateMessageProcessors(ctx, nil)
+	require.NotNil(t, err, "message processors is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "PUT",
+								Path:   "/api/system/messageprocessors/config",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader:   getTestHeader(),
+								BodyJSONString: testMessageProcessorsBody,
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: testMessageProcessorsBody,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	processors := testMessageProcessors()
+	_, err = cl.UpdateMessageProcessors(ctx, processors)
+	require.Nil(t, err)
+	require.Equal(t, testMessageProcessors(), processors)
+}
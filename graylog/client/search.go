@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// Search searches messages with the universal search API.
+// If the query is empty, all messages are searched.
+func (client *Client) Search(
+	ctx context.Context, q *graylog.SearchQuery,
+) (*graylog.SearchResult, *ErrorInfo, error) {
+	if q == nil {
+		return nil, nil, errors.New("query is nil")
+	}
+	query := q.Query
+	if query == "" {
+		query = "*"
+	}
+	v := url.Values{"query": []string{query}}
+	u := client.Endpoints().SearchRelative()
+	if q.IsAbsolute() {
+		u = client.Endpoints().SearchAbsolute()
+		v.Set("from", graylog.FormatSearchTime(q.From))
+		v.Set("to", graylog.FormatSearchTime(q.To))
+	} else {
+		v.Set("range", strconv.Itoa(q.Range))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		v.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Filter != "" {
+		v.Set("filter", q.Filter)
+	}
+	if len(q.Fields) != 0 {
+		v.Set("fields", strings.Join(q.Fields, ","))
+	}
+	if q.Sort != "" {
+		v.Set("sort", q.Sort)
+	}
+	result := &graylog.SearchResult{}
+	ei, err := client.callGet(ctx, u+"?"+v.Encode(), nil, result)
+	return result, ei, err
+}
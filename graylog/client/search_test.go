@@ -0,0 +1,104 @@
+package client_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestClient_Search(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, _, err = cl.Search(ctx, nil)
+	require.NotNil(t, err, "query should not be nil")
+
+	buf, err := ioutil.ReadFile("../testdata/search/relative.json")
+	require.Nil(t, err)
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/search/universal/relative",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								Query: url.Values{
+									"query":  []string{"level:error"},
+									"range":  []string{"300"},
+									"limit":  []string{"10"},
+									"fields": []string{"source,message"},
+									"sort":   []string{"timestamp:desc"},
+								},
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: string(buf),
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/search/universal/absolute",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								Query: url.Values{
+									"query": []string{"*"},
+									"from":  []string{"2019-09-20T12:05:00.000Z"},
+									"to":    []string{"2019-09-20T12:10:00.000Z"},
+								},
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 200,
+								},
+								BodyString: string(buf),
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	result, _, err := cl.Search(ctx, &graylog.SearchQuery{
+		Query:  "level:error",
+		Range:  300,
+		Limit:  10,
+		Fields: []string{"source", "message"},
+		Sort:   "timestamp:desc",
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, result.TotalResults)
+	require.Len(t, result.Messages, 1)
+	require.Equal(t, "failed to connect", result.Messages[0].Message["message"])
+	require.Equal(t, "graylog_0", result.UsedIndices[0].IndexName)
+
+	from := time.Date(2019, 9, 20, 12, 5, 0, 0, time.UTC)
+	_, _, err = cl.Search(ctx, &graylog.SearchQuery{
+		From: from,
+		To:   from.Add(5 * time.Minute),
+	})
+	require.Nil(t, err)
+}
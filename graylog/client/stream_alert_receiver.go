@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func (client *Client) streamAlertReceiverEndpoint(streamID, receiverType, entity string) (string, error) {
+	if streamID == "" {
+		return "", errors.New("stream id is required")
+	}
+	if receiverType != graylog.AlertReceiverTypeEmails && receiverType != graylog.AlertReceiverTypeUsers {
+		return "", errors.New(`receiver type must be "emails" or "users": ` + receiverType)
+	}
+	if entity == "" {
+		return "", errors.New("entity is required")
+	}
+	v := url.Values{
+		"entity": []string{entity},
+		"type":   []string{receiverType},
+	}
+	return client.Endpoints().StreamAlertReceivers(streamID) + "?" + v.Encode(), nil
+}
+
+// AddStreamAlertReceiver adds an email address or a user name to the alert receivers of a stream.
+// receiverType is graylog.AlertReceiverTypeEmails or graylog.AlertReceiverTypeUsers.
+func (client *Client) AddStreamAlertReceiver(
+	ctx context.Context, streamID, receiverType, entity string,
+) (*ErrorInfo, error) {
+	// POST /streams/{streamId}/alerts/receivers Add an alert receiver
+	ep, err := client.streamAlertReceiverEndpoint(streamID, receiverType, entity)
+	if err != nil {
+		return nil, err
+	}
+	return client.callPost(ctx, ep, nil, nil)
+}
+
+// DeleteStreamAlertReceiver removes an email address or a user name from the alert receivers of a stream.
+func (client *Client) DeleteStreamAlertReceiver(
+	ctx context.Context, streamID, receiverType, entity string,
+) (*ErrorInfo, error) {
+	// DELETE /streams/{streamId}/alerts/receivers Remove an alert receiver
+	ep, err := client.streamAlertReceiverEndpoint(streamID, receiverType, entity)
+	if err != nil {
+		return nil, err
+	}
+	return client.callDelete(ctx, ep, nil, nil)
+}
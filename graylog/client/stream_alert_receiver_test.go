@@ -0,0 +1,104 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestClient_AddStreamAlertReceiver(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.AddStreamAlertReceiver(ctx, "", graylog.AlertReceiverTypeEmails, "foo@example.com")
+	require.NotNil(t, err, "stream id is required")
+	_, err = cl.AddStreamAlertReceiver(ctx, streamID, "groups", "foo")
+	require.NotNil(t, err, "receiver type is invalid")
+	_, err = cl.AddStreamAlertReceiver(ctx, streamID, graylog.AlertReceiverTypeUsers, "")
+	require.NotNil(t, err, "entity is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/streams/" + streamID + "/alerts/receivers",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								Query: url.Values{
+									"entity": []string{"foo@example.com"},
+									"type":   []string{"emails"},
+								},
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 201,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, err = cl.AddStreamAlertReceiver(ctx, streamID, graylog.AlertReceiverTypeEmails, "foo@example.com")
+	require.Nil(t, err)
+}
+
+func TestClient_DeleteStreamAlertReceiver(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+
+	_, err = cl.DeleteStreamAlertReceiver(ctx, "", graylog.AlertReceiverTypeUsers, "foo")
+	require.NotNil(t, err, "stream id is required")
+
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "DELETE",
+								Path:   "/api/streams/" + streamID + "/alerts/receivers",
+							},
+							Tester: &flute.Tester{
+								PartOfHeader: getTestHeader(),
+								Query: url.Values{
+									"entity": []string{"foo"},
+									"type":   []string{"users"},
+								},
+							},
+							Response: &flute.Response{
+								Base: http.Response{
+									StatusCode: 204,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, err = cl.DeleteStreamAlertReceiver(ctx, streamID, graylog.AlertReceiverTypeUsers, "foo")
+	require.Nil(t, err)
+}
@@ -34,6 +34,7 @@ func (client *Client) GetUserTokens(ctx context.Context, name string) ([]graylog
 }
 
 // DeleteUserToken removes a token for a user.
+// token is the token id or the token itself.
 func (client *Client) DeleteUserToken(
 	ctx context.Context, name, token string,
 ) (*ErrorInfo, error) {
@@ -41,7 +42,7 @@ func (client *Client) DeleteUserToken(
 		return nil, errors.New("name is empty")
 	}
 	if token == "" {
-		return nil, errors.New("name is empty")
+		return nil, errors.New("token is empty")
 	}
 	return client.callDelete(ctx, client.Endpoints().UserToken(name, token), nil, nil)
 }
@@ -5,10 +5,43 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
+// listPerPage is the number of items per page which are requested to paginated APIs.
+const listPerPage = 100
+
+// pageEndpoint returns the endpoint with the query parameters of the page.
+func pageEndpoint(endpoint string, page int) string {
+	return endpoint + "?" + url.Values{
+		"page":     []string{strconv.Itoa(page)},
+		"per_page": []string{strconv.Itoa(listPerPage)},
+	}.Encode()
+}
+
+// getAllPages calls get with the page number from 1 until all items are got.
+// get returns the number of items in the page and the total number of items.
+func getAllPages(get func(page int) (int, int, error)) error {
+	n := 0
+	for page := 1; ; page++ {
+		count, total, err := get(page)
+		if err != nil {
+			return err
+		}
+		n += count
+		if n >= total {
+			return nil
+		}
+		if count == 0 {
+			return fmt.Errorf("the page %d is empty but only %d of %d items are got", page, n, total)
+		}
+	}
+}
+
 func (client *Client) callGet(
 	ctx context.Context, endpoint string, input, output interface{}) (*ErrorInfo, error) {
 	return client.callAPI(ctx, http.MethodGet, endpoint, input, output)
@@ -32,20 +65,21 @@ func (client *Client) callDelete(
 func (client *Client) callAPI(
 	ctx context.Context, method, endpoint string, input, output interface{},
 ) (*ErrorInfo, error) {
-	// prepare request
-	var (
-		req *http.Request
-		err error
-	)
-	if input != nil {
-		reqBody := &bytes.Buffer{}
-		if err := json.NewEncoder(reqBody).Encode(input); err != nil {
-			return nil, fmt.Errorf("failed to encode request body: %w", err)
-		}
-		req, err = http.NewRequest(method, endpoint, reqBody)
-	} else {
-		req, err = http.NewRequest(method, endpoint, nil)
+	if input == nil {
+		return client.callAPIWithBody(ctx, method, endpoint, "application/json", nil, output)
+	}
+	reqBody := &bytes.Buffer{}
+	if err := json.NewEncoder(reqBody).Encode(input); err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %w", err)
 	}
+	return client.callAPIWithBody(ctx, method, endpoint, "application/json", reqBody, output)
+}
+
+func (client *Client) callAPIWithBody(
+	ctx context.Context, method, endpoint, contentType string, body io.Reader, output interface{},
+) (*ErrorInfo, error) {
+	// prepare request
+	req, err := http.NewRequest(method, endpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to call http.NewRequest: %s %s: %w", method, endpoint, err)
@@ -53,13 +87,19 @@ func (client *Client) callAPI(
 	ei := &ErrorInfo{Request: req}
 	req.SetBasicAuth(client.Name(), client.Password())
 	req = req.WithContext(ctx)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	// https://github.com/suzuki-shunsuke/go-graylog/issues/42
 	req.Header.Set("X-Requested-By", client.xRequestedBy)
 	hc := client.httpClient
 	if hc == nil {
 		hc = http.DefaultClient
 	}
+	release, err := client.acquire(ctx)
+	if err != nil {
+		return ei, fmt.Errorf(
+			"failed to wait for the rate limit: %s %s: %w", method, endpoint, err)
+	}
+	defer release()
 	// request
 	resp, err := hc.Do(req)
 	if err != nil {
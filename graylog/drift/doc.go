@@ -0,0 +1,10 @@
+/*
+Package drift compares the configurations of two clusters, or a cluster and a snapshot, and reports the drift.
+
+Both sides are snapshots of the package backup, so a cluster is compared after backup.Take.
+Entities are matched by the title or the name, and fields which the server generates such as
+id, created_at, creator_user_id, metrics and exceptions are ignored.
+References by ids such as the index set of a stream are replaced with the titles,
+because ids are different between clusters.
+*/
+package drift
@@ -0,0 +1,170 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+)
+
+// Change is the kind of the drift of an entity.
+type Change string
+
+const (
+	// ChangeAdded means the entity exists only in the target.
+	ChangeAdded Change = "added"
+	// ChangeRemoved means the entity exists only in the base.
+	ChangeRemoved Change = "removed"
+	// ChangeChanged means fields of the entity are different.
+	ChangeChanged Change = "changed"
+)
+
+type (
+	// Report is the drift between the base and the target.
+	// Entities are sorted by the kind and the key.
+	Report struct {
+		Entities []Entity `json:"entities"`
+	}
+
+	// Entity is the drift of an entity.
+	// The key is the title or the name of the entity.
+	// The key of an extractor is "<input title>/<extractor title>" and
+	// the key of a pipeline connection is the stream title.
+	// Fields is set only when the entity is changed.
+	Entity struct {
+		Kind   string  `json:"kind"`
+		Key    string  `json:"key"`
+		Change Change  `json:"change"`
+		Fields []Field `json:"fields,omitempty"`
+	}
+
+	// Field is a different field. The path is joined with "." such as "attributes.port".
+	// Base is nil when the field exists only in the target and vice versa.
+	Field struct {
+		Path   string      `json:"path"`
+		Base   interface{} `json:"base"`
+		Target interface{} `json:"target"`
+	}
+)
+
+// Compare compares the base and the target and returns the drift.
+func Compare(base, target *backup.Snapshot) (*Report, error) {
+	b, err := normalize(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize the base: %w", err)
+	}
+	t, err := normalize(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize the target: %w", err)
+	}
+	report := &Report{Entities: []Entity{}}
+	for _, kind := range kinds {
+		bs, ts := b[kind], t[kind]
+		for _, key := range keys(bs, ts) {
+			bm, inBase := bs[key]
+			tm, inTarget := ts[key]
+			switch {
+			case !inTarget:
+				report.Entities = append(report.Entities, Entity{Kind: kind, Key: key, Change: ChangeRemoved})
+			case !inBase:
+				report.Entities = append(report.Entities, Entity{Kind: kind, Key: key, Change: ChangeAdded})
+			default:
+				fields := diff("", bm, tm, []Field{})
+				if len(fields) != 0 {
+					report.Entities = append(report.Entities, Entity{
+						Kind: kind, Key: key, Change: ChangeChanged, Fields: fields})
+				}
+			}
+		}
+	}
+	return report, nil
+}
+
+func keys(a, b map[string]map[string]interface{}) []string {
+	arr := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		arr = append(arr, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			arr = append(arr, k)
+		}
+	}
+	sort.Strings(arr)
+	return arr
+}
+
+// diff compares values recursively. Maps are compared per key and other values including lists are compared as a whole.
+func diff(path string, base, target interface{}, fields []Field) []Field {
+	bm, ok1 := base.(map[string]interface{})
+	tm, ok2 := target.(map[string]interface{})
+	if !ok1 || !ok2 {
+		if !reflect.DeepEqual(base, target) {
+			fields = append(fields, Field{Path: path, Base: base, Target: target})
+		}
+		return fields
+	}
+	names := make([]string, 0, len(bm)+len(tm))
+	for k := range bm {
+		names = append(names, k)
+	}
+	for k := range tm {
+		if _, ok := bm[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := name
+		if path != "" {
+			p = path + "." + name
+		}
+		fields = diff(p, bm[name], tm[name], fields)
+	}
+	return fields
+}
+
+// Empty returns true if there is no drift.
+func (report *Report) Empty() bool {
+	return len(report.Entities) == 0
+}
+
+// Summary returns the numbers of the entities per change such as "1 added, 2 removed, 0 changed".
+func (report *Report) Summary() string {
+	n := map[Change]int{}
+	for _, e := range report.Entities {
+		n[e.Change]++
+	}
+	return fmt.Sprintf(
+		"%d added, %d removed, %d changed",
+		n[ChangeAdded], n[ChangeRemoved], n[ChangeChanged])
+}
+
+// WriteTo writes the human readable report.
+// Each entity starts with "+" (added), "-" (removed) or "~" (changed) and the kind and key,
+// followed by the changed fields as "base => target".
+func (report *Report) WriteTo(w io.Writer) (int64, error) {
+	buf := &strings.Builder{}
+	marks := map[Change]string{ChangeAdded: "+", ChangeRemoved: "-", ChangeChanged: "~"}
+	for _, e := range report.Entities {
+		fmt.Fprintf(buf, "%s %s %q\n", marks[e.Change], e.Kind, e.Key)
+		for _, f := range e.Fields {
+			fmt.Fprintf(buf, "    %s: %s => %s\n", f.Path, formatValue(f.Base), formatValue(f.Target))
+		}
+	}
+	fmt.Fprintf(buf, "%s\n", report.Summary())
+	n, err := io.WriteString(w, buf.String())
+	return int64(n), err
+}
+
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
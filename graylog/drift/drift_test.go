@@ -0,0 +1,126 @@
+package drift_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/go-set/v6"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/drift"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestCompare(t *testing.T) {
+	base := &backup.Snapshot{
+		IndexSets: []graylog.IndexSet{{ID: "is1", Title: "app", IndexPrefix: "app", Shards: 4}},
+		Streams: []graylog.Stream{{
+			ID: "s1", Title: "app", IndexSetID: "is1", CreatedAt: "2020-01-01T00:00:00.000Z",
+			Rules: []graylog.StreamRule{
+				{ID: "r1", StreamID: "s1", Field: "tag", Value: "app", Type: 1},
+				{ID: "r2", StreamID: "s1", Field: "level", Value: "3", Type: 4},
+			},
+		}},
+		Roles: []graylog.Role{
+			{Name: "viewer", Permissions: set.NewStrSet("streams:read")},
+			{Name: "old", Permissions: set.NewStrSet("*")},
+		},
+	}
+	// ids and the order of the stream rules are different but the stream is the same
+	target := &backup.Snapshot{
+		IndexSets: []graylog.IndexSet{{ID: "is2", Title: "app", IndexPrefix: "app", Shards: 2}},
+		Streams: []graylog.Stream{{
+			ID: "s2", Title: "app", IndexSetID: "is2", CreatedAt: "2021-01-01T00:00:00.000Z",
+			Rules: []graylog.StreamRule{
+				{ID: "r4", StreamID: "s2", Field: "level", Value: "3", Type: 4},
+				{ID: "r3", StreamID: "s2", Field: "tag", Value: "app", Type: 1},
+			},
+		}},
+		Roles: []graylog.Role{
+			{Name: "viewer", Permissions: set.NewStrSet("streams:read")},
+			{Name: "new", Permissions: set.NewStrSet("*")},
+		},
+	}
+
+	report, err := drift.Compare(base, target)
+	require.Nil(t, err)
+	require.Equal(t, []drift.Entity{
+		{Kind: "index_sets", Key: "app", Change: drift.ChangeChanged, Fields: []drift.Field{
+			{Path: "shards", Base: float64(4), Target: float64(2)},
+		}},
+		{Kind: "roles", Key: "new", Change: drift.ChangeAdded},
+		{Kind: "roles", Key: "old", Change: drift.ChangeRemoved},
+	}, report.Entities)
+
+	buf := &bytes.Buffer{}
+	_, err = report.WriteTo(buf)
+	require.Nil(t, err)
+	require.Equal(t, `~ index_sets "app"
+    shards: 4 => 2
++ roles "new"
+- roles "old"
+1 added, 1 removed, 1 changed
+`, buf.String())
+
+	report, err = drift.Compare(base, base)
+	require.Nil(t, err)
+	require.True(t, report.Empty())
+}
+
+func TestCompare_reference(t *testing.T) {
+	base := &backup.Snapshot{
+		IndexSets: []graylog.IndexSet{
+			{ID: "is1", Title: "app", IndexPrefix: "app"},
+			{ID: "is2", Title: "audit", IndexPrefix: "audit"},
+		},
+		Streams: []graylog.Stream{{ID: "s1", Title: "app", IndexSetID: "is1"}},
+	}
+	target := &backup.Snapshot{
+		IndexSets: []graylog.IndexSet{
+			{ID: "is3", Title: "app", IndexPrefix: "app"},
+			{ID: "is4", Title: "audit", IndexPrefix: "audit"},
+		},
+		Streams: []graylog.Stream{{ID: "s2", Title: "app", IndexSetID: "is4"}},
+	}
+
+	report, err := drift.Compare(base, target)
+	require.Nil(t, err)
+	require.Equal(t, []drift.Entity{
+		{Kind: "streams", Key: "app", Change: drift.ChangeChanged, Fields: []drift.Field{
+			{Path: "index_set", Base: "app", Target: "audit"},
+		}},
+	}, report.Entities)
+}
+
+func TestCompare_deterministic(t *testing.T) {
+	// sets and maps are encoded in random order, so compare the snapshot with itself many times
+	snap := &backup.Snapshot{
+		Inputs: []graylog.Input{
+			{ID: "i1", Title: "gelf"},
+			{ID: "i2", Title: "gelf"},
+		},
+		Extractors: map[string][]graylog.Extractor{
+			"i1": {{ID: "e1", Title: "level", Type: "regex", SourceField: "message", TargetField: "level"}},
+			"i2": {{ID: "e2", Title: "level", Type: "split_and_index", SourceField: "message", TargetField: "level"}},
+		},
+		Roles: []graylog.Role{
+			{Name: "viewer", Permissions: set.NewStrSet("streams:read", "dashboards:read", "searches:relative", "inputs:read")},
+		},
+		Users: []graylog.User{
+			{
+				Username:    "foo",
+				Roles:       set.NewStrSet("Reader", "viewer", "Admin"),
+				Permissions: set.NewStrSet("streams:read", "dashboards:read", "users:edit:foo"),
+			},
+		},
+		EventDefinitions: []graylog.EventDefinition{
+			{ID: "d1", Title: "errors", KeySpec: set.NewStrSet("source", "level", "facility")},
+		},
+	}
+	for i := 0; i < 50; i++ {
+		report, err := drift.Compare(snap, snap)
+		require.Nil(t, err)
+		require.True(t, report.Empty(), report.Entities)
+	}
+}
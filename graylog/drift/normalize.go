@@ -0,0 +1,265 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/backup"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+// serverFields are fields which the server generates.
+var serverFields = []string{"id", "created_at", "creator_user_id", "metrics", "exceptions", "converter_exceptions"}
+
+// kinds is the order of kinds in the report.
+var kinds = []string{
+	"index_sets", "streams", "outputs", "inputs", "extractors", "grok_patterns",
+	"pipeline_rules", "pipelines", "pipeline_connections", "roles", "users",
+	"event_notifications", "event_definitions",
+}
+
+// entities maps kinds and keys to the normalized entities.
+type entities map[string]map[string]map[string]interface{}
+
+// strip removes server generated fields and given fields.
+func strip(m map[string]interface{}, fields ...string) map[string]interface{} {
+	for _, k := range serverFields {
+		delete(m, k)
+	}
+	for _, k := range fields {
+		delete(m, k)
+	}
+	return m
+}
+
+// sortByJSON sorts values by their JSON encoding, so that the order of lists doesn't matter.
+func sortByJSON(a []interface{}) {
+	keys := make([]string, len(a))
+	for i, v := range a {
+		b, _ := json.Marshal(v)
+		keys[i] = string(b)
+	}
+	sort.Sort(byKeys{keys: keys, values: a})
+}
+
+// sortSets sorts the lists of given fields, which are sets such as role permissions.
+// Sets are encoded in random order, so they have to be sorted to compare snapshots.
+func sortSets(m map[string]interface{}, fields ...string) map[string]interface{} {
+	for _, k := range fields {
+		if a, ok := m[k].([]interface{}); ok {
+			sortByJSON(a)
+		}
+	}
+	return m
+}
+
+type byKeys struct {
+	keys   []string
+	values []interface{}
+}
+
+func (b byKeys) Len() int           { return len(b.keys) }
+func (b byKeys) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byKeys) Swap(i, j int) {
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+}
+
+// titles replaces ids with titles. Unknown ids are kept.
+func titles(ids []string, m map[string]string) []interface{} {
+	a := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if t, ok := m[id]; ok {
+			a[i] = t
+		} else {
+			a[i] = id
+		}
+	}
+	sortByJSON(a)
+	return a
+}
+
+// add adds an entity. If the key is duplicated, "#2" and so on are appended to the key.
+func (es entities) add(kind, key string, m map[string]interface{}) {
+	ms, ok := es[kind]
+	if !ok {
+		ms = map[string]map[string]interface{}{}
+		es[kind] = ms
+	}
+	k := key
+	for i := 2; ; i++ {
+		if _, ok := ms[k]; !ok {
+			break
+		}
+		k = fmt.Sprintf("%s #%d", key, i)
+	}
+	ms[k] = m
+}
+
+// normalize converts the snapshot to normalized entities.
+func normalize(snap *backup.Snapshot) (entities, error) {
+	es := entities{}
+	indexSets := map[string]string{}
+	streams := map[string]string{}
+	outputs := map[string]string{}
+	pipelines := map[string]string{}
+	notifs := map[string]string{}
+	for _, is := range snap.IndexSets {
+		indexSets[is.ID] = is.Title
+	}
+	for _, s := range snap.Streams {
+		streams[s.ID] = s.Title
+	}
+	for _, o := range snap.Outputs {
+		outputs[o.ID] = o.Title
+	}
+	for _, p := range snap.Pipelines {
+		pipelines[p.ID] = p.Title
+	}
+	for _, n := range snap.EventNotifications {
+		notifs[n.ID] = n.Title
+	}
+
+	for _, is := range snap.IndexSets {
+		m, err := util.ToMap(&is)
+		if err != nil {
+			return nil, err
+		}
+		es.add("index_sets", is.Title, strip(m, "creation_date"))
+	}
+	for _, s := range snap.Streams {
+		m, err := util.ToMap(&s)
+		if err != nil {
+			return nil, err
+		}
+		strip(m, "index_set_id", "outputs")
+		m["index_set"] = titles([]string{s.IndexSetID}, indexSets)[0]
+		m["outputs"] = titles(snap.StreamOutputs[s.ID], outputs)
+		for _, field := range []string{"rules", "alert_conditions"} {
+			a, ok := m[field].([]interface{})
+			if !ok {
+				continue
+			}
+			for _, v := range a {
+				if r, ok := v.(map[string]interface{}); ok {
+					strip(r, "stream_id")
+				}
+			}
+			sortByJSON(a)
+		}
+		es.add("streams", s.Title, m)
+	}
+	for _, o := range snap.Outputs {
+		m, err := util.ToMap(&o)
+		if err != nil {
+			return nil, err
+		}
+		es.add("outputs", o.Title, strip(m))
+	}
+	inputs := map[string]string{}
+	for i := range snap.Inputs {
+		input := &snap.Inputs[i]
+		inputs[input.ID] = input.Title
+		m, err := util.ToMap(input)
+		if err != nil {
+			return nil, err
+		}
+		// node ids are different between clusters
+		es.add("inputs", input.Title, strip(m, "node"))
+	}
+	// iterate inputs in order, so that the keys of extractors whose titles are duplicated are stable
+	inputIDs := make([]string, 0, len(snap.Extractors))
+	for inputID := range snap.Extractors {
+		inputIDs = append(inputIDs, inputID)
+	}
+	sort.Strings(inputIDs)
+	for _, inputID := range inputIDs {
+		for _, e := range snap.Extractors[inputID] {
+			m, err := util.ToMap(&e)
+			if err != nil {
+				return nil, err
+			}
+			es.add("extractors", titles([]string{inputID}, inputs)[0].(string)+"/"+e.Title, strip(m))
+		}
+	}
+	for _, g := range snap.GrokPatterns {
+		m, err := util.ToMap(&g)
+		if err != nil {
+			return nil, err
+		}
+		es.add("grok_patterns", g.Name, strip(m))
+	}
+	for _, r := range snap.PipelineRules {
+		m, err := util.ToMap(&r)
+		if err != nil {
+			return nil, err
+		}
+		es.add("pipeline_rules", r.Title, strip(m))
+	}
+	for _, p := range snap.Pipelines {
+		m, err := util.ToMap(&p)
+		if err != nil {
+			return nil, err
+		}
+		es.add("pipelines", p.Title, strip(m))
+	}
+	for _, c := range snap.PipelineConnections {
+		es.add("pipeline_connections", titles([]string{c.StreamID}, streams)[0].(string), map[string]interface{}{
+			"pipelines": titles(c.PipelineIDs, pipelines),
+		})
+	}
+	for _, r := range snap.Roles {
+		m, err := util.ToMap(&r)
+		if err != nil {
+			return nil, err
+		}
+		es.add("roles", r.Name, sortSets(strip(m), "permissions"))
+	}
+	for _, u := range snap.Users {
+		m, err := util.ToMap(&u)
+		if err != nil {
+			return nil, err
+		}
+		es.add("users", u.Username, sortSets(strip(m, "last_activity", "client_address", "session_active"), "roles", "permissions"))
+	}
+	for _, n := range snap.EventNotifications {
+		m, err := util.ToMap(&n)
+		if err != nil {
+			return nil, err
+		}
+		es.add("event_notifications", n.Title, strip(m))
+	}
+	for _, d := range snap.EventDefinitions {
+		m, err := util.ToMap(&d)
+		if err != nil {
+			return nil, err
+		}
+		sortSets(strip(m), "key_spec")
+		if cfg, ok := m["config"].(map[string]interface{}); ok {
+			if ids, ok := cfg["streams"].([]interface{}); ok {
+				cfg["streams"] = titles(toStrings(ids), streams)
+			}
+		}
+		if a, ok := m["notifications"].([]interface{}); ok {
+			for _, v := range a {
+				if n, ok := v.(map[string]interface{}); ok {
+					if id, ok := n["notification_id"].(string); ok {
+						n["notification_id"] = titles([]string{id}, notifs)[0]
+					}
+				}
+			}
+			sortByJSON(a)
+		}
+		es.add("event_definitions", d.Title, m)
+	}
+	return es, nil
+}
+
+func toStrings(a []interface{}) []string {
+	b := make([]string, len(a))
+	for i, v := range a {
+		b[i] = fmt.Sprint(v)
+	}
+	return b
+}
@@ -0,0 +1,18 @@
+/*
+Package exporter reads the resources of an existing Graylog cluster
+and converts them to Terraform configuration and "terraform import" commands.
+
+The configuration is rendered from the schemas of the provider's resources
+with the same Read functions as "terraform import", so the imported state matches the configuration.
+Ids of exported resources are replaced with references such as "graylog_index_set.default.id".
+
+Some resources aren't exported.
+
+* built-in resources such as the default stream, system streams, read only roles and read only users
+* users authenticated by LDAP
+* graylog_input_static_fields, because graylog_input has the static fields
+* graylog_grok_patterns and graylog_ldap_setting
+
+Sensitive attributes such as user's password can't be read, so they aren't written.
+*/
+package exporter
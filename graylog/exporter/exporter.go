@@ -0,0 +1,180 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/hcl"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/terraform"
+)
+
+type (
+	// Exporter exports the resources of a Graylog cluster.
+	Exporter struct {
+		// Config is the provider's configuration, which is used to call Graylog API.
+		Config *terraform.Config
+		// Types are the resource types to export such as "graylog_stream".
+		// If Types is empty, all supported types are exported.
+		Types []string
+	}
+
+	// Result is the result of the export.
+	Result struct {
+		Resources []Resource
+		Skipped   []Skipped
+	}
+
+	// Resource is an exported resource.
+	Resource struct {
+		Type string
+		Name string
+		// ID is the resource's id in the state.
+		ID string
+		// ImportID is the argument of "terraform import".
+		ImportID string
+		Block    *hcl.Block
+		// Omitted are the names of sensitive attributes which aren't written.
+		Omitted []string
+	}
+
+	// Skipped is a resource which is listed but isn't exported,
+	// for example because it is removed while exporting.
+	Skipped struct {
+		Type     string
+		ImportID string
+		Reason   string
+	}
+)
+
+// SupportedTypes returns the resource types which can be exported in the order of the export.
+func SupportedTypes() []string {
+	a := make([]string, len(listers))
+	for i, l := range listers {
+		a[i] = l.typ
+	}
+	return a
+}
+
+func (e *Exporter) types() (map[string]struct{}, error) {
+	types := map[string]struct{}{}
+	if len(e.Types) == 0 {
+		for _, t := range SupportedTypes() {
+			types[t] = struct{}{}
+		}
+		return types, nil
+	}
+	supported := map[string]struct{}{}
+	for _, t := range SupportedTypes() {
+		supported[t] = struct{}{}
+	}
+	for _, t := range e.Types {
+		if _, ok := supported[t]; !ok {
+			return nil, fmt.Errorf("the resource type '%s' isn't supported", t)
+		}
+		types[t] = struct{}{}
+	}
+	return types, nil
+}
+
+// Export reads the resources and converts them to the configuration.
+func (e *Exporter) Export(ctx context.Context) (*Result, error) {
+	if e.Config == nil {
+		return nil, errors.New("config is nil")
+	}
+	types, err := e.types()
+	if err != nil {
+		return nil, err
+	}
+	cl, err := e.Config.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	items, err := newLister(cl).list(ctx, types)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]*hcl.Names{}
+	r := &renderer{refs: map[string]string{}, roleRefs: map[string]string{}}
+	for i := range items {
+		it := &items[i]
+		if _, ok := names[it.typ]; !ok {
+			names[it.typ] = hcl.NewNames()
+		}
+		it.name = names[it.typ].New(it.title)
+		if it.ref == "" {
+			continue
+		}
+		if it.typ == "graylog_role" {
+			r.roleRefs[it.ref] = it.typ + "." + it.name + ".name"
+			continue
+		}
+		r.refs[it.ref] = it.typ + "." + it.name + ".id"
+	}
+
+	provider := terraform.Provider()
+	result := &Result{}
+	for _, it := range items {
+		res, ok := provider.ResourcesMap[it.typ]
+		if !ok {
+			return nil, fmt.Errorf("the resource type '%s' isn't found in the provider", it.typ)
+		}
+		d := res.Data(nil)
+		d.SetId(it.importID)
+		ds, err := res.Importer.StateContext(ctx, d, e.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import %s %s: %w", it.typ, it.importID, err)
+		}
+		d = ds[0]
+		if err := diagsError(res.ReadContext(ctx, d, e.Config)); err != nil {
+			return nil, fmt.Errorf("failed to read %s %s: %w", it.typ, it.importID, err)
+		}
+		if d.Id() == "" {
+			result.Skipped = append(result.Skipped, Skipped{
+				Type: it.typ, ImportID: it.importID, Reason: "the resource isn't found",
+			})
+			continue
+		}
+		block, omitted, err := r.render(it.typ, it.name, res.Schema, d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s %s: %w", it.typ, it.importID, err)
+		}
+		result.Resources = append(result.Resources, Resource{
+			Type:     it.typ,
+			Name:     it.name,
+			ID:       d.Id(),
+			ImportID: it.importID,
+			Block:    block,
+			Omitted:  omitted,
+		})
+	}
+	return result, nil
+}
+
+// WriteHCL writes the configuration of the resources.
+func WriteHCL(w io.Writer, resources []Resource) error {
+	file := &hcl.File{Blocks: make([]*hcl.Block, len(resources))}
+	for i, res := range resources {
+		file.Blocks[i] = res.Block
+	}
+	_, err := file.WriteTo(w)
+	return err
+}
+
+// diagsError returns the first error of the diagnostics.
+func diagsError(diags diag.Diagnostics) error {
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		if d.Detail != "" {
+			return fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+		return errors.New(d.Summary)
+	}
+	return nil
+}
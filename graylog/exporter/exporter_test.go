@@ -0,0 +1,315 @@
+package exporter_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/exporter"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/terraform"
+)
+
+const (
+	indexSetID = "5d84bf242ab79c000d691b7f"
+	streamID   = "5d84c1a92ab79c000d35d6ca"
+	ruleID     = "5d84c1a92ab79c000d35d6d7"
+	pipelineID = "5d84c1a92ab79c000d35d6e0"
+)
+
+func getRoute(path, body string) flute.Route {
+	return flute.Route{
+		Matcher: &flute.Matcher{
+			Method: "GET",
+			Path:   "/api" + path,
+		},
+		Response: &flute.Response{
+			Base:       http.Response{StatusCode: 200},
+			BodyString: body,
+		},
+	}
+}
+
+func TestExporter_Export(t *testing.T) {
+	indexSet := `{
+  "id": "` + indexSetID + `",
+  "title": "Default index set",
+  "description": "The Graylog default index set",
+  "index_prefix": "graylog",
+  "shards": 4,
+  "replicas": 0,
+  "rotation_strategy_class": "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy",
+  "rotation_strategy": {
+    "type": "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig",
+    "max_docs_per_index": 20000000
+  },
+  "retention_strategy_class": "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy",
+  "retention_strategy": {
+    "type": "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig",
+    "max_number_of_indices": 20
+  },
+  "creation_date": "2019-09-20T11:59:32.219Z",
+  "index_analyzer": "standard",
+  "index_optimization_max_num_segments": 1,
+  "index_optimization_disabled": false,
+  "writable": true,
+  "default": true
+}`
+	stream := `{
+  "id": "` + streamID + `",
+  "creator_user_id": "admin",
+  "matching_type": "AND",
+  "description": "error logs",
+  "created_at": "2019-09-20T12:02:06.078Z",
+  "disabled": false,
+  "title": "Error Logs",
+  "remove_matches_from_default_stream": true,
+  "index_set_id": "` + indexSetID + `",
+  "is_default": false
+}`
+	rule := `{
+  "field": "level",
+  "stream_id": "` + streamID + `",
+  "description": "",
+  "id": "` + ruleID + `",
+  "type": 1,
+  "inverted": false,
+  "value": "error"
+}`
+	pipeline := `{
+  "id": "` + pipelineID + `",
+  "title": "errors",
+  "description": "",
+  "source": "pipeline \"errors\"\nstage 0 match either\nend\n"
+}`
+	role := `{
+  "name": "Error Readers",
+  "description": "read error logs",
+  "permissions": ["streams:read:` + streamID + `"],
+  "read_only": false
+}`
+	user := `{
+  "id": "5d84c1a92ab79c000d35d6f0",
+  "username": "foo",
+  "email": "foo@example.com",
+  "full_name": "foo",
+  "permissions": [],
+  "timezone": "",
+  "session_timeout_ms": 3600000,
+  "external": false,
+  "roles": ["Error Readers", "Reader"],
+  "read_only": false
+}`
+
+	routes := []flute.Route{
+		getRoute("/system/indices/index_sets", `{"total": 1, "index_sets": [`+indexSet+`], "stats": {}}`),
+		getRoute("/system/indices/index_sets/"+indexSetID, indexSet),
+		getRoute("/streams", `{"total": 4, "streams": [
+  {"id": "000000000000000000000001", "title": "All messages", "is_default": true, "index_set_id": "`+indexSetID+`"},
+  {"id": "000000000000000000000002", "title": "All events", "index_set_id": "`+indexSetID+`"},
+  {"id": "000000000000000000000003", "title": "All system events", "index_set_id": "`+indexSetID+`"},
+  `+stream+`
+]}`),
+		getRoute("/streams/"+streamID, stream),
+		getRoute("/streams/000000000000000000000001/rules", `{"total": 0, "stream_rules": []}`),
+		getRoute("/streams/"+streamID+"/rules", `{"total": 1, "stream_rules": [`+rule+`]}`),
+		getRoute("/streams/"+streamID+"/rules/"+ruleID, rule),
+		getRoute("/plugins/org.graylog.plugins.pipelineprocessor/system/pipelines/pipeline", `[`+pipeline+`]`),
+		getRoute("/plugins/org.graylog.plugins.pipelineprocessor/system/pipelines/pipeline/"+pipelineID, pipeline),
+		getRoute("/plugins/org.graylog.plugins.pipelineprocessor/system/pipelines/connections", `[
+  {"id": "c1", "stream_id": "000000000000000000000001", "pipeline_ids": ["`+pipelineID+`"]},
+  {"id": "c2", "stream_id": "`+streamID+`", "pipeline_ids": []}
+]`),
+		getRoute("/plugins/org.graylog.plugins.pipelineprocessor/system/pipelines/connections/000000000000000000000001",
+			`{"id": "c1", "stream_id": "000000000000000000000001", "pipeline_ids": ["`+pipelineID+`"]}`),
+		getRoute("/roles", `{"total": 2, "roles": [
+  {"name": "Admin", "description": "built-in", "permissions": ["*"], "read_only": true},
+  `+role+`
+]}`),
+		getRoute("/roles/Error Readers", role),
+		getRoute("/users", `{"users": [
+  {"id": "local:admin", "username": "admin", "roles": ["Admin"], "read_only": true},
+  {"id": "ldap", "username": "ldap-user", "roles": ["Reader"], "external": true},
+  `+user+`
+]}`),
+		getRoute("/users/foo", user),
+	}
+
+	defaultTransport := http.DefaultClient.Transport
+	defer func() {
+		http.DefaultClient.Transport = defaultTransport
+	}()
+	http.DefaultClient.Transport = &flute.Transport{
+		T: t,
+		Services: []flute.Service{
+			{
+				Endpoint: "http://example.com",
+				Routes:   routes,
+			},
+		},
+	}
+
+	e := &exporter.Exporter{
+		Config: &terraform.Config{
+			Endpoint:     "http://example.com/api",
+			AuthName:     "admin",
+			AuthPassword: "admin",
+		},
+		Types: []string{
+			"graylog_index_set", "graylog_stream", "graylog_stream_rule",
+			"graylog_pipeline", "graylog_pipeline_connection", "graylog_role", "graylog_user",
+		},
+	}
+	result, err := e.Export(context.Background())
+	require.Nil(t, err)
+	require.Empty(t, result.Skipped)
+	require.Len(t, result.Resources, 7)
+	require.Equal(t, []string{"password"}, result.Resources[6].Omitted)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, exporter.WriteHCL(buf, result.Resources))
+	require.Equal(t, `resource "graylog_index_set" "default_index_set" {
+  index_analyzer                      = "standard"
+  index_optimization_max_num_segments = 1
+  index_prefix                        = "graylog"
+  retention_strategy_class            = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
+  rotation_strategy_class             = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy"
+  shards                              = 4
+  title                               = "Default index set"
+  default                             = true
+  description                         = "The Graylog default index set"
+  writable                            = true
+
+  retention_strategy {
+    type                  = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
+    max_number_of_indices = 20
+  }
+
+  rotation_strategy {
+    type               = "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig"
+    max_docs_per_index = 20000000
+  }
+}
+
+resource "graylog_stream" "error_logs" {
+  index_set_id                       = graylog_index_set.default_index_set.id
+  title                              = "Error Logs"
+  description                        = "error logs"
+  matching_type                      = "AND"
+  remove_matches_from_default_stream = true
+}
+
+resource "graylog_stream_rule" "error_logs_level" {
+  field     = "level"
+  stream_id = graylog_stream.error_logs.id
+  value     = "error"
+  type      = 1
+}
+
+resource "graylog_pipeline" "errors" {
+  source = <<EOT
+pipeline "errors"
+stage 0 match either
+end
+EOT
+}
+
+resource "graylog_pipeline_connection" "all_messages" {
+  pipeline_ids = [graylog_pipeline.errors.id]
+  stream_id    = "000000000000000000000001"
+}
+
+resource "graylog_role" "error_readers" {
+  name        = "Error Readers"
+  permissions = ["streams:read:5d84c1a92ab79c000d35d6ca"]
+  description = "read error logs"
+}
+
+resource "graylog_user" "foo" {
+  email     = "foo@example.com"
+  full_name = "foo"
+  username  = "foo"
+  roles     = [graylog_role.error_readers.name, "Reader"]
+}
+`, buf.String())
+
+	buf = &bytes.Buffer{}
+	require.Nil(t, exporter.WriteImportScript(buf, result.Resources))
+	require.Equal(t, `#!/bin/sh
+
+set -eu
+
+terraform import graylog_index_set.default_index_set '5d84bf242ab79c000d691b7f'
+terraform import graylog_stream.error_logs '5d84c1a92ab79c000d35d6ca'
+terraform import graylog_stream_rule.error_logs_level '5d84c1a92ab79c000d35d6ca/5d84c1a92ab79c000d35d6d7'
+terraform import graylog_pipeline.errors '5d84c1a92ab79c000d35d6e0'
+terraform import graylog_pipeline_connection.all_messages '000000000000000000000001'
+terraform import graylog_role.error_readers 'Error Readers'
+terraform import graylog_user.foo 'foo'
+`, buf.String())
+}
+
+func TestExporter_Export_unsupportedType(t *testing.T) {
+	e := &exporter.Exporter{
+		Config: &terraform.Config{Endpoint: "http://example.com/api"},
+		Types:  []string{"graylog_input_static_fields"},
+	}
+	_, err := e.Export(context.Background())
+	require.NotNil(t, err)
+}
+
+func TestExporter_Export_eventNotificationPages(t *testing.T) {
+	// the list API is paginated, so the notifications of the second page are also exported
+	pageRoute := func(page, body string) flute.Route {
+		route := getRoute("/events/notifications", body)
+		route.Matcher.PartOfQuery = map[string][]string{"page": {page}}
+		return route
+	}
+	foo := `{"id": "n1", "title": "foo", "description": "", "config": {"type": "http-notification-v1", "url": "http://example.com/foo"}}`
+	bar := `{"id": "n2", "title": "bar", "description": "", "config": {"type": "http-notification-v1", "url": "http://example.com/bar"}}`
+
+	defaultTransport := http.DefaultClient.Transport
+	defer func() {
+		http.DefaultClient.Transport = defaultTransport
+	}()
+	http.DefaultClient.Transport = &flute.Transport{
+		T: t,
+		Services: []flute.Service{
+			{
+				Endpoint: "http://example.com",
+				Routes: []flute.Route{
+					pageRoute("1", `{"notifications": [`+foo+`], "total": 2, "page": 1, "per_page": 1}`),
+					pageRoute("2", `{"notifications": [`+bar+`], "total": 2, "page": 2, "per_page": 1}`),
+					getRoute("/events/notifications/n1", foo),
+					getRoute("/events/notifications/n2", bar),
+				},
+			},
+		},
+	}
+
+	e := &exporter.Exporter{
+		Config: &terraform.Config{
+			Endpoint:     "http://example.com/api",
+			AuthName:     "admin",
+			AuthPassword: "admin",
+		},
+		Types: []string{"graylog_event_notification"},
+	}
+	result, err := e.Export(context.Background())
+	require.Nil(t, err)
+	require.Empty(t, result.Skipped)
+	require.Len(t, result.Resources, 2)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, exporter.WriteImportScript(buf, result.Resources))
+	require.Equal(t, `#!/bin/sh
+
+set -eu
+
+terraform import graylog_event_notification.foo 'n1'
+terraform import graylog_event_notification.bar 'n2'
+`, buf.String())
+}
@@ -0,0 +1,459 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+const (
+	// ids of the streams "All events" and "All system events", which Graylog creates
+	allEventsStreamID       = "000000000000000000000002"
+	allSystemEventsStreamID = "000000000000000000000003"
+
+	systemNotificationsConfigType = "system-notifications-v1"
+)
+
+type (
+	// item is a resource which is listed through the client.
+	item struct {
+		typ      string
+		title    string
+		importID string
+		// ref is the id which other resources refer to.
+		// If the resource isn't referred, ref is empty.
+		ref  string
+		name string
+	}
+
+	lister struct {
+		client     *client.Client
+		streams    []graylog.Stream
+		inputs     []graylog.Input
+		dashboards []graylog.Dashboard
+	}
+
+	listFunc func(ctx context.Context, l *lister) ([]item, error)
+)
+
+// listers are ordered so that resources are written after the resources which they refer to.
+var listers = []struct {
+	typ  string
+	list listFunc
+}{
+	{"graylog_index_set", listIndexSets},
+	{"graylog_stream", listStreams},
+	{"graylog_stream_rule", listStreamRules},
+	{"graylog_output", listOutputs},
+	{"graylog_stream_output", listStreamOutputs},
+	{"graylog_input", listInputs},
+	{"graylog_extractor", listExtractors},
+	{"graylog_grok_pattern", listGrokPatterns},
+	{"graylog_pipeline_rule", listPipelineRules},
+	{"graylog_pipeline", listPipelines},
+	{"graylog_pipeline_connection", listPipelineConnections},
+	{"graylog_role", listRoles},
+	{"graylog_user", listUsers},
+	{"graylog_dashboard", listDashboards},
+	{"graylog_dashboard_widget", listDashboardWidgets},
+	{"graylog_dashboard_widget_positions", listDashboardWidgetPositions},
+	{"graylog_alert_condition", listAlertConditions},
+	{"graylog_alarm_callback", listAlarmCallbacks},
+	{"graylog_event_notification", listEventNotifications},
+	{"graylog_event_definition", listEventDefinitions},
+}
+
+func newLister(cl *client.Client) *lister {
+	return &lister{client: cl}
+}
+
+func (l *lister) list(ctx context.Context, types map[string]struct{}) ([]item, error) {
+	items := []item{}
+	for _, a := range listers {
+		if _, ok := types[a.typ]; !ok {
+			continue
+		}
+		arr, err := a.list(ctx, l)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, arr...)
+	}
+	return items, nil
+}
+
+// getStreams returns all streams except for the system streams, which can't have rules, outputs and alerts.
+func (l *lister) getStreams(ctx context.Context) ([]graylog.Stream, error) {
+	if l.streams != nil {
+		return l.streams, nil
+	}
+	streams, _, _, err := l.client.GetStreams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get streams: %w", err)
+	}
+	l.streams = []graylog.Stream{}
+	for _, stream := range streams {
+		if stream.ID == allEventsStreamID || stream.ID == allSystemEventsStreamID {
+			continue
+		}
+		l.streams = append(l.streams, stream)
+	}
+	return l.streams, nil
+}
+
+func (l *lister) getInputs(ctx context.Context) ([]graylog.Input, error) {
+	if l.inputs != nil {
+		return l.inputs, nil
+	}
+	inputs, _, _, err := l.client.GetInputs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inputs: %w", err)
+	}
+	if inputs == nil {
+		inputs = []graylog.Input{}
+	}
+	l.inputs = inputs
+	return inputs, nil
+}
+
+func (l *lister) getDashboards(ctx context.Context) ([]graylog.Dashboard, error) {
+	if l.dashboards != nil {
+		return l.dashboards, nil
+	}
+	dashboards, _, _, err := l.client.GetDashboards(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dashboards: %w", err)
+	}
+	if dashboards == nil {
+		dashboards = []graylog.Dashboard{}
+	}
+	l.dashboards = dashboards
+	return dashboards, nil
+}
+
+func listIndexSets(ctx context.Context, l *lister) ([]item, error) {
+	// limit 0 means all index sets
+	indexSets, _, _, _, err := l.client.GetIndexSets(ctx, 0, 0, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index sets: %w", err)
+	}
+	items := make([]item, len(indexSets))
+	for i, is := range indexSets {
+		items[i] = item{typ: "graylog_index_set", title: is.Title, importID: is.ID, ref: is.ID}
+	}
+	return items, nil
+}
+
+func listStreams(ctx context.Context, l *lister) ([]item, error) {
+	streams, err := l.getStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []item{}
+	for _, stream := range streams {
+		if stream.IsDefault {
+			continue
+		}
+		items = append(items, item{typ: "graylog_stream", title: stream.Title, importID: stream.ID, ref: stream.ID})
+	}
+	return items, nil
+}
+
+func listStreamRules(ctx context.Context, l *lister) ([]item, error) {
+	streams, err := l.getStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []item{}
+	for _, stream := range streams {
+		rules, _, _, err := l.client.GetStreamRules(ctx, stream.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rules of the stream %s: %w", stream.ID, err)
+		}
+		for _, rule := range rules {
+			items = append(items, item{
+				typ: "graylog_stream_rule", title: stream.Title + " " + rule.Field, importID: stream.ID + "/" + rule.ID,
+			})
+		}
+	}
+	return items, nil
+}
+
+func listOutputs(ctx context.Context, l *lister) ([]item, error) {
+	outputs, _, _, err := l.client.GetOutputs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outputs: %w", err)
+	}
+	items := make([]item, len(outputs))
+	for i, output := range outputs {
+		items[i] = item{typ: "graylog_output", title: output.Title, importID: output.ID, ref: output.ID}
+	}
+	return items, nil
+}
+
+// listStreamOutputs lists streams which have outputs, because the id of graylog_stream_output is the stream id.
+func listStreamOutputs(ctx context.Context, l *lister) ([]item, error) {
+	streams, err := l.getStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []item{}
+	for _, stream := range streams {
+		outputs, _, _, err := l.client.GetStreamOutputs(ctx, stream.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get outputs of the stream %s: %w", stream.ID, err)
+		}
+		if len(outputs) == 0 {
+			continue
+		}
+		items = append(items, item{typ: "graylog_stream_output", title: stream.Title, importID: stream.ID})
+	}
+	return items, nil
+}
+
+func listInputs(ctx context.Context, l *lister) ([]item, error) {
+	inputs, err := l.getInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, len(inputs))
+	for i, input := range inputs {
+		items[i] = item{typ: "graylog_input", title: input.Title, importID: input.ID, ref: input.ID}
+	}
+	return items, nil
+}
+
+func listExtractors(ctx context.Context, l *lister) ([]item, error) {
+	inputs, err := l.getInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []item{}
+	for _, input := range inputs {
+		extractors, _, _, err := l.client.GetExtractors(ctx, input.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get extractors of the input %s: %w", input.ID, err)
+		}
+		for _, extractor := range extractors {
+			items = append(items, item{
+				typ: "graylog_extractor", title: input.Title + " " + extractor.Title, importID: input.ID + "/" + extractor.ID,
+			})
+		}
+	}
+	return items, nil
+}
+
+func listGrokPatterns(ctx context.Context, l *lister) ([]item, error) {
+	patterns, _, err := l.client.GetGrokPatterns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grok patterns: %w", err)
+	}
+	items := make([]item, len(patterns))
+	for i, pattern := range patterns {
+		items[i] = item{typ: "graylog_grok_pattern", title: pattern.Name, importID: pattern.ID}
+	}
+	return items, nil
+}
+
+func listPipelineRules(ctx context.Context, l *lister) ([]item, error) {
+	rules, _, err := l.client.GetPipelineRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline rules: %w", err)
+	}
+	items := make([]item, len(rules))
+	for i, rule := range rules {
+		items[i] = item{typ: "graylog_pipeline_rule", title: rule.Title, importID: rule.ID}
+	}
+	return items, nil
+}
+
+func listPipelines(ctx context.Context, l *lister) ([]item, error) {
+	pipes, _, err := l.client.GetPipelines(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipelines: %w", err)
+	}
+	items := make([]item, len(pipes))
+	for i, pipe := range pipes {
+		items[i] = item{typ: "graylog_pipeline", title: pipe.Title, importID: pipe.ID, ref: pipe.ID}
+	}
+	return items, nil
+}
+
+// listPipelineConnections lists the connections which have pipelines.
+// The id of graylog_pipeline_connection is the stream id.
+func listPipelineConnections(ctx context.Context, l *lister) ([]item, error) {
+	conns, _, err := l.client.GetPipelineConnections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline connections: %w", err)
+	}
+	streams, err := l.getStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[string]string, len(streams))
+	for _, stream := range streams {
+		titles[stream.ID] = stream.Title
+	}
+	items := []item{}
+	for _, conn := range conns {
+		if len(conn.PipelineIDs) == 0 {
+			continue
+		}
+		title, ok := titles[conn.StreamID]
+		if !ok {
+			title = conn.StreamID
+		}
+		items = append(items, item{typ: "graylog_pipeline_connection", title: title, importID: conn.StreamID})
+	}
+	return items, nil
+}
+
+func listRoles(ctx context.Context, l *lister) ([]item, error) {
+	roles, _, _, err := l.client.GetRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+	items := []item{}
+	for _, role := range roles {
+		if role.ReadOnly {
+			continue
+		}
+		items = append(items, item{typ: "graylog_role", title: role.Name, importID: role.Name, ref: role.Name})
+	}
+	return items, nil
+}
+
+func listUsers(ctx context.Context, l *lister) ([]item, error) {
+	users, _, err := l.client.GetUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	items := []item{}
+	for _, user := range users {
+		if user.ReadOnly || user.External {
+			continue
+		}
+		items = append(items, item{typ: "graylog_user", title: user.Username, importID: user.Username})
+	}
+	return items, nil
+}
+
+func listDashboards(ctx context.Context, l *lister) ([]item, error) {
+	dashboards, err := l.getDashboards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, len(dashboards))
+	for i, db := range dashboards {
+		items[i] = item{typ: "graylog_dashboard", title: db.Title, importID: db.ID, ref: db.ID}
+	}
+	return items, nil
+}
+
+func listDashboardWidgets(ctx context.Context, l *lister) ([]item, error) {
+	dashboards, err := l.getDashboards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []item{}
+	for _, db := range dashboards {
+		for _, widget := range db.Widgets {
+			items = append(items, item{
+				typ:      "graylog_dashboard_widget",
+				title:    db.Title + " " + widget.Description,
+				importID: db.ID + "/" + widget.ID,
+				ref:      widget.ID,
+			})
+		}
+	}
+	return items, nil
+}
+
+// listDashboardWidgetPositions lists dashboards which have widget positions,
+// because the id of graylog_dashboard_widget_positions is the dashboard id.
+func listDashboardWidgetPositions(ctx context.Context, l *lister) ([]item, error) {
+	dashboards, err := l.getDashboards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []item{}
+	for _, db := range dashboards {
+		if len(db.Positions) == 0 {
+			continue
+		}
+		items = append(items, item{typ: "graylog_dashboard_widget_positions", title: db.Title, importID: db.ID})
+	}
+	return items, nil
+}
+
+func listAlertConditions(ctx context.Context, l *lister) ([]item, error) {
+	streams, err := l.getStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []item{}
+	for _, stream := range streams {
+		conds, _, _, err := l.client.GetStreamAlertConditions(ctx, stream.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alert conditions of the stream %s: %w", stream.ID, err)
+		}
+		for _, cond := range conds {
+			items = append(items, item{
+				typ: "graylog_alert_condition", title: stream.Title + " " + cond.Title, importID: stream.ID + "/" + cond.ID,
+			})
+		}
+	}
+	return items, nil
+}
+
+func listAlarmCallbacks(ctx context.Context, l *lister) ([]item, error) {
+	streams, err := l.getStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []item{}
+	for _, stream := range streams {
+		callbacks, _, _, err := l.client.GetStreamAlarmCallbacks(ctx, stream.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alarm callbacks of the stream %s: %w", stream.ID, err)
+		}
+		for _, ac := range callbacks {
+			items = append(items, item{
+				typ: "graylog_alarm_callback", title: stream.Title + " " + ac.Title, importID: stream.ID + "/" + ac.ID,
+			})
+		}
+	}
+	return items, nil
+}
+
+func listEventNotifications(ctx context.Context, l *lister) ([]item, error) {
+	body, _, err := l.client.GetEventNotifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event notifications: %w", err)
+	}
+	items := make([]item, len(body.EventNotifications))
+	for i, notif := range body.EventNotifications {
+		items[i] = item{typ: "graylog_event_notification", title: notif.Title, importID: notif.ID, ref: notif.ID}
+	}
+	return items, nil
+}
+
+// listEventDefinitions lists event definitions except for the built-in definition of system notifications.
+func listEventDefinitions(ctx context.Context, l *lister) ([]item, error) {
+	body, _, err := l.client.GetEventDefinitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event definitions: %w", err)
+	}
+	items := []item{}
+	for _, definition := range body.EventDefinitions {
+		if definition.Config != nil && definition.Config.Type() == systemNotificationsConfigType {
+			continue
+		}
+		items = append(items, item{
+			typ: "graylog_event_definition", title: definition.Title, importID: definition.ID, ref: definition.ID,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,323 @@
+package exporter
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/hcl"
+)
+
+// serverManagedAttrs are optional and computed attributes which Graylog sets,
+// so they aren't written though they aren't computed only.
+var serverManagedAttrs = map[string]struct{}{
+	"client_address":  {},
+	"created_at":      {},
+	"creation_date":   {},
+	"creator_user_id": {},
+	"external":        {},
+	"last_activity":   {},
+	"read_only":       {},
+	"session_active":  {},
+	"user_id":         {},
+}
+
+// separateAttrs are attributes which aren't written because they are exported as other resources.
+// For example, the rules of streams are exported as graylog_stream_rule.
+var separateAttrs = map[string]map[string]struct{}{
+	"graylog_stream": {
+		"rule":       {},
+		"output_ids": {},
+	},
+}
+
+// renderer converts ResourceData to a resource block.
+type renderer struct {
+	// refs maps ids to references such as "graylog_stream.foo.id".
+	refs map[string]string
+	// roleRefs maps role names to references such as "graylog_role.foo.name".
+	// roleRefs are used only in the attribute "roles".
+	roleRefs map[string]string
+}
+
+func (r *renderer) render(
+	typ, name string, sch map[string]*schema.Schema, d *schema.ResourceData,
+) (*hcl.Block, []string, error) {
+	if attrs, ok := separateAttrs[typ]; ok {
+		filtered := make(map[string]*schema.Schema, len(sch))
+		for k, s := range sch {
+			if _, ok := attrs[k]; !ok {
+				filtered[k] = s
+			}
+		}
+		sch = filtered
+	}
+	values := make(map[string]interface{}, len(sch))
+	omitted := []string{}
+	for k, s := range sch {
+		if s.Sensitive {
+			omitted = append(omitted, k)
+			continue
+		}
+		values[k] = d.Get(k)
+	}
+	sort.Strings(omitted)
+	block := hcl.NewBlock("resource", typ, name)
+	if err := r.body(&block.Body, sch, values); err != nil {
+		return nil, nil, err
+	}
+	return block, omitted, nil
+}
+
+func (r *renderer) ref(key, v string) (string, bool) {
+	if key == "roles" {
+		ref, ok := r.roleRefs[v]
+		return ref, ok
+	}
+	ref, ok := r.refs[v]
+	return ref, ok
+}
+
+// sortedKeys returns the keys of the schema which should be written.
+// Required attributes are followed by optional attributes, and nested blocks come last.
+func sortedKeys(sch map[string]*schema.Schema, values map[string]interface{}) []string {
+	keys := []string{}
+	for k, s := range sch {
+		if s.Sensitive {
+			continue
+		}
+		if s.Computed && !s.Optional {
+			continue
+		}
+		if _, ok := serverManagedAttrs[k]; ok && s.Computed {
+			continue
+		}
+		if !s.Required && isDefault(s, values[k]) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	rank := func(k string) int {
+		s := sch[k]
+		if isBlock(s) {
+			return 2
+		}
+		if s.Required {
+			return 0
+		}
+		return 1
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := rank(keys[i]), rank(keys[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func isBlock(s *schema.Schema) bool {
+	_, ok := s.Elem.(*schema.Resource)
+	return ok && (s.Type == schema.TypeList || s.Type == schema.TypeSet)
+}
+
+// isDefault returns true if the value is the zero value or the schema's default value.
+// A single nested block whose attributes are default such as "alert_receivers {}" is also default.
+func isDefault(s *schema.Schema, v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	if s.Default != nil && reflect.DeepEqual(s.Default, v) {
+		return true
+	}
+	switch a := v.(type) {
+	case string:
+		return a == ""
+	case int:
+		return a == 0
+	case float64:
+		return a == 0
+	case bool:
+		return !a
+	case []interface{}:
+		return len(a) == 0 || (len(a) == 1 && isDefaultBlock(s, a[0]))
+	case map[string]interface{}:
+		return len(a) == 0
+	case *schema.Set:
+		return a.Len() == 0
+	}
+	return false
+}
+
+func isDefaultBlock(s *schema.Schema, v interface{}) bool {
+	elem, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return false
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v == nil
+	}
+	for k, a := range m {
+		if es, ok := elem.Schema[k]; !ok || !isDefault(es, a) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *renderer) body(body *hcl.Body, sch map[string]*schema.Schema, values map[string]interface{}) error {
+	for _, k := range sortedKeys(sch, values) {
+		s := sch[k]
+		v := values[k]
+		if isBlock(s) {
+			if err := r.blocks(body, k, s, v); err != nil {
+				return err
+			}
+			continue
+		}
+		expr, err := r.expr(k, s, v)
+		if err != nil {
+			return err
+		}
+		body.Attr(k, expr)
+	}
+	return nil
+}
+
+func listValues(v interface{}) []interface{} {
+	switch a := v.(type) {
+	case *schema.Set:
+		return a.List()
+	case []interface{}:
+		return a
+	}
+	return nil
+}
+
+// blocks writes nested blocks.
+// The blocks of a set are sorted by the content, because the order of sets isn't stable.
+func (r *renderer) blocks(body *hcl.Body, key string, s *schema.Schema, v interface{}) error {
+	elem := s.Elem.(*schema.Resource)
+	blocks := []*hcl.Block{}
+	for _, a := range listValues(v) {
+		m, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		block := hcl.NewBlock(key)
+		if err := r.body(&block.Body, elem.Schema, m); err != nil {
+			return err
+		}
+		blocks = append(blocks, block)
+	}
+	if s.Type == schema.TypeSet {
+		sort.SliceStable(blocks, func(i, j int) bool {
+			return blocks[i].String() < blocks[j].String()
+		})
+	}
+	for _, block := range blocks {
+		body.Append(block)
+	}
+	return nil
+}
+
+func (r *renderer) expr(key string, s *schema.Schema, v interface{}) (hcl.Expr, error) {
+	switch s.Type {
+	case schema.TypeString:
+		return r.stringExpr(key, s, v.(string))
+	case schema.TypeInt:
+		return hcl.Int(v.(int)), nil
+	case schema.TypeFloat:
+		return hcl.Float(v.(float64)), nil
+	case schema.TypeBool:
+		return hcl.Bool(v.(bool)), nil
+	case schema.TypeMap:
+		m := map[string]interface{}{}
+		for k, a := range v.(map[string]interface{}) {
+			m[k] = a
+		}
+		return hcl.Value(m)
+	case schema.TypeList, schema.TypeSet:
+		elem, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		values := listValues(v)
+		if s.Type == schema.TypeSet {
+			sortSet(values)
+		}
+		items := make([]hcl.Expr, len(values))
+		for i, a := range values {
+			item, err := r.expr(key, elem, a)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return hcl.List(items...), nil
+	}
+	return hcl.Value(v)
+}
+
+func sortSet(values []interface{}) {
+	sort.SliceStable(values, func(i, j int) bool {
+		a, aok := values[i].(string)
+		b, bok := values[j].(string)
+		if aok && bok {
+			return a < b
+		}
+		ai, aok := values[i].(int)
+		bi, bok := values[j].(int)
+		return aok && bok && ai < bi
+	})
+}
+
+// stringExpr returns a reference if the value is an exported resource's id,
+// jsonencode(...) if the value is JSON and otherwise a string.
+// Only attributes whose diffs are suppressed are regarded as JSON,
+// because jsonencode changes the format of JSON.
+func (r *renderer) stringExpr(key string, s *schema.Schema, v string) (hcl.Expr, error) {
+	if ref, ok := r.ref(key, v); ok {
+		return hcl.Raw(ref), nil
+	}
+	if t := strings.TrimSpace(v); s.DiffSuppressFunc != nil && (strings.HasPrefix(t, "{") || strings.HasPrefix(t, "[")) {
+		var a interface{}
+		if err := json.Unmarshal([]byte(v), &a); err == nil {
+			expr, err := hcl.Value(r.replaceRefs(a))
+			if err != nil {
+				return nil, err
+			}
+			return hcl.Call("jsonencode", expr), nil
+		}
+	}
+	return hcl.Heredoc(v), nil
+}
+
+// replaceRefs replaces ids in a value decoded from JSON with references.
+func (r *renderer) replaceRefs(v interface{}) interface{} {
+	switch a := v.(type) {
+	case string:
+		if ref, ok := r.refs[a]; ok {
+			return hcl.Raw(ref)
+		}
+		return a
+	case []interface{}:
+		arr := make([]interface{}, len(a))
+		for i, b := range a {
+			arr[i] = r.replaceRefs(b)
+		}
+		return arr
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(a))
+		for k, b := range a {
+			m[k] = r.replaceRefs(b)
+		}
+		return m
+	}
+	return v
+}
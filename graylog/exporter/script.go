@@ -0,0 +1,24 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteImportScript writes a shell script which imports the resources with "terraform import".
+func WriteImportScript(w io.Writer, resources []Resource) error {
+	if _, err := io.WriteString(w, "#!/bin/sh\n\nset -eu\n\n"); err != nil {
+		return err
+	}
+	for _, res := range resources {
+		if _, err := fmt.Fprintf(w, "terraform import %s.%s %s\n", res.Type, res.Name, shellQuote(res.ImportID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
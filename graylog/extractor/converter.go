@@ -0,0 +1,226 @@
+package extractor
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// runConverters converts the value of the extractor's target field.
+// Like Graylog, converters are applied only to the target field.
+func runConverters(extractor *graylog.Extractor, fields map[string]interface{}) error {
+	for _, converter := range extractor.Converters {
+		v, ok := fields[extractor.TargetField].(string)
+		if !ok {
+			return nil
+		}
+		cfg := converter.Config
+		if cfg == nil {
+			cfg = &graylog.ExtractorConverterConfig{}
+		}
+		a, err := convert(converter.Type, cfg, v)
+		if err != nil {
+			return fmt.Errorf("converter %s: %w", converter.Type, err)
+		}
+		fields[extractor.TargetField] = a
+	}
+	return nil
+}
+
+func convert(t string, cfg *graylog.ExtractorConverterConfig, v string) (interface{}, error) {
+	switch t {
+	case "date":
+		return convertDate(cfg, v)
+	case "numeric":
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i, nil
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, nil
+		}
+		return v, nil
+	case "lowercase":
+		return strings.ToLower(v), nil
+	case "uppercase":
+		return strings.ToUpper(v), nil
+	case "hash":
+		sum := md5.Sum([]byte(v))
+		return hex.EncodeToString(sum[:]), nil
+	default:
+		return nil, errors.New("unsupported converter type")
+	}
+}
+
+// convertDate parses a date with the Joda-Time format date_format.
+// If time_zone is empty, UTC is used. locale is ignored and only English names are supported.
+// Like Graylog, if date_format doesn't have the year, the current year in time_zone is used.
+func convertDate(cfg *graylog.ExtractorConverterConfig, v string) (time.Time, error) {
+	if cfg.DateFormat == "" {
+		return time.Time{}, errors.New("date_format is required")
+	}
+	layout, hasYear, err := jodaToLayout(cfg.DateFormat)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc := time.UTC
+	if cfg.TimeZone != "" {
+		loc, err = time.LoadLocation(cfg.TimeZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time_zone: %w", err)
+		}
+	}
+	t, err := time.ParseInLocation(layout, v, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !hasYear {
+		t = time.Date(
+			time.Now().In(loc).Year(), t.Month(), t.Day(),
+			t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+	return t, nil
+}
+
+// literalCheckTime differs from Go's reference time in all elements.
+// Formatting a text with it changes the text if the text has layout elements.
+var literalCheckTime = time.Date(2001, 2, 3, 4, 5, 6, 123456789, time.UTC)
+
+// jodaToLayout converts a Joda-Time date format to a Go time layout.
+// hasYear is true if the format has the year.
+// Go layouts can't escape literals, so literals which Go would parse as layout elements such as "1" and "Jan" are rejected.
+func jodaToLayout(format string) (layout string, hasYear bool, err error) {
+	var buf, lit strings.Builder
+	flush := func() error {
+		s := lit.String()
+		lit.Reset()
+		if literalCheckTime.Format(s) != s {
+			return fmt.Errorf("invalid date_format %s: the literal %q is unsupported", format, s)
+		}
+		buf.WriteString(s)
+		return nil
+	}
+	runes := []rune(format)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		if c == '\'' {
+			// quoted literal. '' is a single quote both inside and outside of a quoted literal.
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				lit.WriteRune('\'')
+				i += 2
+				continue
+			}
+			j := i + 1
+			for ; ; j++ {
+				if j >= len(runes) {
+					return "", false, fmt.Errorf("invalid date_format %s: the quoted literal isn't closed", format)
+				}
+				if runes[j] != '\'' {
+					lit.WriteRune(runes[j])
+					continue
+				}
+				if j+1 < len(runes) && runes[j+1] == '\'' {
+					lit.WriteRune('\'')
+					j++
+					continue
+				}
+				break
+			}
+			i = j + 1
+			continue
+		}
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			lit.WriteRune(c)
+			i++
+			continue
+		}
+		if err := flush(); err != nil {
+			return "", false, err
+		}
+		n := 1
+		for i+n < len(runes) && runes[i+n] == c {
+			n++
+		}
+		s, err := jodaToken(c, n)
+		if err != nil {
+			return "", false, fmt.Errorf("invalid date_format %s: %w", format, err)
+		}
+		if c == 'y' || c == 'Y' {
+			hasYear = true
+		}
+		buf.WriteString(s)
+		i += n
+	}
+	if err := flush(); err != nil {
+		return "", false, err
+	}
+	return buf.String(), hasYear, nil
+}
+
+func jodaToken(c rune, n int) (string, error) {
+	switch c {
+	case 'y', 'Y':
+		if n == 2 {
+			return "06", nil
+		}
+		return "2006", nil
+	case 'M':
+		switch n {
+		case 1:
+			return "1", nil
+		case 2:
+			return "01", nil
+		case 3:
+			return "Jan", nil
+		default:
+			return "January", nil
+		}
+	case 'd':
+		if n == 1 {
+			return "2", nil
+		}
+		return "02", nil
+	case 'D':
+		return "002", nil
+	case 'E':
+		if n <= 3 {
+			return "Mon", nil
+		}
+		return "Monday", nil
+	case 'H':
+		return "15", nil
+	case 'h':
+		if n == 1 {
+			return "3", nil
+		}
+		return "03", nil
+	case 'm':
+		if n == 1 {
+			return "4", nil
+		}
+		return "04", nil
+	case 's':
+		if n == 1 {
+			return "5", nil
+		}
+		return "05", nil
+	case 'S':
+		return strings.Repeat("0", n), nil
+	case 'a':
+		return "PM", nil
+	case 'z':
+		return "MST", nil
+	case 'Z':
+		if n == 1 {
+			return "-0700", nil
+		}
+		return "-07:00", nil
+	default:
+		return "", fmt.Errorf("unsupported pattern letter: %c", c)
+	}
+}
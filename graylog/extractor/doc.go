@@ -0,0 +1,7 @@
+/*
+Package extractor provides a local executor of Graylog's extractors.
+
+It applies extractors to sample messages without a Graylog server, so extractors can be tested in CI.
+Regular expressions are executed by Go's regexp package, so Java specific syntax isn't supported.
+*/
+package extractor
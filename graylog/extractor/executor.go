@@ -0,0 +1,217 @@
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/grok"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+type (
+	// Executor applies extractors to messages.
+	Executor struct {
+		// Grok is used to run grok extractors.
+		// If Grok is nil, grok extractors fail.
+		Grok *grok.Grok
+	}
+
+	// Result is the result of applying extractors to a message.
+	Result struct {
+		// Message is the message after extractors are applied.
+		Message map[string]interface{}
+		// Extractors are the results of each extractor in the order of execution.
+		Extractors []ExtractorResult
+	}
+
+	// ExtractorResult is the result of an extractor.
+	ExtractorResult struct {
+		ID    string
+		Title string
+		// Skipped is true when the source field isn't a string or the condition isn't matched.
+		Skipped bool
+		// Fields are the fields which the extractor sets.
+		Fields map[string]interface{}
+		// Error is the extractor's error. Even if an extractor fails, the following extractors are run.
+		Error error
+	}
+
+	// extracted is a value which is extracted from the source field.
+	// begin and end are the indexes of the value in the source field. If they are -1, the value can't be cut.
+	extracted struct {
+		field string
+		value interface{}
+		begin int
+		end   int
+	}
+)
+
+const fullyCutByExtractor = "fullyCutByExtractor"
+
+// reservedFields are the message fields which extractors can't cut.
+var reservedFields = map[string]struct{}{
+	"_id":                 {},
+	"timestamp":           {},
+	"source":              {},
+	"streams":             {},
+	"gl2_source_input":    {},
+	"gl2_source_node":     {},
+	"gl2_remote_ip":       {},
+	"gl2_remote_port":     {},
+	"gl2_remote_hostname": {},
+}
+
+// Run applies extractors to a message in the order of the extractors' order.
+// The given message isn't changed.
+func (exe *Executor) Run(msg map[string]interface{}, extractors []graylog.Extractor) *Result {
+	result := &Result{
+		Message:    make(map[string]interface{}, len(msg)),
+		Extractors: make([]ExtractorResult, 0, len(extractors)),
+	}
+	for k, v := range msg {
+		result.Message[k] = v
+	}
+	sorted := make([]graylog.Extractor, len(extractors))
+	copy(sorted, extractors)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Order < sorted[j].Order
+	})
+	for i := range sorted {
+		result.Extractors = append(result.Extractors, exe.runExtractor(result.Message, &sorted[i]))
+	}
+	return result
+}
+
+func (exe *Executor) runExtractor(msg map[string]interface{}, extractor *graylog.Extractor) ExtractorResult {
+	result := ExtractorResult{
+		ID:    extractor.ID,
+		Title: extractor.Title,
+	}
+	value, ok := msg[extractor.SourceField].(string)
+	if !ok {
+		result.Skipped = true
+		return result
+	}
+	matched, err := matchCondition(extractor, value)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	if !matched {
+		result.Skipped = true
+		return result
+	}
+	values, err := exe.extract(extractor, value)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	if len(values) == 0 {
+		return result
+	}
+	result.Fields = make(map[string]interface{}, len(values))
+	for _, v := range values {
+		result.Fields[v.field] = v.value
+	}
+	if err := runConverters(extractor, result.Fields); err != nil {
+		result.Error = err
+		return result
+	}
+	if extractor.CursorStrategy == "cut" && extractor.SourceField != extractor.TargetField {
+		if _, ok := reservedFields[extractor.SourceField]; !ok {
+			msg[extractor.SourceField] = cut(value, values)
+		}
+	}
+	for k, v := range result.Fields {
+		msg[k] = v
+	}
+	return result
+}
+
+func matchCondition(extractor *graylog.Extractor, value string) (bool, error) {
+	switch extractor.ConditionType {
+	case "", "none":
+		return true, nil
+	case "string":
+		return strings.Contains(value, extractor.ConditionValue), nil
+	case "regex":
+		re, err := regexp.Compile(extractor.ConditionValue)
+		if err != nil {
+			return false, fmt.Errorf("invalid condition_value: %w", err)
+		}
+		return re.MatchString(value), nil
+	default:
+		return false, fmt.Errorf("unsupported condition_type: %s", extractor.ConditionType)
+	}
+}
+
+func (exe *Executor) extract(extractor *graylog.Extractor, value string) ([]extracted, error) {
+	switch extractor.Type {
+	case "regex":
+		cfg := &graylog.ExtractorTypeRegexConfig{}
+		if err := decodeConfig(extractor.ExtractorConfig, cfg); err != nil {
+			return nil, err
+		}
+		return extractRegex(extractor.TargetField, cfg, value)
+	case "split_and_index":
+		cfg := &graylog.ExtractorTypeSplitAndIndexConfig{}
+		if err := decodeConfig(extractor.ExtractorConfig, cfg); err != nil {
+			return nil, err
+		}
+		return extractSplitAndIndex(extractor.TargetField, cfg, value)
+	case "json":
+		cfg := &graylog.ExtractorTypeJSONConfig{}
+		if err := decodeConfig(extractor.ExtractorConfig, cfg); err != nil {
+			return nil, err
+		}
+		return extractJSON(cfg, value)
+	case "grok":
+		if exe.Grok == nil {
+			return nil, errors.New("grok patterns aren't given to the executor")
+		}
+		return extractGrok(exe.Grok, extractor, value)
+	case "copy_input":
+		return []extracted{{field: extractor.TargetField, value: value, begin: 0, end: len(value)}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported extractor type: %s", extractor.Type)
+	}
+}
+
+// decodeConfig decodes an extractor config into a typed config.
+// The config is either a typed config or a map.
+func decodeConfig(src, dest interface{}) error {
+	if src == nil {
+		return errors.New("extractor_config is required")
+	}
+	if m, ok := src.(map[string]interface{}); ok {
+		if err := util.MSDecode(m, dest); err != nil {
+			return fmt.Errorf("invalid extractor_config: %w", err)
+		}
+		return nil
+	}
+	if reflect.TypeOf(src) != reflect.TypeOf(dest) || reflect.ValueOf(src).IsNil() {
+		return fmt.Errorf("invalid extractor_config: %T", src)
+	}
+	reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(src).Elem())
+	return nil
+}
+
+// cut removes the extracted values from the source value.
+func cut(value string, values []extracted) string {
+	for _, v := range values {
+		if v.begin < 0 || v.end < 0 || v.end > len(value) || v.begin > v.end {
+			continue
+		}
+		value = value[:v.begin] + value[v.end:]
+		break
+	}
+	if strings.TrimSpace(value) == "" {
+		return fullyCutByExtractor
+	}
+	return value
+}
@@ -0,0 +1,253 @@
+package extractor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/extractor"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/grok"
+)
+
+func TestExecutor_Run(t *testing.T) {
+	g, err := grok.New([]graylog.GrokPattern{
+		{Name: "INT", Pattern: `(?:[+-]?(?:[0-9]+))`},
+		{Name: "WORD", Pattern: `\b\w+\b`},
+	})
+	require.Nil(t, err)
+	exe := &extractor.Executor{Grok: g}
+
+	data := []struct {
+		title      string
+		msg        map[string]interface{}
+		extractors []graylog.Extractor
+		exp        map[string]interface{}
+		isErr      bool
+		skipped    bool
+	}{
+		{
+			title: "regex with cut",
+			msg:   map[string]interface{}{"message": "status=200 ok"},
+			extractors: []graylog.Extractor{{
+				Type:           "regex",
+				SourceField:    "message",
+				TargetField:    "status",
+				CursorStrategy: "cut",
+				ConditionType:  "none",
+				ExtractorConfig: &graylog.ExtractorTypeRegexConfig{
+					RegexValue: `status=(\d+)`,
+				},
+				Converters: []graylog.ExtractorConverter{{Type: "numeric"}},
+			}},
+			exp: map[string]interface{}{"message": "status= ok", "status": int64(200)},
+		},
+		{
+			title: "split and index with string condition",
+			msg:   map[string]interface{}{"message": "foo  bar baz"},
+			extractors: []graylog.Extractor{{
+				Type:           "split_and_index",
+				SourceField:    "message",
+				TargetField:    "second",
+				CursorStrategy: "copy",
+				ConditionType:  "string",
+				ConditionValue: "bar",
+				ExtractorConfig: map[string]interface{}{
+					"split_by": " ",
+					"index":    2,
+				},
+				Converters: []graylog.ExtractorConverter{{Type: "uppercase"}},
+			}},
+			exp: map[string]interface{}{"message": "foo  bar baz", "second": "BAR"},
+		},
+		{
+			title: "regex condition isn't matched",
+			msg:   map[string]interface{}{"message": "foo"},
+			extractors: []graylog.Extractor{{
+				Type:           "copy_input",
+				SourceField:    "message",
+				TargetField:    "copied",
+				ConditionType:  "regex",
+				ConditionValue: `^bar`,
+			}},
+			exp:     map[string]interface{}{"message": "foo"},
+			skipped: true,
+		},
+		{
+			title: "grok",
+			msg:   map[string]interface{}{"message": "user foo took 10"},
+			extractors: []graylog.Extractor{{
+				Type:        "grok",
+				SourceField: "message",
+				ExtractorConfig: &graylog.ExtractorTypeGrokConfig{
+					GrokPattern: `user %{WORD:user} took %{INT:took:int}`,
+				},
+			}},
+			exp: map[string]interface{}{
+				"message": "user foo took 10",
+				"user":    "foo",
+				"took":    int64(10),
+			},
+		},
+		{
+			title: "json",
+			msg:   map[string]interface{}{"message": `{"a": {"b": 1}, "c d": [1, "x"], "e": true}`},
+			extractors: []graylog.Extractor{{
+				Type:        "json",
+				SourceField: "message",
+				ExtractorConfig: &graylog.ExtractorTypeJSONConfig{
+					KeyPrefix:            "j_",
+					ReplaceKeyWhitespace: true,
+				},
+			}},
+			exp: map[string]interface{}{
+				"message": `{"a": {"b": 1}, "c d": [1, "x"], "e": true}`,
+				"j_a_b":   int64(1),
+				"j_c_d":   "1, x",
+				"j_e":     true,
+			},
+		},
+		{
+			title: "date converter",
+			msg:   map[string]interface{}{"message": "at 2020-01-02 03:04:05.678 done"},
+			extractors: []graylog.Extractor{{
+				Type:        "regex",
+				SourceField: "message",
+				TargetField: "at",
+				ExtractorConfig: &graylog.ExtractorTypeRegexConfig{
+					RegexValue: `at (\S+ \S+)`,
+				},
+				Converters: []graylog.ExtractorConverter{{
+					Type: "date",
+					Config: &graylog.ExtractorConverterConfig{
+						DateFormat: "yyyy-MM-dd HH:mm:ss.SSS",
+						TimeZone:   "Asia/Tokyo",
+					},
+				}},
+			}},
+			exp: map[string]interface{}{
+				"message": "at 2020-01-02 03:04:05.678 done",
+				"at":      time.Date(2020, 1, 1, 18, 4, 5, 678000000, time.UTC),
+			},
+		},
+		{
+			title: "date converter with fractions of a second",
+			msg:   map[string]interface{}{"message": "12:00:00.123"},
+			extractors: []graylog.Extractor{{
+				Type:        "copy_input",
+				SourceField: "message",
+				TargetField: "at",
+				Converters: []graylog.ExtractorConverter{{
+					Type: "date",
+					Config: &graylog.ExtractorConverterConfig{
+						DateFormat: "HH:mm:ss.SSS",
+					},
+				}},
+			}},
+			exp: map[string]interface{}{
+				"message": "12:00:00.123",
+				// the current year is used like Graylog
+				"at": time.Date(time.Now().UTC().Year(), 1, 1, 12, 0, 0, 123000000, time.UTC),
+			},
+		},
+		{
+			title: "date converter with a quoted literal",
+			msg:   map[string]interface{}{"message": "2020-01-02 at 3 o'clock"},
+			extractors: []graylog.Extractor{{
+				Type:        "copy_input",
+				SourceField: "message",
+				TargetField: "at",
+				Converters: []graylog.ExtractorConverter{{
+					Type: "date",
+					Config: &graylog.ExtractorConverterConfig{
+						DateFormat: "yyyy-MM-dd 'at' h 'o''clock'",
+					},
+				}},
+			}},
+			exp: map[string]interface{}{
+				"message": "2020-01-02 at 3 o'clock",
+				"at":      time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			title: "date converter with a literal which is a layout element",
+			msg:   map[string]interface{}{"message": "Jan 2020"},
+			extractors: []graylog.Extractor{{
+				Type:        "copy_input",
+				SourceField: "message",
+				TargetField: "at",
+				Converters: []graylog.ExtractorConverter{{
+					Type: "date",
+					Config: &graylog.ExtractorConverterConfig{
+						DateFormat: "'Jan' yyyy",
+					},
+				}},
+			}},
+			exp:   map[string]interface{}{"message": "Jan 2020"},
+			isErr: true,
+		},
+		{
+			title: "unsupported type",
+			msg:   map[string]interface{}{"message": "foo"},
+			extractors: []graylog.Extractor{{
+				Type:        "lookup_table",
+				SourceField: "message",
+			}},
+			exp:   map[string]interface{}{"message": "foo"},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			result := exe.Run(d.msg, d.extractors)
+			require.Len(t, result.Extractors, len(d.extractors))
+			if d.isErr {
+				require.NotNil(t, result.Extractors[0].Error)
+			} else {
+				require.Nil(t, result.Extractors[0].Error)
+			}
+			require.Equal(t, d.skipped, result.Extractors[0].Skipped)
+			require.Equal(t, len(d.exp), len(result.Message))
+			for k, v := range d.exp {
+				if tm, ok := v.(time.Time); ok {
+					a, ok := result.Message[k].(time.Time)
+					require.True(t, ok, k)
+					require.True(t, tm.Equal(a), a)
+					continue
+				}
+				require.Equal(t, v, result.Message[k], k)
+			}
+		})
+	}
+}
+
+func TestExecutor_Run_order(t *testing.T) {
+	exe := &extractor.Executor{}
+	result := exe.Run(map[string]interface{}{"message": "a,b"}, []graylog.Extractor{
+		{
+			Title:       "second",
+			Order:       2,
+			Type:        "copy_input",
+			SourceField: "first",
+			TargetField: "second",
+		},
+		{
+			Title:       "first",
+			Order:       1,
+			Type:        "split_and_index",
+			SourceField: "message",
+			TargetField: "first",
+			ExtractorConfig: &graylog.ExtractorTypeSplitAndIndexConfig{
+				SplitBy: ",",
+				Index:   2,
+			},
+		},
+	})
+	require.Equal(t, "first", result.Extractors[0].Title)
+	require.Equal(t, map[string]interface{}{
+		"message": "a,b",
+		"first":   "b",
+		"second":  "b",
+	}, result.Message)
+}
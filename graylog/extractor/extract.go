@@ -0,0 +1,174 @@
+package extractor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/grok"
+)
+
+func extractRegex(
+	target string, cfg *graylog.ExtractorTypeRegexConfig, value string,
+) ([]extracted, error) {
+	re, err := regexp.Compile(cfg.RegexValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex_value: %w", err)
+	}
+	if re.NumSubexp() == 0 {
+		return nil, errors.New("regex_value should have a capture group")
+	}
+	idx := re.FindStringSubmatchIndex(value)
+	if idx == nil || idx[2] < 0 {
+		return nil, nil
+	}
+	return []extracted{{field: target, value: value[idx[2]:idx[3]], begin: idx[2], end: idx[3]}}, nil
+}
+
+// extractSplitAndIndex splits the value by any character of split_by and returns the element at index.
+// index starts at 1 and adjacent separators are treated as one separator.
+func extractSplitAndIndex(
+	target string, cfg *graylog.ExtractorTypeSplitAndIndexConfig, value string,
+) ([]extracted, error) {
+	if cfg.SplitBy == "" {
+		return nil, errors.New("split_by is required")
+	}
+	if cfg.Index < 1 {
+		return nil, errors.New("index should be greater than 0")
+	}
+	n := 0
+	begin := -1
+	for i, r := range value {
+		if strings.ContainsRune(cfg.SplitBy, r) {
+			if begin >= 0 {
+				n++
+				if n == cfg.Index {
+					return []extracted{{field: target, value: value[begin:i], begin: begin, end: i}}, nil
+				}
+				begin = -1
+			}
+			continue
+		}
+		if begin < 0 {
+			begin = i
+		}
+	}
+	if begin >= 0 && n+1 == cfg.Index {
+		return []extracted{{field: target, value: value[begin:], begin: begin, end: len(value)}}, nil
+	}
+	return nil, nil
+}
+
+func extractGrok(g *grok.Grok, extractor *graylog.Extractor, value string) ([]extracted, error) {
+	p, err := g.CompileExtractor(extractor)
+	if err != nil {
+		return nil, err
+	}
+	fields, matched, err := p.Match(value)
+	if err != nil || !matched {
+		return nil, err
+	}
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	values := make([]extracted, len(names))
+	for i, k := range names {
+		values[i] = extracted{field: k, value: fields[k], begin: -1, end: -1}
+	}
+	return values, nil
+}
+
+func extractJSON(cfg *graylog.ExtractorTypeJSONConfig, value string) ([]extracted, error) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	obj := map[string]interface{}{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("the source field isn't a JSON object: %w", err)
+	}
+	c := *cfg
+	if c.ListSeparator == "" {
+		c.ListSeparator = ", "
+	}
+	if c.KVSeparator == "" {
+		c.KVSeparator = "="
+	}
+	if c.KeySeparator == "" {
+		c.KeySeparator = "_"
+	}
+	if c.KeyWhitespaceReplacement == "" {
+		c.KeyWhitespaceReplacement = "_"
+	}
+	fields := map[string]interface{}{}
+	for k, v := range obj {
+		flattenJSON(&c, fields, c.KeyPrefix+k, v)
+	}
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	values := make([]extracted, len(names))
+	for i, k := range names {
+		values[i] = extracted{field: k, value: fields[k], begin: -1, end: -1}
+	}
+	return values, nil
+}
+
+func flattenJSON(cfg *graylog.ExtractorTypeJSONConfig, fields map[string]interface{}, key string, value interface{}) {
+	if cfg.ReplaceKeyWhitespace {
+		key = strings.ReplaceAll(key, " ", cfg.KeyWhitespaceReplacement)
+	}
+	switch v := value.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		for k, a := range v {
+			flattenJSON(cfg, fields, key+cfg.KeySeparator+k, a)
+		}
+	case []interface{}:
+		elems := make([]string, 0, len(v))
+		for _, a := range v {
+			elems = append(elems, formatJSONValue(cfg, a))
+		}
+		fields[key] = strings.Join(elems, cfg.ListSeparator)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			fields[key] = i
+			return
+		}
+		f, _ := v.Float64()
+		fields[key] = f
+	default:
+		fields[key] = v
+	}
+}
+
+func formatJSONValue(cfg *graylog.ExtractorTypeJSONConfig, value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		elems := make([]string, len(keys))
+		for i, k := range keys {
+			elems[i] = k + cfg.KVSeparator + formatJSONValue(cfg, v[k])
+		}
+		return strings.Join(elems, cfg.ListSeparator)
+	case []interface{}:
+		elems := make([]string, len(v))
+		for i, a := range v {
+			elems[i] = formatJSONValue(cfg, a)
+		}
+		return strings.Join(elems, cfg.ListSeparator)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,122 @@
+package graylog
+
+const (
+	// AuthenticationBackendTypeLDAP is the type of LDAP authentication backends.
+	AuthenticationBackendTypeLDAP = "ldap"
+	// AuthenticationBackendTypeActiveDirectory is the type of Active Directory authentication backends.
+	AuthenticationBackendTypeActiveDirectory = "active-directory"
+
+	// TransportSecurityNone means the connection isn't encrypted.
+	TransportSecurityNone = "none"
+	// TransportSecurityTLS means the connection is encrypted with TLS (ldaps).
+	TransportSecurityTLS = "tls"
+	// TransportSecurityStartTLS means the connection is encrypted with StartTLS.
+	TransportSecurityStartTLS = "start_tls"
+)
+
+type (
+	// AuthenticationBackend represents an authentication service backend of Graylog 4.
+	// It replaces LDAPSetting, whose API is removed in Graylog 4.
+	AuthenticationBackend struct {
+		ID           string                       `json:"id,omitempty"`
+		Title        string                       `json:"title"`
+		Description  string                       `json:"description"`
+		DefaultRoles []string                     `json:"default_roles"`
+		Config       *AuthenticationBackendConfig `json:"config"`
+	}
+
+	// AuthenticationBackendConfig represents the configuration of a LDAP or Active Directory backend.
+	AuthenticationBackendConfig struct {
+		Type               string                 `json:"type"`
+		Servers            []AuthenticationServer `json:"servers"`
+		TransportSecurity  string                 `json:"transport_security"`
+		VerifyCertificates bool                   `json:"verify_certificates"`
+		SystemUserDN       string                 `json:"system_user_dn"`
+		SystemUserPassword *EncryptedValue        `json:"system_user_password,omitempty"`
+		UserSearchBase     string                 `json:"user_search_base"`
+		UserSearchPattern  string                 `json:"user_search_pattern"`
+		// UserUniqueIDAttribute is only for LDAP. Active Directory always uses objectGUID.
+		UserUniqueIDAttribute string `json:"user_unique_id_attribute,omitempty"`
+		UserNameAttribute     string `json:"user_name_attribute"`
+		UserFullNameAttribute string `json:"user_full_name_attribute"`
+	}
+
+	// AuthenticationServer represents a LDAP or Active Directory server.
+	AuthenticationServer struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	// EncryptedValue represents a secret of Graylog 4 such as the system user's password.
+	// Graylog never returns the value but returns whether the value is set.
+	// To change the secret, one of SetValue, KeepValue and DeleteValue must be set.
+	EncryptedValue struct {
+		IsSet       bool   `json:"is_set,omitempty"`
+		SetValue    string `json:"set_value,omitempty"`
+		KeepValue   bool   `json:"keep_value,omitempty"`
+		DeleteValue bool   `json:"delete_value,omitempty"`
+	}
+
+	// AuthenticationBackendGroupSync represents the group synchronization of a backend,
+	// which maps groups of the directory to Graylog teams.
+	// This feature is provided by Graylog Enterprise.
+	AuthenticationBackendGroupSync struct {
+		DefaultRoles []string                              `json:"default_roles"`
+		Config       *AuthenticationBackendGroupSyncConfig `json:"config"`
+	}
+
+	// AuthenticationBackendGroupSyncConfig represents the group search of the group synchronization.
+	AuthenticationBackendGroupSyncConfig struct {
+		Type               string `json:"type"`
+		GroupSearchBase    string `json:"group_search_base"`
+		GroupSearchPattern string `json:"group_search_pattern"`
+		GroupNameAttribute string `json:"team_name_attribute"`
+	}
+
+	// AuthenticationBackendBody represents the response body of APIs which return a backend.
+	AuthenticationBackendBody struct {
+		Backend *AuthenticationBackend `json:"backend"`
+	}
+
+	// AuthenticationBackendsBody represents Get Authentication Backends API's response body.
+	AuthenticationBackendsBody struct {
+		Backends []AuthenticationBackend `json:"backends"`
+		Total    int                     `json:"total"`
+		Page     int                     `json:"page"`
+		PerPage  int                     `json:"per_page"`
+		Count    int                     `json:"count"`
+	}
+
+	// AuthenticationServiceConfig represents the configuration of the authentication service.
+	// ActiveBackend is the id of the active backend, and it is empty if no backend is active.
+	AuthenticationServiceConfig struct {
+		ActiveBackend string `json:"active_backend"`
+	}
+
+	// AuthenticationServiceConfigBody represents the response body of the authentication service configuration API.
+	AuthenticationServiceConfigBody struct {
+		Configuration AuthenticationServiceConfig `json:"configuration"`
+	}
+
+	// AuthenticationBackendTestRequest represents the request body of the connection and login test APIs.
+	// BackendID is set to test the saved backend with the configuration whose secrets are kept.
+	AuthenticationBackendTestRequest struct {
+		BackendID string                     `json:"backend_id,omitempty"`
+		Backend   *AuthenticationBackend     `json:"backend_configuration"`
+		UserLogin *AuthenticationBackendUser `json:"user_login,omitempty"`
+	}
+
+	// AuthenticationBackendUser represents the user to test the login.
+	AuthenticationBackendUser struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	// AuthenticationBackendTestResult represents the result of the connection and login tests.
+	AuthenticationBackendTestResult struct {
+		Success bool                   `json:"success"`
+		Message string                 `json:"message"`
+		Errors  []string               `json:"errors"`
+		Result  map[string]interface{} `json:"result"`
+	}
+)
@@ -0,0 +1,170 @@
+package graylog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// ClusterConfigClassMessageProcessors is the class of MessageProcessorsConfig.
+	ClusterConfigClassMessageProcessors = "org.graylog2.messageprocessors.MessageProcessorsConfig"
+	// ClusterConfigClassSearches is the class of SearchesClusterConfig.
+	ClusterConfigClassSearches = "org.graylog2.indexer.searches.SearchesClusterConfig"
+	// ClusterConfigClassIndexSetsDefault is the class of IndexSetsDefaultConfiguration.
+	ClusterConfigClassIndexSetsDefault = "org.graylog2.configuration.IndexSetsDefaultConfiguration"
+	// ClusterConfigClassURLWhitelist is the class of URLWhitelistConfig.
+	ClusterConfigClassURLWhitelist = "org.graylog2.system.urlwhitelist.UrlWhitelist"
+	// ClusterConfigClassEvents is the class of EventsConfiguration.
+	ClusterConfigClassEvents = "org.graylog.events.configuration.EventsConfiguration"
+)
+
+type (
+	// ClusterConfig represents a cluster configuration of Graylog, which is stored per Java class name.
+	ClusterConfig interface {
+		Class() string
+	}
+
+	// MessageProcessorsConfig represents the order of message processors and the disabled processors.
+	MessageProcessorsConfig struct {
+		ProcessorOrder     []string `json:"processor_order"`
+		DisabledProcessors []string `json:"disabled_processors"`
+	}
+
+	// SearchesClusterConfig represents the configuration of searches.
+	// The values of the time range options are ISO 8601 durations such as "PT5M".
+	SearchesClusterConfig struct {
+		QueryTimeRangeLimit         string            `json:"query_time_range_limit"`
+		RelativeTimerangeOptions    map[string]string `json:"relative_timerange_options"`
+		SurroundingTimerangeOptions map[string]string `json:"surrounding_timerange_options"`
+		SurroundingFilterFields     []string          `json:"surrounding_filter_fields"`
+		AnalysisDisabledFields      []string          `json:"analysis_disabled_fields"`
+	}
+
+	// IndexSetsDefaultConfiguration represents the default configuration of new index sets.
+	IndexSetsDefaultConfiguration struct {
+		IndexAnalyzer                   string                 `json:"index_analyzer"`
+		Shards                          int                    `json:"shards"`
+		Replicas                        int                    `json:"replicas"`
+		IndexOptimizationMaxNumSegments int                    `json:"index_optimization_max_num_segments"`
+		IndexOptimizationDisabled       bool                   `json:"index_optimization_disabled"`
+		FieldTypeRefreshInterval        int                    `json:"field_type_refresh_interval"`
+		FieldTypeRefreshIntervalUnit    string                 `json:"field_type_refresh_interval_unit"`
+		RotationStrategyClass           string                 `json:"rotation_strategy_class"`
+		RotationStrategyConfig          map[string]interface{} `json:"rotation_strategy_config"`
+		RetentionStrategyClass          string                 `json:"retention_strategy_class"`
+		RetentionStrategyConfig         map[string]interface{} `json:"retention_strategy_config"`
+	}
+
+	// URLWhitelistConfig represents the URLs which Graylog is allowed to access such as HTTP notifications.
+	URLWhitelistConfig struct {
+		Entries  []URLWhitelistEntry `json:"entries"`
+		Disabled bool                `json:"disabled"`
+	}
+
+	// URLWhitelistEntry represents an entry of the URL whitelist.
+	// Type is "literal" or "regex".
+	URLWhitelistEntry struct {
+		ID    string `json:"id"`
+		Type  string `json:"type"`
+		Title string `json:"title"`
+		Value string `json:"value"`
+	}
+
+	// EventsConfiguration represents the configuration of events.
+	// Durations are in milliseconds.
+	EventsConfiguration struct {
+		EventsSearchTimeout              int64 `json:"events_search_timeout"`
+		EventsNotificationRetryPeriod    int64 `json:"events_notification_retry_period"`
+		EventsNotificationDefaultBacklog int64 `json:"events_notification_default_backlog"`
+		EventsCatchupWindow              int64 `json:"events_catchup_window"`
+		EventsNotificationTCPKeepalive   bool  `json:"events_notification_tcp_keepalive"`
+	}
+
+	// RawClusterConfig represents a cluster configuration of an unknown class.
+	RawClusterConfig struct {
+		ClassName string
+		Fields    map[string]interface{}
+	}
+)
+
+// clusterConfigRegistry has the constructors of the known cluster configurations.
+var clusterConfigRegistry = map[string]func() ClusterConfig{
+	ClusterConfigClassMessageProcessors: func() ClusterConfig { return &MessageProcessorsConfig{} },
+	ClusterConfigClassSearches:          func() ClusterConfig { return &SearchesClusterConfig{} },
+	ClusterConfigClassIndexSetsDefault:  func() ClusterConfig { return &IndexSetsDefaultConfiguration{} },
+	ClusterConfigClassURLWhitelist:      func() ClusterConfig { return &URLWhitelistConfig{} },
+	ClusterConfigClassEvents:            func() ClusterConfig { return &EventsConfiguration{} },
+}
+
+// IsKnownClusterConfigClass returns true if the class has the typed cluster configuration.
+func IsKnownClusterConfigClass(class string) bool {
+	_, ok := clusterConfigRegistry[class]
+	return ok
+}
+
+// NewClusterConfig returns an empty cluster configuration of the class.
+// If the class is unknown, RawClusterConfig is returned.
+func NewClusterConfig(class string) ClusterConfig {
+	if f, ok := clusterConfigRegistry[class]; ok {
+		return f()
+	}
+	return &RawClusterConfig{ClassName: class}
+}
+
+// UnmarshalClusterConfig unmarshals JSON into the cluster configuration of the class.
+// If strict is true, the unknown fields of the known class are an error.
+func UnmarshalClusterConfig(class string, b []byte, strict bool) (ClusterConfig, error) {
+	cfg := NewClusterConfig(class)
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if _, ok := cfg.(*RawClusterConfig); !ok && strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the cluster config %s: %w", class, err)
+	}
+	return cfg, nil
+}
+
+// Class returns "org.graylog2.messageprocessors.MessageProcessorsConfig".
+func (cfg *MessageProcessorsConfig) Class() string {
+	return ClusterConfigClassMessageProcessors
+}
+
+// Class returns "org.graylog2.indexer.searches.SearchesClusterConfig".
+func (cfg *SearchesClusterConfig) Class() string {
+	return ClusterConfigClassSearches
+}
+
+// Class returns "org.graylog2.configuration.IndexSetsDefaultConfiguration".
+func (cfg *IndexSetsDefaultConfiguration) Class() string {
+	return ClusterConfigClassIndexSetsDefault
+}
+
+// Class returns "org.graylog2.system.urlwhitelist.UrlWhitelist".
+func (cfg *URLWhitelistConfig) Class() string {
+	return ClusterConfigClassURLWhitelist
+}
+
+// Class returns "org.graylog.events.configuration.EventsConfiguration".
+func (cfg *EventsConfiguration) Class() string {
+	return ClusterConfigClassEvents
+}
+
+// Class returns the class name.
+func (cfg *RawClusterConfig) Class() string {
+	return cfg.ClassName
+}
+
+// MarshalJSON returns JSON encoding of the fields.
+func (cfg *RawClusterConfig) MarshalJSON() ([]byte, error) {
+	if cfg.Fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(cfg.Fields)
+}
+
+// UnmarshalJSON unmarshals JSON into the fields.
+func (cfg *RawClusterConfig) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &cfg.Fields)
+}
@@ -0,0 +1,25 @@
+package graylog_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestUnmarshalClusterConfig(t *testing.T) {
+	cfg, err := graylog.UnmarshalClusterConfig(graylog.ClusterConfigClassEvents, []byte(`{"events_search_timeout": 60000}`), true)
+	require.Nil(t, err)
+	require.Equal(t, &graylog.EventsConfiguration{EventsSearchTimeout: 60000}, cfg)
+
+	_, err = graylog.UnmarshalClusterConfig(graylog.ClusterConfigClassEvents, []byte(`{"events_search_timeot": 60000}`), true)
+	require.NotNil(t, err, "the unknown field is an error in the strict mode")
+	_, err = graylog.UnmarshalClusterConfig(graylog.ClusterConfigClassEvents, []byte(`{"events_search_timeot": 60000}`), false)
+	require.Nil(t, err)
+
+	cfg, err = graylog.UnmarshalClusterConfig("org.example.FooConfig", []byte(`{"foo": 1}`), true)
+	require.Nil(t, err)
+	require.Equal(t, "org.example.FooConfig", cfg.Class())
+	require.False(t, graylog.IsKnownClusterConfigClass(cfg.Class()))
+}
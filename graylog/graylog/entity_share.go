@@ -0,0 +1,68 @@
+package graylog
+
+const (
+	// CapabilityView allows the grantee to view the entity.
+	CapabilityView = "view"
+	// CapabilityManage allows the grantee to view and edit the entity.
+	CapabilityManage = "manage"
+	// CapabilityOwn allows the grantee to view, edit, delete and share the entity.
+	CapabilityOwn = "own"
+)
+
+type (
+	// EntityShareRequest represents the request body of the prepare and update entity shares APIs.
+	// The key of SelectedGranteeCapabilities is the grantee's GRN and the value is the capability.
+	// The selected grantees replace all grantees of the entity.
+	// If SelectedGranteeCapabilities is nil, it is encoded to null and the prepare API returns the current grantees.
+	EntityShareRequest struct {
+		SelectedGranteeCapabilities map[string]string `json:"selected_grantee_capabilities"`
+	}
+
+	// EntityShareResponse represents the response body of the prepare and update entity shares APIs.
+	EntityShareResponse struct {
+		Entity                           string                 `json:"entity"`
+		SharingUser                      string                 `json:"sharing_user"`
+		AvailableGrantees                []AvailableGrantee     `json:"available_grantees"`
+		AvailableCapabilities            []AvailableCapability  `json:"available_capabilities"`
+		ActiveShares                     []ActiveShare          `json:"active_shares"`
+		SelectedGranteeCapabilities      map[string]string      `json:"selected_grantee_capabilities"`
+		MissingPermissionsOnDependencies map[string]interface{} `json:"missing_permissions_on_dependencies"`
+		ValidationResult                 *ShareValidationResult `json:"validation_result"`
+	}
+
+	// AvailableGrantee represents a user or team which the entity can be shared with.
+	AvailableGrantee struct {
+		GRN   string `json:"grn"`
+		Type  string `json:"type"`
+		Title string `json:"title"`
+	}
+
+	// AvailableCapability represents a capability such as "view".
+	AvailableCapability struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+
+	// ActiveShare represents a grant of the entity.
+	ActiveShare struct {
+		Grant      string `json:"grant"`
+		Grantee    string `json:"grantee"`
+		Capability string `json:"capability"`
+	}
+
+	// ShareValidationResult represents the validation result of the selected grantees.
+	ShareValidationResult struct {
+		Failed bool                `json:"failed"`
+		Errors map[string][]string `json:"errors"`
+	}
+)
+
+// Grantees returns the effective grantees of the entity.
+// The key is the grantee's GRN and the value is the capability.
+func (resp *EntityShareResponse) Grantees() map[string]string {
+	grantees := make(map[string]string, len(resp.ActiveShares))
+	for _, share := range resp.ActiveShares {
+		grantees[share.Grantee] = share.Capability
+	}
+	return grantees
+}
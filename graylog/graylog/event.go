@@ -0,0 +1,131 @@
+package graylog
+
+import (
+	"time"
+)
+
+const (
+	// EventsSearchAlertsInclude is the filter to include both alerts and events.
+	EventsSearchAlertsInclude = "include"
+	// EventsSearchAlertsExclude is the filter to exclude alerts.
+	EventsSearchAlertsExclude = "exclude"
+	// EventsSearchAlertsOnly is the filter to return only alerts.
+	EventsSearchAlertsOnly = "only"
+
+	eventsSearchTimeFormat = "2006-01-02T15:04:05.000Z"
+)
+
+type (
+	// Event represents an event which an event definition creates.
+	Event struct {
+		ID                  string            `json:"id"`
+		EventDefinitionType string            `json:"event_definition_type"`
+		EventDefinitionID   string            `json:"event_definition_id"`
+		OriginContext       string            `json:"origin_context"`
+		Timestamp           string            `json:"timestamp"`
+		TimestampProcessing string            `json:"timestamp_processing"`
+		TimerangeStart      string            `json:"timerange_start"`
+		TimerangeEnd        string            `json:"timerange_end"`
+		Streams             []string          `json:"streams"`
+		SourceStreams       []string          `json:"source_streams"`
+		Message             string            `json:"message"`
+		Source              string            `json:"source"`
+		KeyTuple            []string          `json:"key_tuple"`
+		Key                 string            `json:"key"`
+		Priority            int               `json:"priority"`
+		Alert               bool              `json:"alert"`
+		Fields              map[string]string `json:"fields"`
+	}
+
+	// EventsSearchParameters represents the request body of the events search API.
+	EventsSearchParameters struct {
+		Query         string                `json:"query"`
+		Page          int                   `json:"page,omitempty"`
+		PerPage       int                   `json:"per_page,omitempty"`
+		TimeRange     EventsSearchTimeRange `json:"timerange"`
+		Filter        EventsSearchFilter    `json:"filter"`
+		SortBy        string                `json:"sort_by,omitempty"`
+		SortDirection string                `json:"sort_direction,omitempty"`
+	}
+
+	// EventsSearchTimeRange represents the time range of the events search.
+	// Type is either "relative", "absolute" or "keyword".
+	EventsSearchTimeRange struct {
+		Type    string `json:"type"`
+		Range   int    `json:"range,omitempty"`
+		From    string `json:"from,omitempty"`
+		To      string `json:"to,omitempty"`
+		Keyword string `json:"keyword,omitempty"`
+	}
+
+	// EventsSearchFilter represents the filter of the events search.
+	// Alerts is either "include", "exclude" or "only".
+	// Graylog's API doesn't support filtering by priority,
+	// so the client gets all events page by page and filters them with Priorities.
+	EventsSearchFilter struct {
+		Alerts           string   `json:"alerts"`
+		EventDefinitions []string `json:"event_definitions"`
+		Priorities       []int    `json:"-"`
+	}
+
+	// EventsSearchResult represents the response body of the events search API.
+	EventsSearchResult struct {
+		Events      []EventsSearchResultEvent `json:"events"`
+		UsedIndices []string                  `json:"used_indices"`
+		Parameters  EventsSearchParameters    `json:"parameters"`
+		TotalEvents int                       `json:"total_events"`
+		Duration    int                       `json:"duration"`
+		Context     EventsSearchContext       `json:"context"`
+	}
+
+	// EventsSearchResultEvent represents an event of the events search result.
+	EventsSearchResultEvent struct {
+		Event     Event  `json:"event"`
+		IndexName string `json:"index_name"`
+		IndexType string `json:"index_type"`
+	}
+
+	// EventsSearchContext has the summaries of the event definitions and streams which the events refer to.
+	EventsSearchContext struct {
+		EventDefinitions map[string]EventsSearchContextEntity `json:"event_definitions"`
+		Streams          map[string]EventsSearchContextEntity `json:"streams"`
+	}
+
+	// EventsSearchContextEntity represents a summary of an event definition or a stream.
+	EventsSearchContextEntity struct {
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+)
+
+// NewRelativeTimeRange returns a time range of the last given seconds.
+func NewRelativeTimeRange(seconds int) EventsSearchTimeRange {
+	return EventsSearchTimeRange{
+		Type:  "relative",
+		Range: seconds,
+	}
+}
+
+// NewAbsoluteTimeRange returns a time range between from and to.
+func NewAbsoluteTimeRange(from, to time.Time) EventsSearchTimeRange {
+	return EventsSearchTimeRange{
+		Type: "absolute",
+		From: from.UTC().Format(eventsSearchTimeFormat),
+		To:   to.UTC().Format(eventsSearchTimeFormat),
+	}
+}
+
+// Match returns true if the event matches the filter's priorities.
+// If Priorities is empty, all events match.
+func (filter *EventsSearchFilter) Match(event *Event) bool {
+	if len(filter.Priorities) == 0 {
+		return true
+	}
+	for _, p := range filter.Priorities {
+		if event.Priority == p {
+			return true
+		}
+	}
+	return false
+}
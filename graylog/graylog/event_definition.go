@@ -1,6 +1,17 @@
 package graylog
 
-import "github.com/suzuki-shunsuke/go-set/v6"
+import (
+	"encoding/json"
+
+	"github.com/suzuki-shunsuke/go-set/v6"
+)
+
+const (
+	// EventDefinitionConfigTypeAggregation is the type of EventDefinitionConfigAggregation.
+	EventDefinitionConfigTypeAggregation = "aggregation-v1"
+	// EventDefinitionConfigTypeCorrelation is the type of EventDefinitionConfigCorrelation.
+	EventDefinitionConfigTypeCorrelation = "correlation-v1"
+)
 
 type (
 	// EventDefinition represents an event definition.
@@ -14,14 +25,15 @@ type (
 		KeySpec              set.StrSet                          `json:"key_spec"`
 		NotificationSettings EventDefinitionNotificationSettings `json:"notification_settings"`
 		Notifications        []EventDefinitionNotification       `json:"notifications"`
-		Storage              []interface{}                       `json:"storage"`
-		Config               interface{}                         `json:"config"`
+		Storage              []EventDefinitionStorage            `json:"storage"`
+		Config               EventDefinitionConfig               `json:"config"`
 	}
 
-	//	EventDefinitionStorage struct {
-	//		Type    string   `json:"type"`
-	//		Streams []string `json:"streams"`
-	//	}
+	// EventDefinitionStorage represents an event definition's storage.
+	EventDefinitionStorage struct {
+		Type    string   `json:"type"`
+		Streams []string `json:"streams"`
+	}
 
 	EventDefinitionNotification struct {
 		NotificationID string `json:"notification_id"`
@@ -34,49 +46,85 @@ type (
 	}
 
 	EventDefinitionFieldSpec struct {
-		DataType  string        `json:"data_type"`
-		Providers []interface{} `json:"providers"`
+		DataType  string                             `json:"data_type"`
+		Providers []EventDefinitionFieldSpecProvider `json:"providers"`
 	}
 
-	//	EventDefinitionFieldSpecProvider struct {
-	//		Type          string `json:"type"`
-	//		Template      string `json:"template"`
-	//		RequireValues bool   `json:"require_values"`
-	//	}
+	// EventDefinitionFieldSpecProvider represents a field value provider.
+	// The type "template-v1" uses Template and RequireValues,
+	// and the type "lookup-v1" uses TableName and KeyField.
+	EventDefinitionFieldSpecProvider struct {
+		Type          string `json:"type"`
+		Template      string `json:"template,omitempty"`
+		RequireValues bool   `json:"require_values"`
+		TableName     string `json:"table_name,omitempty"`
+		KeyField      string `json:"key_field,omitempty"`
+	}
 
-	//	EventDefinitionConfig struct {
-	//		Type    string      `json:"type"`
-	//		Query   string      `json:"query"`
-	//		Streams *set.StrSet `json:"streams"`
-	//		// GroupBy []string    `json:"group_by"`
-	//		Series     *EventDefinitionConfigSeries    `json:"series"`
-	//		Conditions EventDefinitionConfigConditions `json:"conditions"`
-	//	}
+	// EventDefinitionConfig represents an event definition's configuration.
+	EventDefinitionConfig interface {
+		Type() string
+	}
+
+	// EventDefinitionConfigAggregation represents the configuration of the type "aggregation-v1".
+	EventDefinitionConfigAggregation struct {
+		Query           string                           `json:"query"`
+		QueryParameters []interface{}                    `json:"query_parameters,omitempty"`
+		Streams         []string                         `json:"streams"`
+		GroupBy         []string                         `json:"group_by"`
+		Series          []EventDefinitionConfigSeries    `json:"series"`
+		Conditions      *EventDefinitionConfigConditions `json:"conditions,omitempty"`
+		SearchWithinMS  int                              `json:"search_within_ms"`
+		ExecuteEveryMS  int                              `json:"execute_every_ms"`
+	}
+
+	// EventDefinitionConfigCorrelation represents the configuration of the type "correlation-v1".
+	// This type is provided by Graylog Enterprise.
+	EventDefinitionConfigCorrelation struct {
+		Sequence       []EventDefinitionCorrelationEvent `json:"sequence"`
+		MatchWithinMS  int                               `json:"match_within_ms"`
+		ExecuteEveryMS int                               `json:"execute_every_ms"`
+		InOrder        bool                              `json:"in_order"`
+	}
+
+	// EventDefinitionCorrelationEvent represents an element of the correlation sequence.
+	EventDefinitionCorrelationEvent struct {
+		ID                string `json:"id,omitempty"`
+		EventDefinitionID string `json:"event_definition_id"`
+		Negate            bool   `json:"negate"`
+	}
+
+	// EventDefinitionConfigUnknownType represents the configuration of an unknown type.
+	EventDefinitionConfigUnknownType struct {
+		T      string
+		Fields map[string]interface{}
+	}
 
 	EventDefinitionConfigSeries struct {
 		ID       string `json:"id"`
 		Function string `json:"function"`
-		// Field string `json:"field"`
+		Field    string `json:"field,omitempty"`
 	}
 
 	EventDefinitionConfigConditions struct {
-		Expression EventDefinitionConfigConditionsExpression `json:"expression"`
+		Expression *EventDefinitionConfigConditionsExpression `json:"expression"`
 	}
 
+	// EventDefinitionConfigConditionsExpression represents a node of the conditions' expression tree.
+	// Which fields are used depends on Expr.
+	//
+	//   "&&", "||", "<", "<=", ">", ">=", "==": Left and Right
+	//   "!": Left
+	//   "group": Child
+	//   "number-ref": Ref, which is the id of a series
+	//   "number": Value
 	EventDefinitionConfigConditionsExpression struct {
-		Expr  string                                          `json:"expr"`
-		Left  *EventDefinitionConfigConditionsExpressionLeft  `json:"left"`
-		Right *EventDefinitionConfigConditionsExpressionRight `json:"right"`
-	}
-
-	EventDefinitionConfigConditionsExpressionLeft struct {
-		Expr string `json:"expr"`
-		Ref  string `json:"ref"`
-	}
-
-	EventDefinitionConfigConditionsExpressionRight struct {
-		Expr  string `json:"expr"`
-		Value string `json:"value"`
+		Expr  string                                     `json:"expr"`
+		Left  *EventDefinitionConfigConditionsExpression `json:"left,omitempty"`
+		Right *EventDefinitionConfigConditionsExpression `json:"right,omitempty"`
+		Child *EventDefinitionConfigConditionsExpression `json:"child,omitempty"`
+		Ref   string                                     `json:"ref,omitempty"`
+		Value *float64                                   `json:"value,omitempty"`
 	}
 
 	// EventDefinitionsBody represents Get EventDefinitions API's response body.
@@ -91,3 +139,125 @@ type (
 		Query            string            `json:"query"`
 	}
 )
+
+// UnmarshalJSON unmarshals JSON into an event definition.
+// config is decoded to the struct according to its type.
+func (definition *EventDefinition) UnmarshalJSON(b []byte) error {
+	type alias EventDefinition
+	a := struct {
+		Config json.RawMessage `json:"config"`
+		*alias
+	}{
+		alias: (*alias)(definition),
+	}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	if len(a.Config) == 0 {
+		// like encoding/json, the field is kept if the key isn't included
+		return nil
+	}
+	if string(a.Config) == "null" {
+		definition.Config = nil
+		return nil
+	}
+	cfg, err := UnmarshalEventDefinitionConfig(a.Config)
+	if err != nil {
+		return err
+	}
+	definition.Config = cfg
+	return nil
+}
+
+// UnmarshalEventDefinitionConfig unmarshals JSON into an event definition's configuration according to its type.
+// If the type is unknown, EventDefinitionConfigUnknownType is returned.
+func UnmarshalEventDefinitionConfig(b []byte) (EventDefinitionConfig, error) {
+	t := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, err
+	}
+	var cfg EventDefinitionConfig
+	switch t.Type {
+	case EventDefinitionConfigTypeAggregation:
+		cfg = &EventDefinitionConfigAggregation{}
+	case EventDefinitionConfigTypeCorrelation:
+		cfg = &EventDefinitionConfigCorrelation{}
+	default:
+		cfg = &EventDefinitionConfigUnknownType{T: t.Type}
+	}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Type returns "aggregation-v1".
+func (cfg *EventDefinitionConfigAggregation) Type() string {
+	return EventDefinitionConfigTypeAggregation
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+// nil slices are encoded to empty arrays because Graylog doesn't accept null.
+func (cfg *EventDefinitionConfigAggregation) MarshalJSON() ([]byte, error) {
+	type alias EventDefinitionConfigAggregation
+	a := *cfg
+	if a.Streams == nil {
+		a.Streams = []string{}
+	}
+	if a.GroupBy == nil {
+		a.GroupBy = []string{}
+	}
+	if a.Series == nil {
+		a.Series = []EventDefinitionConfigSeries{}
+	}
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(&a),
+	})
+}
+
+// Type returns "correlation-v1".
+func (cfg *EventDefinitionConfigCorrelation) Type() string {
+	return EventDefinitionConfigTypeCorrelation
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventDefinitionConfigCorrelation) MarshalJSON() ([]byte, error) {
+	type alias EventDefinitionConfigCorrelation
+	a := *cfg
+	if a.Sequence == nil {
+		a.Sequence = []EventDefinitionCorrelationEvent{}
+	}
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(&a),
+	})
+}
+
+// Type returns the configuration's type.
+func (cfg *EventDefinitionConfigUnknownType) Type() string {
+	return cfg.T
+}
+
+// UnmarshalJSON unmarshals JSON into a raw map.
+func (cfg *EventDefinitionConfigUnknownType) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &cfg.Fields)
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventDefinitionConfigUnknownType) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(cfg.Fields)+1)
+	for k, v := range cfg.Fields {
+		fields[k] = v
+	}
+	fields["type"] = cfg.T
+	return json.Marshal(fields)
+}
@@ -0,0 +1,200 @@
+package graylog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseEventDefinitionConditions parses an expression such as "count > 10 && (avg_took >= 2.5 || !(errors == 0))"
+// and returns the expression tree of an aggregation's conditions.
+//
+// Identifiers refer to the ids of the series, and numbers are number values.
+// Supported operators are "||", "&&", "!", "<", "<=", ">", ">=", "==" and parentheses.
+func ParseEventDefinitionConditions(s string) (*EventDefinitionConfigConditionsExpression, error) {
+	tokens, err := tokenizeConditions(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := &conditionsParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in conditions: %s", p.tokens[p.pos], s)
+	}
+	return expr, nil
+}
+
+// String returns the expression in the format which ParseEventDefinitionConditions parses.
+func (expr *EventDefinitionConfigConditionsExpression) String() string {
+	if expr == nil {
+		return ""
+	}
+	switch expr.Expr {
+	case "&&", "||", "<", "<=", ">", ">=", "==":
+		return expr.Left.String() + " " + expr.Expr + " " + expr.Right.String()
+	case "!":
+		return "!" + expr.Left.String()
+	case "group":
+		return "(" + expr.Child.String() + ")"
+	case "number-ref":
+		return expr.Ref
+	case "number":
+		if expr.Value == nil {
+			return "0"
+		}
+		return strconv.FormatFloat(*expr.Value, 'f', -1, 64)
+	default:
+		return expr.Expr
+	}
+}
+
+// Refs returns the series ids which the expression refers to.
+func (expr *EventDefinitionConfigConditionsExpression) Refs() []string {
+	if expr == nil {
+		return nil
+	}
+	if expr.Expr == "number-ref" {
+		return []string{expr.Ref}
+	}
+	refs := expr.Left.Refs()
+	refs = append(refs, expr.Right.Refs()...)
+	return append(refs, expr.Child.Refs()...)
+}
+
+type conditionsParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *conditionsParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *conditionsParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *conditionsParser) parseOr() (*EventDefinitionConfigConditionsExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &EventDefinitionConfigConditionsExpression{Expr: "||", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionsParser) parseAnd() (*EventDefinitionConfigConditionsExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &EventDefinitionConfigConditionsExpression{Expr: "&&", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionsParser) parseUnary() (*EventDefinitionConfigConditionsExpression, error) {
+	if p.peek() == "!" {
+		p.next()
+		left, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &EventDefinitionConfigConditionsExpression{Expr: "!", Left: left}, nil
+	}
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek(); op {
+	case "<", "<=", ">", ">=", "==":
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &EventDefinitionConfigConditionsExpression{Expr: op, Left: left, Right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *conditionsParser) parseOperand() (*EventDefinitionConfigConditionsExpression, error) {
+	t := p.next()
+	switch t {
+	case "":
+		return nil, errors.New("unexpected end of conditions")
+	case "(":
+		child, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New(`")" is expected in conditions`)
+		}
+		return &EventDefinitionConfigConditionsExpression{Expr: "group", Child: child}, nil
+	case ")", "!", "&&", "||", "<", "<=", ">", ">=", "==":
+		return nil, fmt.Errorf("unexpected token %q in conditions", t)
+	}
+	if v, err := strconv.ParseFloat(t, 64); err == nil {
+		return &EventDefinitionConfigConditionsExpression{Expr: "number", Value: &v}, nil
+	}
+	return &EventDefinitionConfigConditionsExpression{Expr: "number-ref", Ref: t}, nil
+}
+
+func tokenizeConditions(s string) ([]string, error) {
+	tokens := []string{}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(s[i:], "&&"), strings.HasPrefix(s[i:], "||"),
+			strings.HasPrefix(s[i:], "<="), strings.HasPrefix(s[i:], ">="), strings.HasPrefix(s[i:], "=="):
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		case c == '(' || c == ')' || c == '!' || c == '<' || c == '>':
+			tokens = append(tokens, s[i:i+1])
+			i++
+		case isConditionsWordChar(c):
+			j := i
+			for j < len(s) && isConditionsWordChar(s[j]) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("invalid character %q in conditions: %s", c, s)
+		}
+	}
+	return tokens, nil
+}
+
+func isConditionsWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.'
+}
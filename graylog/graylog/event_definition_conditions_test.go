@@ -0,0 +1,49 @@
+package graylog_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/go-ptr"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestParseEventDefinitionConditions(t *testing.T) {
+	expr, err := graylog.ParseEventDefinitionConditions("")
+	require.Nil(t, err)
+	require.Nil(t, expr)
+
+	expr, err = graylog.ParseEventDefinitionConditions(
+		"9dfd012c-4f4d-417b-80d8-f7ebda2020a3 < 0")
+	require.Nil(t, err)
+	require.Equal(t, &graylog.EventDefinitionConfigConditionsExpression{
+		Expr: "<",
+		Left: &graylog.EventDefinitionConfigConditionsExpression{
+			Expr: "number-ref",
+			Ref:  "9dfd012c-4f4d-417b-80d8-f7ebda2020a3",
+		},
+		Right: &graylog.EventDefinitionConfigConditionsExpression{
+			Expr:  "number",
+			Value: ptr.PFloat64(0),
+		},
+	}, expr)
+
+	expr, err = graylog.ParseEventDefinitionConditions(
+		"count > 10 && (took >= 2.5 || !(errors == -1))")
+	require.Nil(t, err)
+	require.Equal(t, "&&", expr.Expr)
+	require.Equal(t, "group", expr.Right.Expr)
+	require.Equal(t, "||", expr.Right.Child.Expr)
+	require.Equal(t, []string{"count", "took", "errors"}, expr.Refs())
+	require.Equal(t, "count > 10 && (took >= 2.5 || !(errors == -1))", expr.String())
+
+	expr, err = graylog.ParseEventDefinitionConditions("a > 1 || b > 2 && c > 3")
+	require.Nil(t, err)
+	require.Equal(t, "||", expr.Expr, "&& has higher precedence than ||")
+
+	for _, s := range []string{"a >", "(a > 1", "a > 1)", "a $ 1", "&& a"} {
+		_, err := graylog.ParseEventDefinitionConditions(s)
+		require.NotNil(t, err, s)
+	}
+}
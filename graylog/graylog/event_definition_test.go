@@ -0,0 +1,103 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/testdata/event_definition/get"
+)
+
+func TestEventDefinition_UnmarshalJSON(t *testing.T) {
+	buf, err := ioutil.ReadFile("../testdata/event_definition/get/response.json")
+	require.Nil(t, err)
+	definition := &graylog.EventDefinition{}
+	require.Nil(t, json.Unmarshal(buf, definition))
+	require.Equal(t, get.Response(), definition)
+
+	b, err := json.Marshal(definition)
+	require.Nil(t, err)
+	eq, err := jsoneq.Equal(buf, b)
+	require.Nil(t, err)
+	require.True(t, eq, string(b))
+}
+
+func TestUnmarshalEventDefinitionConfig(t *testing.T) {
+	data := []struct {
+		title string
+		cfg   string
+		exp   graylog.EventDefinitionConfig
+	}{
+		{
+			title: "aggregation without conditions",
+			cfg: `{
+  "type": "aggregation-v1",
+  "query": "",
+  "streams": [],
+  "group_by": [],
+  "series": [],
+  "search_within_ms": 300000,
+  "execute_every_ms": 60000
+}`,
+			exp: &graylog.EventDefinitionConfigAggregation{
+				Streams:        []string{},
+				GroupBy:        []string{},
+				Series:         []graylog.EventDefinitionConfigSeries{},
+				SearchWithinMS: 300000,
+				ExecuteEveryMS: 60000,
+			},
+		},
+		{
+			title: "correlation",
+			cfg: `{
+  "type": "correlation-v1",
+  "sequence": [
+    {"id": "a", "event_definition_id": "5de5a9e7a1de18000cdfe192", "negate": false},
+    {"id": "b", "event_definition_id": "5de5aac1a1de18000cdfe2b3", "negate": true}
+  ],
+  "match_within_ms": 60000,
+  "execute_every_ms": 60000,
+  "in_order": true
+}`,
+			exp: &graylog.EventDefinitionConfigCorrelation{
+				Sequence: []graylog.EventDefinitionCorrelationEvent{
+					{ID: "a", EventDefinitionID: "5de5a9e7a1de18000cdfe192"},
+					{ID: "b", EventDefinitionID: "5de5aac1a1de18000cdfe2b3", Negate: true},
+				},
+				MatchWithinMS:  60000,
+				ExecuteEveryMS: 60000,
+				InOrder:        true,
+			},
+		},
+		{
+			title: "unknown type",
+			cfg: `{
+  "type": "foo-v1",
+  "foo": "bar"
+}`,
+			exp: &graylog.EventDefinitionConfigUnknownType{
+				T: "foo-v1",
+				Fields: map[string]interface{}{
+					"type": "foo-v1",
+					"foo":  "bar",
+				},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			cfg, err := graylog.UnmarshalEventDefinitionConfig([]byte(d.cfg))
+			require.Nil(t, err)
+			require.Equal(t, d.exp, cfg)
+			b, err := json.Marshal(cfg)
+			require.Nil(t, err)
+			eq, err := jsoneq.Equal([]byte(d.cfg), b)
+			require.Nil(t, err)
+			require.True(t, eq, string(b))
+		})
+	}
+}
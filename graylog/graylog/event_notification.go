@@ -1,12 +1,115 @@
 package graylog
 
+import (
+	"encoding/json"
+)
+
+const (
+	// EventNotificationConfigTypeEmail is the type of EventNotificationConfigEmail.
+	EventNotificationConfigTypeEmail = "email-notification-v1"
+	// EventNotificationConfigTypeHTTP is the type of EventNotificationConfigHTTP.
+	EventNotificationConfigTypeHTTP = "http-notification-v1"
+	// EventNotificationConfigTypeLegacyAlarmCallback is the type of EventNotificationConfigLegacyAlarmCallback.
+	EventNotificationConfigTypeLegacyAlarmCallback = "legacy-alarm-callback-notification-v1"
+	// EventNotificationConfigTypeSlack is the type of EventNotificationConfigSlack.
+	EventNotificationConfigTypeSlack = "slack-notification-v1"
+	// EventNotificationConfigTypeTeams is the type of EventNotificationConfigTeams.
+	EventNotificationConfigTypeTeams = "teams-notification-v1"
+	// EventNotificationConfigTypePagerDuty is the type of EventNotificationConfigPagerDuty.
+	EventNotificationConfigTypePagerDuty = "pagerduty-notification-v2"
+	// EventNotificationConfigTypeScript is the type of EventNotificationConfigScript.
+	EventNotificationConfigTypeScript = "script-notification-v1"
+)
+
 type (
-	// EventNotification represents an output.
+	// EventNotification represents an event notification.
 	EventNotification struct {
-		ID          string      `json:"id,omitempty"`
-		Title       string      `json:"title"`
-		Description string      `json:"description"`
-		Config      interface{} `json:"config"`
+		ID          string                  `json:"id,omitempty"`
+		Title       string                  `json:"title"`
+		Description string                  `json:"description"`
+		Config      EventNotificationConfig `json:"config"`
+	}
+
+	// EventNotificationConfig represents an event notification's configuration.
+	EventNotificationConfig interface {
+		Type() string
+	}
+
+	// EventNotificationConfigEmail represents the configuration of the type "email-notification-v1".
+	EventNotificationConfigEmail struct {
+		Sender           string   `json:"sender"`
+		Subject          string   `json:"subject"`
+		ReplyTo          string   `json:"reply_to,omitempty"`
+		BodyTemplate     string   `json:"body_template"`
+		HTMLBodyTemplate string   `json:"html_body_template,omitempty"`
+		EmailRecipients  []string `json:"email_recipients"`
+		UserRecipients   []string `json:"user_recipients"`
+		TimeZone         string   `json:"time_zone,omitempty"`
+	}
+
+	// EventNotificationConfigHTTP represents the configuration of the type "http-notification-v1".
+	EventNotificationConfigHTTP struct {
+		URL string `json:"url"`
+	}
+
+	// EventNotificationConfigLegacyAlarmCallback represents the configuration of
+	// the type "legacy-alarm-callback-notification-v1", which Graylog creates when it migrates alarm callbacks.
+	EventNotificationConfigLegacyAlarmCallback struct {
+		CallbackType  string                 `json:"callback_type"`
+		Configuration map[string]interface{} `json:"configuration"`
+	}
+
+	// EventNotificationConfigSlack represents the configuration of the type "slack-notification-v1".
+	// This type is provided by the Graylog Integrations plugin.
+	EventNotificationConfigSlack struct {
+		WebhookURL    string `json:"webhook_url"`
+		Channel       string `json:"channel"`
+		Color         string `json:"color"`
+		CustomMessage string `json:"custom_message"`
+		BacklogSize   int    `json:"backlog_size"`
+		UserName      string `json:"user_name,omitempty"`
+		NotifyChannel bool   `json:"notify_channel"`
+		LinkNames     bool   `json:"link_names"`
+		IconURL       string `json:"icon_url,omitempty"`
+		IconEmoji     string `json:"icon_emoji,omitempty"`
+		TimeZone      string `json:"time_zone,omitempty"`
+	}
+
+	// EventNotificationConfigTeams represents the configuration of the type "teams-notification-v1".
+	// This type is provided by the Graylog Integrations plugin.
+	EventNotificationConfigTeams struct {
+		WebhookURL    string `json:"webhook_url"`
+		Color         string `json:"color"`
+		CustomMessage string `json:"custom_message"`
+		BacklogSize   int    `json:"backlog_size"`
+		IconURL       string `json:"icon_url,omitempty"`
+		TimeZone      string `json:"time_zone,omitempty"`
+	}
+
+	// EventNotificationConfigPagerDuty represents the configuration of the type "pagerduty-notification-v2".
+	// This type is provided by the Graylog Integrations plugin.
+	EventNotificationConfigPagerDuty struct {
+		RoutingKey     string `json:"routing_key"`
+		CustomIncident bool   `json:"custom_incident"`
+		KeyPrefix      string `json:"key_prefix"`
+		ClientName     string `json:"client_name"`
+		ClientURL      string `json:"client_url"`
+	}
+
+	// EventNotificationConfigScript represents the configuration of the type "script-notification-v1".
+	// This type is provided by the Graylog Integrations plugin.
+	// ScriptTimeout is milliseconds.
+	EventNotificationConfigScript struct {
+		ScriptPath      string `json:"script_path"`
+		ScriptTimeout   int64  `json:"script_timeout"`
+		ScriptArgs      string `json:"script_args"`
+		ScriptSendStdin bool   `json:"script_send_stdin"`
+	}
+
+	// EventNotificationConfigUnknownType represents the configuration of an unknown type.
+	EventNotificationConfigUnknownType struct {
+		T      string
+		Fields map[string]interface{}
 	}
 
 	// EventNotificationsBody represents Get EventNotifications API's response body.
@@ -20,3 +123,217 @@ type (
 		Query              string              `json:"query"`
 	}
 )
+
+// UnmarshalJSON unmarshals JSON into an event notification.
+// config is decoded to the struct according to its type.
+func (notif *EventNotification) UnmarshalJSON(b []byte) error {
+	type alias EventNotification
+	a := struct {
+		Config json.RawMessage `json:"config"`
+		*alias
+	}{
+		alias: (*alias)(notif),
+	}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	if len(a.Config) == 0 {
+		// like encoding/json, the field is kept if the key isn't included
+		return nil
+	}
+	if string(a.Config) == "null" {
+		notif.Config = nil
+		return nil
+	}
+	cfg, err := UnmarshalEventNotificationConfig(a.Config)
+	if err != nil {
+		return err
+	}
+	notif.Config = cfg
+	return nil
+}
+
+// UnmarshalEventNotificationConfig unmarshals JSON into an event notification's configuration according to its type.
+// If the type is unknown, EventNotificationConfigUnknownType is returned.
+func UnmarshalEventNotificationConfig(b []byte) (EventNotificationConfig, error) {
+	t := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, err
+	}
+	var cfg EventNotificationConfig
+	switch t.Type {
+	case EventNotificationConfigTypeEmail:
+		cfg = &EventNotificationConfigEmail{}
+	case EventNotificationConfigTypeHTTP:
+		cfg = &EventNotificationConfigHTTP{}
+	case EventNotificationConfigTypeLegacyAlarmCallback:
+		cfg = &EventNotificationConfigLegacyAlarmCallback{}
+	case EventNotificationConfigTypeSlack:
+		cfg = &EventNotificationConfigSlack{}
+	case EventNotificationConfigTypeTeams:
+		cfg = &EventNotificationConfigTeams{}
+	case EventNotificationConfigTypePagerDuty:
+		cfg = &EventNotificationConfigPagerDuty{}
+	case EventNotificationConfigTypeScript:
+		cfg = &EventNotificationConfigScript{}
+	default:
+		cfg = &EventNotificationConfigUnknownType{T: t.Type}
+	}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Type returns "email-notification-v1".
+func (cfg *EventNotificationConfigEmail) Type() string {
+	return EventNotificationConfigTypeEmail
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+// nil slices are encoded to empty arrays because Graylog doesn't accept null.
+func (cfg *EventNotificationConfigEmail) MarshalJSON() ([]byte, error) {
+	type alias EventNotificationConfigEmail
+	a := *cfg
+	if a.EmailRecipients == nil {
+		a.EmailRecipients = []string{}
+	}
+	if a.UserRecipients == nil {
+		a.UserRecipients = []string{}
+	}
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(&a),
+	})
+}
+
+// Type returns "http-notification-v1".
+func (cfg *EventNotificationConfigHTTP) Type() string {
+	return EventNotificationConfigTypeHTTP
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventNotificationConfigHTTP) MarshalJSON() ([]byte, error) {
+	type alias EventNotificationConfigHTTP
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(cfg),
+	})
+}
+
+// Type returns "legacy-alarm-callback-notification-v1".
+func (cfg *EventNotificationConfigLegacyAlarmCallback) Type() string {
+	return EventNotificationConfigTypeLegacyAlarmCallback
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventNotificationConfigLegacyAlarmCallback) MarshalJSON() ([]byte, error) {
+	type alias EventNotificationConfigLegacyAlarmCallback
+	a := *cfg
+	if a.Configuration == nil {
+		a.Configuration = map[string]interface{}{}
+	}
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(&a),
+	})
+}
+
+// Type returns "slack-notification-v1".
+func (cfg *EventNotificationConfigSlack) Type() string {
+	return EventNotificationConfigTypeSlack
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventNotificationConfigSlack) MarshalJSON() ([]byte, error) {
+	type alias EventNotificationConfigSlack
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(cfg),
+	})
+}
+
+// Type returns "teams-notification-v1".
+func (cfg *EventNotificationConfigTeams) Type() string {
+	return EventNotificationConfigTypeTeams
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventNotificationConfigTeams) MarshalJSON() ([]byte, error) {
+	type alias EventNotificationConfigTeams
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(cfg),
+	})
+}
+
+// Type returns "pagerduty-notification-v2".
+func (cfg *EventNotificationConfigPagerDuty) Type() string {
+	return EventNotificationConfigTypePagerDuty
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventNotificationConfigPagerDuty) MarshalJSON() ([]byte, error) {
+	type alias EventNotificationConfigPagerDuty
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(cfg),
+	})
+}
+
+// Type returns "script-notification-v1".
+func (cfg *EventNotificationConfigScript) Type() string {
+	return EventNotificationConfigTypeScript
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventNotificationConfigScript) MarshalJSON() ([]byte, error) {
+	type alias EventNotificationConfigScript
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{
+		Type:  cfg.Type(),
+		alias: (*alias)(cfg),
+	})
+}
+
+// Type returns the configuration's type.
+func (cfg *EventNotificationConfigUnknownType) Type() string {
+	return cfg.T
+}
+
+// UnmarshalJSON unmarshals JSON into a raw map.
+func (cfg *EventNotificationConfigUnknownType) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &cfg.Fields)
+}
+
+// MarshalJSON returns JSON encoding of the configuration with the type.
+func (cfg *EventNotificationConfigUnknownType) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(cfg.Fields)+1)
+	for k, v := range cfg.Fields {
+		fields[k] = v
+	}
+	fields["type"] = cfg.T
+	return json.Marshal(fields)
+}
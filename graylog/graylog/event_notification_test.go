@@ -0,0 +1,122 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestUnmarshalEventNotificationConfig(t *testing.T) {
+	data := []struct {
+		title string
+		cfg   string
+		exp   graylog.EventNotificationConfig
+	}{
+		{
+			title: "email",
+			cfg: `{
+  "type": "email-notification-v1",
+  "sender": "graylog@example.com",
+  "subject": "Graylog event notification",
+  "body_template": "hello",
+  "email_recipients": ["graylog@example.com"],
+  "user_recipients": []
+}`,
+			exp: &graylog.EventNotificationConfigEmail{
+				Sender:          "graylog@example.com",
+				Subject:         "Graylog event notification",
+				BodyTemplate:    "hello",
+				EmailRecipients: []string{"graylog@example.com"},
+				UserRecipients:  []string{},
+			},
+		},
+		{
+			title: "http",
+			cfg:   `{"type": "http-notification-v1", "url": "http://example.com"}`,
+			exp: &graylog.EventNotificationConfigHTTP{
+				URL: "http://example.com",
+			},
+		},
+		{
+			title: "slack",
+			cfg: `{
+  "type": "slack-notification-v1",
+  "webhook_url": "https://hooks.slack.com/services/xxx",
+  "channel": "#general",
+  "color": "#FF0000",
+  "custom_message": "hello",
+  "backlog_size": 5,
+  "user_name": "Graylog",
+  "notify_channel": false,
+  "link_names": true
+}`,
+			exp: &graylog.EventNotificationConfigSlack{
+				WebhookURL:    "https://hooks.slack.com/services/xxx",
+				Channel:       "#general",
+				Color:         "#FF0000",
+				CustomMessage: "hello",
+				BacklogSize:   5,
+				UserName:      "Graylog",
+				LinkNames:     true,
+			},
+		},
+		{
+			title: "legacy alarm callback",
+			cfg: `{
+  "type": "legacy-alarm-callback-notification-v1",
+  "callback_type": "org.graylog2.alarmcallbacks.HTTPAlarmCallback",
+  "configuration": {"url": "https://example.com"}
+}`,
+			exp: &graylog.EventNotificationConfigLegacyAlarmCallback{
+				CallbackType: "org.graylog2.alarmcallbacks.HTTPAlarmCallback",
+				Configuration: map[string]interface{}{
+					"url": "https://example.com",
+				},
+			},
+		},
+		{
+			title: "script",
+			cfg: `{
+  "type": "script-notification-v1",
+  "script_path": "/usr/local/bin/notify",
+  "script_timeout": 10000,
+  "script_args": "--event",
+  "script_send_stdin": true
+}`,
+			exp: &graylog.EventNotificationConfigScript{
+				ScriptPath:      "/usr/local/bin/notify",
+				ScriptTimeout:   10000,
+				ScriptArgs:      "--event",
+				ScriptSendStdin: true,
+			},
+		},
+		{
+			title: "unknown",
+			cfg:   `{"type": "custom-notification-v1", "command": "/usr/local/bin/notify"}`,
+			exp: &graylog.EventNotificationConfigUnknownType{
+				T: "custom-notification-v1",
+				Fields: map[string]interface{}{
+					"type":    "custom-notification-v1",
+					"command": "/usr/local/bin/notify",
+				},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			cfg, err := graylog.UnmarshalEventNotificationConfig([]byte(d.cfg))
+			require.Nil(t, err)
+			require.Equal(t, d.exp, cfg)
+
+			b, err := json.Marshal(cfg)
+			require.Nil(t, err)
+			eq, err := jsoneq.Equal([]byte(d.cfg), b)
+			require.Nil(t, err)
+			require.True(t, eq, string(b))
+		})
+	}
+}
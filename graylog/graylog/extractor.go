@@ -44,7 +44,8 @@ type (
 	}
 
 	ExtractorTypeGrokConfig struct {
-		GrokPattern string `json:"grok_pattern"`
+		GrokPattern       string `json:"grok_pattern"`
+		NamedCapturesOnly bool   `json:"named_captures_only"`
 	}
 
 	ExtractorTypeRegexConfig struct {
@@ -113,23 +114,30 @@ func (extractor *Extractor) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, a); err != nil {
 		return err
 	}
+	cfg, err := decodeExtractorConfig(a.Type, a.ExtractorConfig)
+	if err != nil {
+		return err
+	}
+	extractor.ExtractorConfig = cfg
+	return nil
+}
+
+func decodeExtractorConfig(t string, b json.RawMessage) (interface{}, error) {
 	cfgs := map[string]interface{}{
 		"json":            &ExtractorTypeJSONConfig{},
 		"grok":            &ExtractorTypeGrokConfig{},
 		"regex":           &ExtractorTypeRegexConfig{},
 		"split_and_index": &ExtractorTypeSplitAndIndexConfig{},
 	}
-	if cfg, ok := cfgs[a.Type]; ok {
-		if err := json.Unmarshal(a.ExtractorConfig, cfg); err != nil {
-			return err
+	if cfg, ok := cfgs[t]; ok {
+		if err := json.Unmarshal(b, cfg); err != nil {
+			return nil, err
 		}
-		extractor.ExtractorConfig = cfg
-		return nil
+		return cfg, nil
 	}
 	cfg := map[string]interface{}{}
-	if err := json.Unmarshal(a.ExtractorConfig, &cfg); err != nil {
-		return err
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, err
 	}
-	extractor.ExtractorConfig = cfg
-	return nil
+	return cfg, nil
 }
@@ -0,0 +1,97 @@
+package graylog
+
+import (
+	"encoding/json"
+)
+
+type (
+	// ExtractorsExport represents extractors which are exported by Graylog Web UI.
+	// Graylog Web UI can import this format too.
+	ExtractorsExport struct {
+		Extractors []ExtractorExport `json:"extractors"`
+		Version    string            `json:"version,omitempty"`
+	}
+
+	// ExtractorExport represents an exported extractor.
+	ExtractorExport struct {
+		Title           string               `json:"title"`
+		ExtractorType   string               `json:"extractor_type"`
+		Converters      []ExtractorConverter `json:"converters"`
+		Order           int                  `json:"order"`
+		CursorStrategy  string               `json:"cursor_strategy"`
+		SourceField     string               `json:"source_field"`
+		TargetField     string               `json:"target_field"`
+		ExtractorConfig interface{}          `json:"extractor_config"`
+		ConditionType   string               `json:"condition_type"`
+		ConditionValue  string               `json:"condition_value"`
+	}
+)
+
+// NewExtractorsExport converts extractors to the export format.
+func NewExtractorsExport(extractors []Extractor) *ExtractorsExport {
+	exp := &ExtractorsExport{
+		Extractors: make([]ExtractorExport, len(extractors)),
+	}
+	for i, extractor := range extractors {
+		exp.Extractors[i] = *NewExtractorExport(&extractor)
+	}
+	return exp
+}
+
+// NewExtractorExport converts an extractor to the export format.
+// Server generated fields such as id and metrics are dropped.
+func NewExtractorExport(extractor *Extractor) *ExtractorExport {
+	converters := extractor.Converters
+	if converters == nil {
+		converters = []ExtractorConverter{}
+	}
+	return &ExtractorExport{
+		Title:           extractor.Title,
+		ExtractorType:   extractor.Type,
+		Converters:      converters,
+		Order:           extractor.Order,
+		CursorStrategy:  extractor.CursorStrategy,
+		SourceField:     extractor.SourceField,
+		TargetField:     extractor.TargetField,
+		ExtractorConfig: extractor.ExtractorConfig,
+		ConditionType:   extractor.ConditionType,
+		ConditionValue:  extractor.ConditionValue,
+	}
+}
+
+// Extractor converts an exported extractor to Extractor.
+func (exp *ExtractorExport) Extractor() *Extractor {
+	return &Extractor{
+		Title:           exp.Title,
+		Type:            exp.ExtractorType,
+		Converters:      exp.Converters,
+		Order:           exp.Order,
+		CursorStrategy:  exp.CursorStrategy,
+		SourceField:     exp.SourceField,
+		TargetField:     exp.TargetField,
+		ExtractorConfig: exp.ExtractorConfig,
+		ConditionType:   exp.ConditionType,
+		ConditionValue:  exp.ConditionValue,
+	}
+}
+
+// UnmarshalJSON unmarshals JSON into an exported extractor.
+// extractor_config is decoded to the struct according to extractor_type.
+func (exp *ExtractorExport) UnmarshalJSON(b []byte) error {
+	type alias ExtractorExport
+	a := &struct {
+		*alias
+		ExtractorConfig json.RawMessage `json:"extractor_config"`
+	}{
+		alias: (*alias)(exp),
+	}
+	if err := json.Unmarshal(b, a); err != nil {
+		return err
+	}
+	cfg, err := decodeExtractorConfig(a.ExtractorType, a.ExtractorConfig)
+	if err != nil {
+		return err
+	}
+	exp.ExtractorConfig = cfg
+	return nil
+}
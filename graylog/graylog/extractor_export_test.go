@@ -0,0 +1,46 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestExtractorExport_UnmarshalJSON(t *testing.T) {
+	exp := &graylog.ExtractorsExport{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "extractors": [
+    {
+      "title": "status",
+      "extractor_type": "regex",
+      "converters": [
+        {"type": "numeric", "config": {}}
+      ],
+      "order": 0,
+      "cursor_strategy": "copy",
+      "source_field": "message",
+      "target_field": "status",
+      "extractor_config": {"regex_value": "status=(\\d+)"},
+      "condition_type": "none",
+      "condition_value": ""
+    }
+  ],
+  "version": "3.3.0"
+}`), exp))
+	require.Equal(t, "3.3.0", exp.Version)
+	require.Len(t, exp.Extractors, 1)
+	extractor := exp.Extractors[0].Extractor()
+	require.Equal(t, "regex", extractor.Type)
+	require.Equal(t, &graylog.ExtractorTypeRegexConfig{
+		RegexValue: `status=(\d+)`,
+	}, extractor.ExtractorConfig)
+
+	b, err := json.Marshal(graylog.NewExtractorsExport([]graylog.Extractor{*extractor}))
+	require.Nil(t, err)
+	exp2 := &graylog.ExtractorsExport{}
+	require.Nil(t, json.Unmarshal(b, exp2))
+	require.Equal(t, exp.Extractors, exp2.Extractors)
+}
@@ -0,0 +1,56 @@
+package graylog
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	grnPrefix = "grn::::"
+
+	// GRNTypeStream is the GRN type of streams.
+	GRNTypeStream = "stream"
+	// GRNTypeDashboard is the GRN type of dashboards.
+	GRNTypeDashboard = "dashboard"
+	// GRNTypeSearch is the GRN type of saved searches.
+	GRNTypeSearch = "search"
+	// GRNTypeEventDefinition is the GRN type of event definitions.
+	GRNTypeEventDefinition = "event_definition"
+	// GRNTypeEventNotification is the GRN type of event notifications.
+	GRNTypeEventNotification = "notification"
+	// GRNTypeUser is the GRN type of users.
+	GRNTypeUser = "user"
+	// GRNTypeTeam is the GRN type of teams, which are provided by Graylog Enterprise.
+	GRNTypeTeam = "team"
+	// GRNTypeBuiltinTeam is the GRN type of the built-in team "everyone".
+	GRNTypeBuiltinTeam = "builtin-team"
+)
+
+// GRN represents a Graylog Resource Name of Graylog 4 such as "grn::::stream:5e9a8f9e2ab79c0012c3d4e5",
+// which identifies an entity to share and a grantee.
+type GRN struct {
+	Type string
+	ID   string
+}
+
+// NewGRN returns a GRN.
+func NewGRN(grnType, id string) GRN {
+	return GRN{Type: grnType, ID: id}
+}
+
+// ParseGRN parses a string such as "grn::::stream:5e9a8f9e2ab79c0012c3d4e5".
+func ParseGRN(s string) (GRN, error) {
+	if !strings.HasPrefix(s, grnPrefix) {
+		return GRN{}, errors.New(`GRN must start with "` + grnPrefix + `": ` + s)
+	}
+	a := strings.SplitN(strings.TrimPrefix(s, grnPrefix), ":", 2)
+	if len(a) != 2 || a[0] == "" || a[1] == "" {
+		return GRN{}, errors.New("GRN must be the format grn::::<type>:<id>: " + s)
+	}
+	return GRN{Type: a[0], ID: a[1]}, nil
+}
+
+// String returns the GRN string such as "grn::::stream:5e9a8f9e2ab79c0012c3d4e5".
+func (grn GRN) String() string {
+	return grnPrefix + grn.Type + ":" + grn.ID
+}
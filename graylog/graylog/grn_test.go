@@ -0,0 +1,25 @@
+package graylog_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestParseGRN(t *testing.T) {
+	grn, err := graylog.ParseGRN("grn::::stream:5e9a8f9e2ab79c0012c3d4e5")
+	require.Nil(t, err)
+	require.Equal(t, graylog.NewGRN(graylog.GRNTypeStream, "5e9a8f9e2ab79c0012c3d4e5"), grn)
+	require.Equal(t, "grn::::stream:5e9a8f9e2ab79c0012c3d4e5", grn.String())
+
+	grn, err = graylog.ParseGRN("grn::::builtin-team:everyone")
+	require.Nil(t, err)
+	require.Equal(t, graylog.GRNTypeBuiltinTeam, grn.Type)
+
+	for _, s := range []string{"stream:5e9a8f9e2ab79c0012c3d4e5", "grn::::stream", "grn::::stream:", "grn::::"} {
+		_, err := graylog.ParseGRN(s)
+		require.NotNil(t, err, s)
+	}
+}
@@ -14,4 +14,11 @@ type (
 	GrokPatternsBody struct {
 		Patterns []GrokPattern `json:"patterns"`
 	}
+
+	// GrokPatternTestBody represents Test Grok Pattern API's request body.
+	// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+	GrokPatternTestBody struct {
+		GrokPattern GrokPattern `json:"grok_pattern"`
+		SampleData  string      `json:"sample_data"`
+	}
 )
@@ -0,0 +1,44 @@
+package graylog
+
+import (
+	"reflect"
+	"strings"
+)
+
+// InputAttrsField is a field of input attributes.
+// Name is the JSON field name and Required is true if the field is required to create an input.
+type InputAttrsField struct {
+	Name     string
+	Kind     reflect.Kind
+	Required bool
+}
+
+// GetInputAttrsFields returns the fields of the input attributes of the given type.
+// If the type isn't registered by SetInputAttrs, ok is false.
+func GetInputAttrsFields(inputType string) (fields map[string]InputAttrsField, ok bool) {
+	attrs := NewInputAttrsByType(inputType)
+	if _, ok := attrs.(*InputUnknownAttrs); ok {
+		return nil, false
+	}
+	v := reflect.ValueOf(attrs)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	t := v.Elem().Type()
+	fields = make(map[string]InputAttrsField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		required := false
+		for _, tag := range strings.Split(f.Tag.Get("v-create"), ",") {
+			if tag == "required" {
+				required = true
+			}
+		}
+		fields[name] = InputAttrsField{Name: name, Kind: f.Type.Kind(), Required: required}
+	}
+	return fields, true
+}
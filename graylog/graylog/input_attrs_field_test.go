@@ -0,0 +1,22 @@
+package graylog_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestGetInputAttrsFields(t *testing.T) {
+	fields, ok := graylog.GetInputAttrsFields(graylog.InputTypeGELFUDP)
+	require.True(t, ok)
+	require.Equal(t, graylog.InputAttrsField{Name: "port", Kind: reflect.Int, Required: true}, fields["port"])
+	require.Equal(t, graylog.InputAttrsField{Name: "override_source", Kind: reflect.String}, fields["override_source"])
+	_, ok = fields["tls_enable"]
+	require.False(t, ok)
+
+	_, ok = graylog.GetInputAttrsFields("org.example.UnknownInput")
+	require.False(t, ok)
+}
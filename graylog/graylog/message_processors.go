@@ -0,0 +1,26 @@
+package graylog
+
+type (
+	// MessageProcessors represents the order of message processors and the disabled processors,
+	// which are returned by the message processors config API.
+	// ProcessorOrder includes all processors which are available on the server.
+	MessageProcessors struct {
+		ProcessorOrder     []MessageProcessor `json:"processor_order"`
+		DisabledProcessors []string           `json:"disabled_processors"`
+	}
+
+	// MessageProcessor represents a message processor such as the pipeline processor.
+	MessageProcessor struct {
+		Name      string `json:"name"`
+		ClassName string `json:"class_name"`
+	}
+)
+
+// ClassNames returns the class names of the processors in order.
+func (processors *MessageProcessors) ClassNames() []string {
+	names := make([]string, len(processors.ProcessorOrder))
+	for i, processor := range processors.ProcessorOrder {
+		names[i] = processor.ClassName
+	}
+	return names
+}
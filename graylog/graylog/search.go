@@ -0,0 +1,62 @@
+package graylog
+
+import (
+	"time"
+)
+
+const searchTimeFormat = "2006-01-02T15:04:05.000Z"
+
+type (
+	// SearchQuery represents the parameters of the universal search.
+	// If From and To are zero, messages of the last Range seconds are searched.
+	SearchQuery struct {
+		Query  string
+		Range  int
+		From   time.Time
+		To     time.Time
+		Limit  int
+		Offset int
+		Filter string
+		Fields []string
+		// Sort is a field and an order such as "timestamp:desc".
+		Sort string
+	}
+
+	// SearchResult represents the response body of the universal search API.
+	SearchResult struct {
+		Query        string                `json:"query"`
+		BuiltQuery   string                `json:"built_query"`
+		UsedIndices  []SearchResultIndex   `json:"used_indices"`
+		Messages     []SearchResultMessage `json:"messages"`
+		Fields       []string              `json:"fields"`
+		Time         int                   `json:"time"`
+		TotalResults int                   `json:"total_results"`
+		From         string                `json:"from"`
+		To           string                `json:"to"`
+	}
+
+	// SearchResultIndex represents an index which the search used.
+	SearchResultIndex struct {
+		IndexName    string `json:"index_name"`
+		Begin        string `json:"begin"`
+		End          string `json:"end"`
+		CalculatedAt string `json:"calculated_at"`
+		TookMS       int    `json:"took_ms"`
+	}
+
+	// SearchResultMessage represents a message of the search result.
+	SearchResultMessage struct {
+		Message map[string]interface{} `json:"message"`
+		Index   string                 `json:"index"`
+	}
+)
+
+// IsAbsolute returns true if the query has an absolute time range.
+func (q *SearchQuery) IsAbsolute() bool {
+	return !q.From.IsZero() || !q.To.IsZero()
+}
+
+// FormatSearchTime formats a time in the format of the universal search API.
+func FormatSearchTime(t time.Time) string {
+	return t.UTC().Format(searchTimeFormat)
+}
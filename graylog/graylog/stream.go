@@ -9,6 +9,13 @@ import (
 // TestMatchStream
 // POST /streams/{streamID}/testMatch Test matching of a stream against a supplied message
 
+const (
+	// AlertReceiverTypeEmails is the type of alert receivers which are email addresses.
+	AlertReceiverTypeEmails = "emails"
+	// AlertReceiverTypeUsers is the type of alert receivers which are user names.
+	AlertReceiverTypeUsers = "users"
+)
+
 type (
 	// Stream represents a steram.
 	Stream struct {
@@ -1,8 +1,10 @@
 package graylog
 
 type (
-	// UserToken is an access token for a user
+	// UserToken is an access token for a user.
+	// ID is returned since Graylog 3.1, and Token isn't returned by the list API since Graylog 4.0.
 	UserToken struct {
+		ID         string `json:"id,omitempty"`
 		Name       string `json:"name"`
 		Token      string `json:"token"`
 		LastAccess string `json:"last_access,omitempty"`
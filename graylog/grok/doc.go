@@ -0,0 +1,6 @@
+/*
+Package grok provides a local grok engine which compiles grok expressions with Graylog's grok patterns.
+
+It is useful to test grok patterns, grok extractors and grok based pipeline rules without a Graylog server.
+*/
+package grok
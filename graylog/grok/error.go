@@ -0,0 +1,52 @@
+package grok
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// UndefinedPatternError is returned when a grok expression refers to an undefined pattern.
+	UndefinedPatternError struct {
+		Name string
+		// Path is the chain of the pattern names which leads to the reference.
+		Path []string
+	}
+
+	// RecursionError is returned when grok patterns refer to each other recursively.
+	RecursionError struct {
+		// Path is the chain of the pattern names. The last name is equal to one of the preceding names.
+		Path []string
+	}
+
+	// UnsupportedSyntaxError is returned when a grok pattern uses the syntax which Go's regular expression (RE2) doesn't support.
+	// Callers can skip such patterns, which are valid in Graylog.
+	UnsupportedSyntaxError struct {
+		// Syntax is the unsupported syntax such as "(?<=".
+		Syntax string
+		// Path is the chain of the pattern names which leads to the syntax.
+		// Path is empty if the syntax is in the grok expression itself.
+		Path []string
+	}
+)
+
+func (e *UndefinedPatternError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("grok pattern %s is undefined", e.Name)
+	}
+	return fmt.Sprintf(
+		"grok pattern %s is undefined: %s", e.Name, strings.Join(e.Path, " -> "))
+}
+
+func (e *RecursionError) Error() string {
+	return "grok patterns are recursive: " + strings.Join(e.Path, " -> ")
+}
+
+func (e *UnsupportedSyntaxError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("the grok expression uses %s which isn't supported by RE2", e.Syntax)
+	}
+	return fmt.Sprintf(
+		"grok pattern %s uses %s which isn't supported by RE2: %s",
+		e.Path[len(e.Path)-1], e.Syntax, strings.Join(e.Path, " -> "))
+}
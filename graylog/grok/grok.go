@@ -0,0 +1,339 @@
+package grok
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+type (
+	// Grok compiles grok expressions with a set of named grok patterns.
+	//
+	// Grok expressions are expanded into Go's regular expressions (RE2), while Graylog uses Java's regular expressions.
+	// Lookahead, lookbehind, atomic groups and possessive quantifiers aren't supported,
+	// so patterns which use them fail with UnsupportedSyntaxError.
+	Grok struct {
+		patterns map[string]string
+	}
+
+	// Pattern is a compiled grok expression.
+	Pattern struct {
+		expr     string
+		re       *regexp.Regexp
+		captures []capture
+	}
+
+	capture struct {
+		group string
+		field string
+		typ   string
+		// index of the submatch
+		index int
+	}
+
+	compiler struct {
+		grok              *Grok
+		namedCapturesOnly bool
+		captures          []capture
+	}
+)
+
+var (
+	// %{NAME}, %{NAME:field} and %{NAME:field:type}
+	referenceRegexp = regexp.MustCompile(`%\{(\w+)(?::([^:}]+))?(?::(\w+))?\}`)
+	// (?<name>...) and (?P<name>...)
+	namedGroupRegexp  = regexp.MustCompile(`\(\?P?<([A-Za-z][A-Za-z0-9_]*)>`)
+	patternNameRegexp = regexp.MustCompile(`^\w+$`)
+	// Java's group constructs which RE2 doesn't support
+	unsupportedGroups = []string{"(?<=", "(?<!", "(?=", "(?!", "(?>"}
+
+	types = map[string]struct{}{
+		"":        {},
+		"string":  {},
+		"int":     {},
+		"integer": {},
+		"long":    {},
+		"float":   {},
+		"double":  {},
+		"boolean": {},
+	}
+)
+
+// New returns a new Grok.
+// patterns are typically the grok patterns which are returned by client.GetGrokPatterns.
+func New(patterns []graylog.GrokPattern) (*Grok, error) {
+	g := &Grok{patterns: make(map[string]string, len(patterns))}
+	for _, p := range patterns {
+		if err := g.AddPattern(p.Name, p.Pattern); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// AddPattern adds a named grok pattern.
+func (g *Grok) AddPattern(name, pattern string) error {
+	if !patternNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid grok pattern name: %q", name)
+	}
+	if _, ok := g.patterns[name]; ok {
+		return fmt.Errorf("grok pattern %s is duplicated", name)
+	}
+	g.patterns[name] = pattern
+	return nil
+}
+
+// Names returns the sorted names of the grok patterns.
+func (g *Grok) Names() []string {
+	names := make([]string, 0, len(g.patterns))
+	for name := range g.patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate compiles all grok patterns and returns the first error.
+// Undefined and recursive references and invalid regular expressions are detected.
+func (g *Grok) Validate() error {
+	for _, name := range g.Names() {
+		if _, err := g.Compile("%{"+name+"}", false); err != nil {
+			return fmt.Errorf("grok pattern %s is invalid: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Compile expands the references of a grok expression and compiles it.
+// If namedCapturesOnly is true, only references with a field name such as %{IP:client} are captured.
+// Otherwise references without a field name are captured with the pattern name.
+func (g *Grok) Compile(expr string, namedCapturesOnly bool) (*Pattern, error) {
+	c := &compiler{grok: g, namedCapturesOnly: namedCapturesOnly}
+	s, err := c.expand(expr, nil)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile the expanded grok expression: %w", err)
+	}
+	indexes := make(map[string]int, len(c.captures))
+	for i, name := range re.SubexpNames() {
+		indexes[name] = i
+	}
+	for i, cp := range c.captures {
+		c.captures[i].index = indexes[cp.group]
+	}
+	return &Pattern{expr: expr, re: re, captures: c.captures}, nil
+}
+
+// CompileExtractor compiles the grok pattern of a grok extractor.
+func (g *Grok) CompileExtractor(extractor *graylog.Extractor) (*Pattern, error) {
+	if extractor == nil {
+		return nil, errors.New("extractor is nil")
+	}
+	if extractor.Type != "grok" {
+		return nil, fmt.Errorf("extractor type should be grok: %s", extractor.Type)
+	}
+	switch cfg := extractor.ExtractorConfig.(type) {
+	case *graylog.ExtractorTypeGrokConfig:
+		return g.Compile(cfg.GrokPattern, cfg.NamedCapturesOnly)
+	case map[string]interface{}:
+		expr, ok := cfg["grok_pattern"].(string)
+		if !ok {
+			return nil, errors.New("extractor_config.grok_pattern is required")
+		}
+		namedCapturesOnly, _ := cfg["named_captures_only"].(bool)
+		return g.Compile(expr, namedCapturesOnly)
+	default:
+		return nil, fmt.Errorf("invalid grok extractor config: %T", cfg)
+	}
+}
+
+func (c *compiler) expand(expr string, path []string) (string, error) {
+	var buf strings.Builder
+	last := 0
+	for _, m := range referenceRegexp.FindAllStringSubmatchIndex(expr, -1) {
+		if err := c.writeRegexp(&buf, expr[last:m[0]], path); err != nil {
+			return "", err
+		}
+		last = m[1]
+		name := expr[m[2]:m[3]]
+		field, typ := "", ""
+		if m[4] >= 0 {
+			field = expr[m[4]:m[5]]
+		}
+		if m[6] >= 0 {
+			typ = expr[m[6]:m[7]]
+		}
+		if _, ok := types[typ]; !ok {
+			return "", fmt.Errorf("unsupported type of %s: %s", expr[m[0]:m[1]], typ)
+		}
+		for _, p := range path {
+			if p == name {
+				return "", &RecursionError{Path: append(copyPath(path), name)}
+			}
+		}
+		pattern, ok := c.grok.patterns[name]
+		if !ok {
+			return "", &UndefinedPatternError{Name: name, Path: copyPath(path)}
+		}
+		s, err := c.expand(pattern, append(copyPath(path), name))
+		if err != nil {
+			return "", err
+		}
+		if field == "" {
+			if c.namedCapturesOnly {
+				buf.WriteString("(?:" + s + ")")
+				continue
+			}
+			field = name
+		}
+		buf.WriteString("(?P<" + c.addCapture(field, typ) + ">" + s + ")")
+	}
+	if err := c.writeRegexp(&buf, expr[last:], path); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// writeRegexp writes a raw regular expression.
+// Named groups are renamed because field names aren't always valid group names.
+func (c *compiler) writeRegexp(buf *strings.Builder, s string, path []string) error {
+	if syntax := findUnsupportedSyntax(s); syntax != "" {
+		return &UnsupportedSyntaxError{Syntax: syntax, Path: copyPath(path)}
+	}
+	last := 0
+	for _, m := range namedGroupRegexp.FindAllStringSubmatchIndex(s, -1) {
+		if isEscaped(s, m[0]) {
+			continue
+		}
+		buf.WriteString(s[last:m[0]])
+		buf.WriteString("(?P<" + c.addCapture(s[m[2]:m[3]], "") + ">")
+		last = m[1]
+	}
+	buf.WriteString(s[last:])
+	return nil
+}
+
+// findUnsupportedSyntax returns the first syntax in a regular expression which RE2 doesn't support.
+// Escaped characters and character classes are skipped.
+func findUnsupportedSyntax(s string) string {
+	inClass := false
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '\\':
+			i++
+		case inClass:
+			if ch == ']' {
+				inClass = false
+			}
+		case ch == '[':
+			inClass = true
+			// "]" just after "[" or "[^" is a literal
+			if strings.HasPrefix(s[i+1:], "^") {
+				i++
+			}
+			if strings.HasPrefix(s[i+1:], "]") {
+				i++
+			}
+		case ch == '(':
+			for _, g := range unsupportedGroups {
+				if strings.HasPrefix(s[i:], g) {
+					return g
+				}
+			}
+		case strings.IndexByte("*+?}", ch) >= 0 && strings.HasPrefix(s[i+1:], "+"):
+			// possessive quantifier such as "a++"
+			return s[i : i+2]
+		}
+	}
+	return ""
+}
+
+func (c *compiler) addCapture(field, typ string) string {
+	group := "g" + strconv.Itoa(len(c.captures))
+	c.captures = append(c.captures, capture{group: group, field: field, typ: typ})
+	return group
+}
+
+// String returns the original grok expression.
+func (p *Pattern) String() string {
+	return p.expr
+}
+
+// Regexp returns the expanded regular expression.
+func (p *Pattern) Regexp() string {
+	return p.re.String()
+}
+
+// Fields returns the names of the captured fields.
+func (p *Pattern) Fields() []string {
+	fields := []string{}
+	found := map[string]struct{}{}
+	for _, c := range p.captures {
+		if _, ok := found[c.field]; ok {
+			continue
+		}
+		found[c.field] = struct{}{}
+		fields = append(fields, c.field)
+	}
+	return fields
+}
+
+// Match extracts fields from a given text.
+// If the text doesn't match the pattern, matched is false.
+// If the same field is captured multiple times, the first matched value is used.
+// An error is returned when a value can't be converted to the specified type.
+func (p *Pattern) Match(s string) (fields map[string]interface{}, matched bool, err error) {
+	idx := p.re.FindStringSubmatchIndex(s)
+	if idx == nil {
+		return nil, false, nil
+	}
+	fields = map[string]interface{}{}
+	for _, c := range p.captures {
+		start, end := idx[c.index*2], idx[c.index*2+1]
+		if start < 0 {
+			continue
+		}
+		if _, ok := fields[c.field]; ok {
+			continue
+		}
+		v, err := convert(s[start:end], c.typ)
+		if err != nil {
+			return nil, true, fmt.Errorf("failed to convert the field %s: %w", c.field, err)
+		}
+		fields[c.field] = v
+	}
+	return fields, true, nil
+}
+
+func convert(v, typ string) (interface{}, error) {
+	switch typ {
+	case "int", "integer", "long":
+		return strconv.ParseInt(v, 10, 64)
+	case "float", "double":
+		return strconv.ParseFloat(v, 64)
+	case "boolean":
+		return strconv.ParseBool(v)
+	default:
+		return v, nil
+	}
+}
+
+func isEscaped(s string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+func copyPath(path []string) []string {
+	return append(make([]string, 0, len(path)+1), path...)
+}
@@ -0,0 +1,217 @@
+package grok_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/grok"
+)
+
+func newGrok(t *testing.T) *grok.Grok {
+	g, err := grok.New([]graylog.GrokPattern{
+		{Name: "INT", Pattern: `(?:[+-]?(?:[0-9]+))`},
+		{Name: "NUMBER", Pattern: `(?:%{INT}(?:\.[0-9]+)?)`},
+		{Name: "WORD", Pattern: `\b\w+\b`},
+		{Name: "IPV4", Pattern: `(?:[0-9]{1,3}\.){3}[0-9]{1,3}`},
+		{Name: "METHOD", Pattern: `(?<verb>GET|POST)`},
+	})
+	require.Nil(t, err)
+	return g
+}
+
+func TestNew(t *testing.T) {
+	_, err := grok.New([]graylog.GrokPattern{
+		{Name: "INT", Pattern: `\d+`},
+		{Name: "INT", Pattern: `\d+`},
+	})
+	require.NotNil(t, err, "duplicated pattern")
+	_, err = grok.New([]graylog.GrokPattern{{Name: "foo bar", Pattern: `\d+`}})
+	require.NotNil(t, err, "invalid pattern name")
+}
+
+func TestGrok_Validate(t *testing.T) {
+	require.Nil(t, newGrok(t).Validate())
+
+	g, err := grok.New([]graylog.GrokPattern{
+		{Name: "A", Pattern: `%{B}`},
+		{Name: "B", Pattern: `x%{A}`},
+	})
+	require.Nil(t, err)
+	err = g.Validate()
+	recErr := &grok.RecursionError{}
+	require.True(t, errors.As(err, &recErr), err)
+	require.Equal(t, []string{"A", "B", "A"}, recErr.Path)
+
+	g, err = grok.New([]graylog.GrokPattern{
+		{Name: "A", Pattern: `%{B}`},
+	})
+	require.Nil(t, err)
+	err = g.Validate()
+	undefErr := &grok.UndefinedPatternError{}
+	require.True(t, errors.As(err, &undefErr), err)
+	require.Equal(t, "B", undefErr.Name)
+	require.Equal(t, []string{"A"}, undefErr.Path)
+}
+
+func TestGrok_Compile(t *testing.T) {
+	g := newGrok(t)
+	_, err := g.Compile("%{UNKNOWN}", false)
+	require.NotNil(t, err, "undefined pattern")
+	_, err = g.Compile("%{INT:count:unknown}", false)
+	require.NotNil(t, err, "unsupported type")
+	_, err = g.Compile("%{INT:count}(", false)
+	require.NotNil(t, err, "invalid regular expression")
+}
+
+func TestGrok_Compile_unsupportedSyntax(t *testing.T) {
+	g, err := grok.New([]graylog.GrokPattern{
+		// stock Graylog pattern which uses lookbehind
+		{Name: "BASE16FLOAT", Pattern: `\b(?<![0-9A-Fa-f.])(?:[+-]?(?:0x)?(?:(?:[0-9A-Fa-f]+(?:\.[0-9A-Fa-f]*)?)|(?:\.[0-9A-Fa-f]+)))\b`},
+		{Name: "AMOUNT", Pattern: `%{BASE16FLOAT}`},
+		{Name: "INT", Pattern: `(?:[+-]?(?:[0-9]+))`},
+	})
+	require.Nil(t, err)
+
+	_, err = g.Compile("amount: %{AMOUNT:amount}", false)
+	syntaxErr := &grok.UnsupportedSyntaxError{}
+	require.True(t, errors.As(err, &syntaxErr), err)
+	require.Equal(t, "(?<!", syntaxErr.Syntax)
+	require.Equal(t, []string{"AMOUNT", "BASE16FLOAT"}, syntaxErr.Path)
+	require.True(t, errors.As(g.Validate(), &syntaxErr))
+
+	data := []struct {
+		title  string
+		expr   string
+		syntax string
+	}{
+		{"lookahead", `%{INT}(?=ms)`, "(?="},
+		{"atomic group", `(?>%{INT})`, "(?>"},
+		{"possessive quantifier", `\d++`, "++"},
+		{"possessive repetition", `\d{2}+`, "}+"},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			_, err := g.Compile(d.expr, false)
+			syntaxErr := &grok.UnsupportedSyntaxError{}
+			require.True(t, errors.As(err, &syntaxErr), err)
+			require.Equal(t, d.syntax, syntaxErr.Syntax)
+			require.Empty(t, syntaxErr.Path)
+		})
+	}
+
+	// escaped characters, character classes and repeated references are supported
+	for _, expr := range []string{`\(?=`, `[(?=]`, `[]++]`, `%{INT}+`} {
+		_, err := g.Compile(expr, false)
+		require.False(t, errors.As(err, &syntaxErr), expr)
+	}
+}
+
+func TestPattern_Match(t *testing.T) {
+	g := newGrok(t)
+	data := []struct {
+		title             string
+		expr              string
+		namedCapturesOnly bool
+		line              string
+		matched           bool
+		fields            map[string]interface{}
+		isErr             bool
+	}{
+		{
+			title:   "typed named captures",
+			expr:    `%{IPV4:client} %{METHOD} %{NUMBER:took:float} %{INT:status:int}`,
+			line:    "192.168.0.1 GET 1.5 200",
+			matched: true,
+			fields: map[string]interface{}{
+				"client": "192.168.0.1",
+				"METHOD": "GET",
+				"verb":   "GET",
+				"INT":    "1",
+				"took":   1.5,
+				"status": int64(200),
+			},
+		},
+		{
+			title:             "named captures only",
+			expr:              `%{IPV4:client} %{METHOD} %{NUMBER:took:float}`,
+			namedCapturesOnly: true,
+			line:              "192.168.0.1 POST 3",
+			matched:           true,
+			fields: map[string]interface{}{
+				"client": "192.168.0.1",
+				"verb":   "POST",
+				"took":   float64(3),
+			},
+		},
+		{
+			title: "not matched",
+			expr:  `^%{INT:count}$`,
+			line:  "foo",
+		},
+		{
+			title:   "optional group",
+			expr:    `^%{WORD:name}(?: %{INT:age:int})?$`,
+			line:    "foo",
+			matched: true,
+			fields: map[string]interface{}{
+				"name": "foo",
+			},
+		},
+		{
+			title:   "conversion error",
+			expr:    `%{WORD:flag:boolean}`,
+			line:    "foo",
+			matched: true,
+			isErr:   true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			p, err := g.Compile(d.expr, d.namedCapturesOnly)
+			require.Nil(t, err)
+			fields, matched, err := p.Match(d.line)
+			require.Equal(t, d.matched, matched)
+			if d.isErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, d.fields, fields)
+		})
+	}
+}
+
+func TestGrok_CompileExtractor(t *testing.T) {
+	g := newGrok(t)
+	p, err := g.CompileExtractor(&graylog.Extractor{
+		Type: "grok",
+		ExtractorConfig: &graylog.ExtractorTypeGrokConfig{
+			GrokPattern: "%{INT:count:int}",
+		},
+	})
+	require.Nil(t, err)
+	fields, matched, err := p.Match("count: 10")
+	require.Nil(t, err)
+	require.True(t, matched)
+	require.Equal(t, map[string]interface{}{"count": int64(10)}, fields)
+
+	// the extractor which is returned by the API
+	extractor := &graylog.Extractor{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "type": "grok",
+  "extractor_config": {"grok_pattern": "%{WORD} %{INT:count:int}", "named_captures_only": true}
+}`), extractor))
+	p, err = g.CompileExtractor(extractor)
+	require.Nil(t, err)
+	fields, matched, err = p.Match("count 10")
+	require.Nil(t, err)
+	require.True(t, matched)
+	require.Equal(t, map[string]interface{}{"count": int64(10)}, fields)
+
+	_, err = g.CompileExtractor(&graylog.Extractor{Type: "regex"})
+	require.NotNil(t, err, "extractor type should be grok")
+}
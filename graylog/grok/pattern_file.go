@@ -0,0 +1,54 @@
+package grok
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+var patternLineRegexp = regexp.MustCompile(`^(\w+)\s+(.*\S)\s*$`)
+
+// ParsePatterns parses a grok pattern file.
+// Each line of the file is "NAME PATTERN".
+// Empty lines and lines which start with "#" are ignored.
+// An error is returned if a line is invalid or a pattern name is duplicated.
+func ParsePatterns(r io.Reader) ([]graylog.GrokPattern, error) {
+	patterns := []graylog.GrokPattern{}
+	lines := map[string]int{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m := patternLineRegexp.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf(`line %d: the format should be "NAME PATTERN": %s`, n, line)
+		}
+		if l, ok := lines[m[1]]; ok {
+			return nil, fmt.Errorf("line %d: grok pattern %s is already defined at line %d", n, m[1], l)
+		}
+		lines[m[1]] = n
+		patterns = append(patterns, graylog.GrokPattern{Name: m[1], Pattern: m[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read grok patterns: %w", err)
+	}
+	return patterns, nil
+}
+
+// FormatPatterns formats grok patterns in the grok pattern file format.
+func FormatPatterns(patterns []graylog.GrokPattern) string {
+	var buf strings.Builder
+	for _, p := range patterns {
+		buf.WriteString(p.Name + " " + p.Pattern + "\n")
+	}
+	return buf.String()
+}
@@ -0,0 +1,38 @@
+package grok_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/grok"
+)
+
+func TestParsePatterns(t *testing.T) {
+	patterns, err := grok.ParsePatterns(strings.NewReader(`# comment
+INT (?:[+-]?(?:[0-9]+))
+
+  NUMBER    (?:%{INT}(?:\.[0-9]+)?)  
+`))
+	require.Nil(t, err)
+	require.Equal(t, []graylog.GrokPattern{
+		{Name: "INT", Pattern: `(?:[+-]?(?:[0-9]+))`},
+		{Name: "NUMBER", Pattern: `(?:%{INT}(?:\.[0-9]+)?)`},
+	}, patterns)
+
+	_, err = grok.ParsePatterns(strings.NewReader("INT"))
+	require.NotNil(t, err, "pattern is required")
+	_, err = grok.ParsePatterns(strings.NewReader("FOO-BAR .*"))
+	require.NotNil(t, err, "invalid name")
+	_, err = grok.ParsePatterns(strings.NewReader("INT \\d+\nINT [0-9]+"))
+	require.NotNil(t, err, "duplicated name")
+}
+
+func TestFormatPatterns(t *testing.T) {
+	require.Equal(t, "INT \\d+\nWORD \\w+\n", grok.FormatPatterns([]graylog.GrokPattern{
+		{Name: "INT", Pattern: `\d+`},
+		{Name: "WORD", Pattern: `\w+`},
+	}))
+}
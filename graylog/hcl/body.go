@@ -0,0 +1,125 @@
+package hcl
+
+import (
+	"io"
+	"strings"
+)
+
+type (
+	// File is a configuration file which consists of top level blocks.
+	File struct {
+		Blocks []*Block
+	}
+
+	// Block is a block such as `resource "graylog_stream" "foo" { ... }`.
+	Block struct {
+		Type   string
+		Labels []string
+		Body   Body
+	}
+
+	// Body is the body of a block, which has attributes and nested blocks in order.
+	Body struct {
+		items []bodyItem
+	}
+
+	bodyItem struct {
+		name  string
+		value Expr
+		block *Block
+	}
+)
+
+// NewBlock returns a new block.
+func NewBlock(typ string, labels ...string) *Block {
+	return &Block{Type: typ, Labels: labels}
+}
+
+// Attr appends an attribute.
+func (body *Body) Attr(name string, value Expr) {
+	body.items = append(body.items, bodyItem{name: name, value: value})
+}
+
+// Block appends a nested block and returns the nested block's body.
+func (body *Body) Block(typ string, labels ...string) *Body {
+	b := NewBlock(typ, labels...)
+	body.items = append(body.items, bodyItem{block: b})
+	return &b.Body
+}
+
+// Append appends a nested block.
+func (body *Body) Append(block *Block) {
+	body.items = append(body.items, bodyItem{block: block})
+}
+
+// String returns the block in HCL.
+func (block *Block) String() string {
+	buf := &strings.Builder{}
+	block.write(buf, 0)
+	return buf.String()
+}
+
+// WriteTo writes the file in HCL.
+func (file *File) WriteTo(w io.Writer) (int64, error) {
+	buf := &strings.Builder{}
+	for i, block := range file.Blocks {
+		if i != 0 {
+			buf.WriteString("\n")
+		}
+		block.write(buf, 0)
+	}
+	n, err := io.WriteString(w, buf.String())
+	return int64(n), err
+}
+
+func (block *Block) write(buf *strings.Builder, indent int) {
+	buf.WriteString(strings.Repeat("  ", indent) + block.Type)
+	for _, label := range block.Labels {
+		buf.WriteString(" " + quote(label))
+	}
+	if len(block.Body.items) == 0 {
+		buf.WriteString(" {}\n")
+		return
+	}
+	buf.WriteString(" {\n")
+	block.Body.write(buf, indent+1)
+	buf.WriteString(strings.Repeat("  ", indent) + "}\n")
+}
+
+// write writes attributes and nested blocks.
+// Like "terraform fmt", the equal signs of consecutive single line attributes are aligned
+// and blocks are separated by blank lines.
+func (body *Body) write(buf *strings.Builder, indent int) {
+	pad := strings.Repeat("  ", indent)
+	for i := 0; i < len(body.items); {
+		item := body.items[i]
+		if i != 0 && (item.block != nil || body.items[i-1].block != nil) {
+			buf.WriteString("\n")
+		}
+		if item.block != nil {
+			item.block.write(buf, indent)
+			i++
+			continue
+		}
+		// a group of consecutive attributes ends with a multi line attribute
+		values := []string{}
+		width := 0
+		j := i
+		for ; j < len(body.items) && body.items[j].block == nil; j++ {
+			v := body.items[j].value.render(indent)
+			values = append(values, v)
+			if len(body.items[j].name) > width {
+				width = len(body.items[j].name)
+			}
+			if strings.Contains(v, "\n") {
+				j++
+				break
+			}
+		}
+		for k, v := range values {
+			name := body.items[i+k].name
+			buf.WriteString(pad + name + strings.Repeat(" ", width-len(name)) + " = " + v + "\n")
+		}
+		i = j
+	}
+}
@@ -0,0 +1,7 @@
+/*
+Package hcl writes Terraform configuration in HCL.
+
+It is a small writer which generates the configuration of the provider's resources.
+The output is formatted like "terraform fmt", so it can be committed as is.
+*/
+package hcl
@@ -0,0 +1,241 @@
+package hcl
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Expr is an expression which is assigned to an attribute.
+type Expr interface {
+	// render returns the expression. indent is the indentation of the line where the expression starts.
+	render(indent int) string
+}
+
+type rawExpr string
+
+func (e rawExpr) render(indent int) string {
+	return string(e)
+}
+
+// Raw returns an expression which is written as is, for example a reference "graylog_stream.foo.id".
+func Raw(s string) Expr {
+	return rawExpr(s)
+}
+
+// String returns a quoted string.
+// Template sequences "${" and "%{" are escaped, so the value is kept as is.
+func String(s string) Expr {
+	return rawExpr(quote(s))
+}
+
+type heredocExpr string
+
+func (e heredocExpr) render(indent int) string {
+	s := string(e)
+	marker := "EOT"
+	for i := 2; strings.Contains("\n"+s, "\n"+marker+"\n"); i++ {
+		marker = "EOT" + strconv.Itoa(i)
+	}
+	return "<<" + marker + "\n" + escapeTemplate(s) + marker
+}
+
+// Heredoc returns a heredoc string if the string has multiple lines and ends with a newline,
+// otherwise a quoted string.
+func Heredoc(s string) Expr {
+	if !strings.HasSuffix(s, "\n") || strings.Count(s, "\n") < 2 {
+		return String(s)
+	}
+	return heredocExpr(s)
+}
+
+// Int returns a number.
+func Int(i int) Expr {
+	return rawExpr(strconv.Itoa(i))
+}
+
+// Float returns a number.
+func Float(f float64) Expr {
+	return rawExpr(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// Bool returns a bool.
+func Bool(b bool) Expr {
+	return rawExpr(strconv.FormatBool(b))
+}
+
+// Null returns null.
+func Null() Expr {
+	return rawExpr("null")
+}
+
+type listExpr []Expr
+
+func (e listExpr) render(indent int) string {
+	if len(e) == 0 {
+		return "[]"
+	}
+	items := make([]string, len(e))
+	multiline := false
+	for i, item := range e {
+		items[i] = item.render(indent + 1)
+		if _, ok := item.(rawExpr); !ok {
+			multiline = true
+		}
+	}
+	if !multiline {
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	pad := strings.Repeat("  ", indent+1)
+	return "[\n" + pad + strings.Join(items, ",\n"+pad) + ",\n" + strings.Repeat("  ", indent) + "]"
+}
+
+// List returns a tuple.
+func List(items ...Expr) Expr {
+	return listExpr(items)
+}
+
+// StringList returns a tuple of strings.
+func StringList(a []string) Expr {
+	items := make([]Expr, len(a))
+	for i, s := range a {
+		items[i] = String(s)
+	}
+	return listExpr(items)
+}
+
+type objectAttr struct {
+	key   string
+	value Expr
+}
+
+type objectExpr []objectAttr
+
+func (e objectExpr) render(indent int) string {
+	if len(e) == 0 {
+		return "{}"
+	}
+	body := &Body{}
+	for _, a := range e {
+		body.Attr(objectKey(a.key), a.value)
+	}
+	buf := &strings.Builder{}
+	buf.WriteString("{\n")
+	body.write(buf, indent+1)
+	buf.WriteString(strings.Repeat("  ", indent) + "}")
+	return buf.String()
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func objectKey(key string) string {
+	if identifierPattern.MatchString(key) {
+		return key
+	}
+	return quote(key)
+}
+
+// Call returns a function call such as jsonencode({...}).
+func Call(name string, args ...Expr) Expr {
+	return callExpr{name: name, args: args}
+}
+
+type callExpr struct {
+	name string
+	args []Expr
+}
+
+func (e callExpr) render(indent int) string {
+	args := make([]string, len(e.args))
+	for i, arg := range e.args {
+		args[i] = arg.render(indent)
+	}
+	return e.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Value converts a value decoded from JSON to an expression.
+// The keys of objects are sorted. Expressions in the value are kept as is.
+func Value(v interface{}) (Expr, error) {
+	switch a := v.(type) {
+	case Expr:
+		return a, nil
+	case nil:
+		return Null(), nil
+	case string:
+		return String(a), nil
+	case bool:
+		return Bool(a), nil
+	case int:
+		return Int(a), nil
+	case float64:
+		return Float(a), nil
+	case []string:
+		return StringList(a), nil
+	case []interface{}:
+		items := make([]Expr, len(a))
+		for i, b := range a {
+			item, err := Value(b)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return listExpr(items), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(a))
+		for k := range a {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		attrs := make(objectExpr, len(keys))
+		for i, k := range keys {
+			value, err := Value(a[k])
+			if err != nil {
+				return nil, err
+			}
+			attrs[i] = objectAttr{key: k, value: value}
+		}
+		return attrs, nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
+
+func quote(s string) string {
+	buf := &strings.Builder{}
+	buf.WriteByte('"')
+	for i, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '$', '%':
+			// escape template sequences "${" and "%{" by doubling the leading character
+			if i+1 < len(s) && s[i+1] == '{' {
+				buf.WriteRune(r)
+			}
+			buf.WriteRune(r)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(buf, `\u%04x`, r)
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// escapeTemplate escapes template sequences "${" and "%{" by doubling the leading character.
+func escapeTemplate(s string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+}
@@ -0,0 +1,100 @@
+package hcl_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/hcl"
+)
+
+func TestFile_WriteTo(t *testing.T) {
+	stream := hcl.NewBlock("resource", "graylog_stream", "foo")
+	stream.Body.Attr("title", hcl.String("foo"))
+	stream.Body.Attr("index_set_id", hcl.Raw("graylog_index_set.default.id"))
+	stream.Body.Attr("disabled", hcl.Bool(false))
+
+	notif := hcl.NewBlock("resource", "graylog_event_notification", "legacy")
+	notif.Body.Attr("title", hcl.String(`say "${hello}" 100%{x}`))
+	cfg, err := hcl.Value(map[string]interface{}{
+		"type":          "legacy-alarm-callback-notification-v1",
+		"callback_type": "org.graylog2.alarmcallbacks.HTTPAlarmCallback",
+		"configuration": map[string]interface{}{
+			"url":      "https://example.com",
+			"receiver": []interface{}{"a", "b"},
+			"a.b":      1.5,
+		},
+	})
+	require.Nil(t, err)
+	notif.Body.Attr("config", hcl.Call("jsonencode", cfg))
+	notif.Body.Attr("description", hcl.String(""))
+	email := notif.Body.Block("email")
+	email.Attr("subject", hcl.String("line1\nline2"))
+	email.Attr("email_recipients", hcl.StringList([]string{"foo@example.com"}))
+
+	buf := &bytes.Buffer{}
+	_, err = (&hcl.File{Blocks: []*hcl.Block{stream, notif}}).WriteTo(buf)
+	require.Nil(t, err)
+	require.Equal(t, `resource "graylog_stream" "foo" {
+  title        = "foo"
+  index_set_id = graylog_index_set.default.id
+  disabled     = false
+}
+
+resource "graylog_event_notification" "legacy" {
+  title  = "say \"$${hello}\" 100%%{x}"
+  config = jsonencode({
+    callback_type = "org.graylog2.alarmcallbacks.HTTPAlarmCallback"
+    configuration = {
+      "a.b"    = 1.5
+      receiver = ["a", "b"]
+      url      = "https://example.com"
+    }
+    type = "legacy-alarm-callback-notification-v1"
+  })
+  description = ""
+
+  email {
+    subject          = "line1\nline2"
+    email_recipients = ["foo@example.com"]
+  }
+}
+`, buf.String())
+}
+
+func TestNames_New(t *testing.T) {
+	names := hcl.NewNames()
+	require.Equal(t, "error_logs", names.New("Error Logs"))
+	require.Equal(t, "error_logs_2", names.New("error  logs!"))
+	require.Equal(t, "_1st", names.New("1st"))
+	require.Equal(t, "_", names.New("!!!"))
+}
+
+func TestHeredoc(t *testing.T) {
+	block := hcl.NewBlock("resource", "graylog_pipeline_rule", "foo")
+	block.Body.Attr("source", hcl.Heredoc("rule \"foo\"\nwhen true\nthen\n  set_field(\"x\", \"${y}\");\nend\n"))
+	block.Body.Attr("marker", hcl.Heredoc("a\nEOT\n"))
+	block.Body.Attr("description", hcl.Heredoc("no trailing newline\nfoo"))
+	block.Body.Attr("list", hcl.List(hcl.Raw("graylog_stream.foo.id")))
+	v, err := hcl.Value([]interface{}{hcl.Raw("graylog_stream.foo.id"), "bar"})
+	require.Nil(t, err)
+	block.Body.Attr("value", v)
+	require.Equal(t, `resource "graylog_pipeline_rule" "foo" {
+  source = <<EOT
+rule "foo"
+when true
+then
+  set_field("x", "$${y}");
+end
+EOT
+  marker = <<EOT2
+a
+EOT
+EOT2
+  description = "no trailing newline\nfoo"
+  list        = [graylog_stream.foo.id]
+  value       = [graylog_stream.foo.id, "bar"]
+}
+`, block.String())
+}
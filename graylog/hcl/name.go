@@ -0,0 +1,58 @@
+package hcl
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Names generates unique resource names.
+// The zero value isn't usable, use NewNames.
+type Names struct {
+	used map[string]struct{}
+}
+
+// NewNames returns a new Names.
+func NewNames() *Names {
+	return &Names{used: map[string]struct{}{}}
+}
+
+// New converts a title such as "Error Logs" to a resource name such as "error_logs".
+// If the name is already used, a suffix such as "_2" is added.
+func (names *Names) New(title string) string {
+	name := normalizeName(title)
+	if _, ok := names.used[name]; !ok {
+		names.used[name] = struct{}{}
+		return name
+	}
+	for i := 2; ; i++ {
+		n := name + "_" + strconv.Itoa(i)
+		if _, ok := names.used[n]; !ok {
+			names.used[n] = struct{}{}
+			return n
+		}
+	}
+}
+
+func normalizeName(title string) string {
+	buf := &strings.Builder{}
+	underscore := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			buf.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && buf.Len() != 0 {
+			buf.WriteByte('_')
+			underscore = true
+		}
+	}
+	name := strings.TrimRight(buf.String(), "_")
+	if name == "" {
+		return "_"
+	}
+	if name[0] >= '0' && name[0] <= '9' || name[0] == '-' {
+		return "_" + name
+	}
+	return name
+}
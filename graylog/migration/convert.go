@@ -0,0 +1,310 @@
+package migration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-set/v6"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+const (
+	defaultExecuteEveryMS = 60 * 1000
+	defaultPriority       = 2
+
+	minuteMS = 60 * 1000
+
+	// DefaultEmailBodyTemplate is the body template of email notifications which are converted
+	// from email alarm callbacks without a custom body.
+	DefaultEmailBodyTemplate = `--- [Event Definition] ---------------------------
+Title:       ${event_definition_title}
+Description: ${event_definition_description}
+Type:        ${event_definition_type}
+--- [Event] --------------------------------------
+Timestamp:            ${event.timestamp}
+Message:              ${event.message}
+Source:               ${event.source}
+Key:                  ${event.key}
+Priority:             ${event.priority}
+Alert:                ${event.alert}
+Timestamp Processing: ${event.timestamp}
+Timerange Start:      ${event.timerange_start}
+Timerange End:        ${event.timerange_end}
+${if backlog}
+--- [Backlog] ------------------------------------
+Last messages accounting for this alert:
+${foreach backlog message}
+${message}
+${end}
+${end}
+`
+)
+
+// Converter converts alert conditions and alarm callbacks.
+// The zero value is ready to use.
+type Converter struct {
+	// ExecuteEveryMS is the interval of the event definitions' execution.
+	// The default is 60000, which is the default interval of Graylog 2's alert checks.
+	ExecuteEveryMS int
+	// Priority is the priority of the event definitions. The default is 2 (Normal).
+	Priority int
+	// LegacyCallbacks makes all alarm callbacks converted to "legacy-alarm-callback-notification-v1".
+	LegacyCallbacks bool
+}
+
+// UnsupportedError is returned when an alert condition or an alarm callback can't be converted.
+type UnsupportedError struct {
+	Kind   string
+	Type   string
+	Reason string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("the %s type '%s' isn't supported: %s", e.Kind, e.Type, e.Reason)
+}
+
+func (c *Converter) executeEveryMS() int {
+	if c.ExecuteEveryMS > 0 {
+		return c.ExecuteEveryMS
+	}
+	return defaultExecuteEveryMS
+}
+
+func (c *Converter) priority() int {
+	if c.Priority > 0 {
+		return c.Priority
+	}
+	return defaultPriority
+}
+
+// ConvertAlertCondition converts an alert condition of a given stream to an event definition.
+// The event definition has no notification, and repeat_notifications isn't converted
+// because event definitions have no equivalent setting.
+func (c *Converter) ConvertAlertCondition(streamID string, cond *graylog.AlertCondition) (*graylog.EventDefinition, error) {
+	var (
+		cfg   *graylog.EventDefinitionConfigAggregation
+		grace int
+		size  int
+		err   error
+	)
+	switch p := cond.Parameters.(type) {
+	case graylog.MessageCountAlertConditionParameters:
+		cfg, err = c.convertMessageCount(streamID, &p)
+		grace, size = p.Grace, p.Backlog
+	case graylog.FieldAggregationAlertConditionParameters:
+		cfg, err = c.convertFieldAggregation(streamID, &p)
+		grace, size = p.Grace, p.Backlog
+	case graylog.FieldContentAlertConditionParameters:
+		cfg = c.convertFieldContent(streamID, &p)
+		grace, size = p.Grace, p.Backlog
+	default:
+		return nil, &UnsupportedError{
+			Kind: "alert condition", Type: cond.Type(), Reason: "only message_count, field_value and field_content_value are supported",
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &graylog.EventDefinition{
+		Title:       cond.Title,
+		Description: "Migrated legacy alert condition",
+		Priority:    c.priority(),
+		Alert:       true,
+		KeySpec:     set.NewStrSet(),
+		FieldSpec:   map[string]graylog.EventDefinitionFieldSpec{},
+		NotificationSettings: graylog.EventDefinitionNotificationSettings{
+			GracePeriodMS: grace * minuteMS,
+			BacklogSize:   size,
+		},
+		Notifications: []graylog.EventDefinitionNotification{},
+		Config:        cfg,
+	}, nil
+}
+
+func thresholdCondition(op, ref string, threshold int) *graylog.EventDefinitionConfigConditions {
+	v := float64(threshold)
+	return &graylog.EventDefinitionConfigConditions{
+		Expression: &graylog.EventDefinitionConfigConditionsExpression{
+			Expr:  op,
+			Left:  &graylog.EventDefinitionConfigConditionsExpression{Expr: "number-ref", Ref: ref},
+			Right: &graylog.EventDefinitionConfigConditionsExpression{Expr: "number", Value: &v},
+		},
+	}
+}
+
+func (c *Converter) convertMessageCount(
+	streamID string, p *graylog.MessageCountAlertConditionParameters,
+) (*graylog.EventDefinitionConfigAggregation, error) {
+	op := ""
+	switch strings.ToUpper(p.ThresholdType) {
+	case "MORE":
+		op = ">"
+	case "LESS":
+		op = "<"
+	default:
+		return nil, fmt.Errorf("invalid threshold_type of the message count alert condition: %s", p.ThresholdType)
+	}
+	return &graylog.EventDefinitionConfigAggregation{
+		Query:   p.Query,
+		Streams: []string{streamID},
+		GroupBy: []string{},
+		Series: []graylog.EventDefinitionConfigSeries{
+			{ID: "count", Function: "count"},
+		},
+		Conditions:     thresholdCondition(op, "count", p.Threshold),
+		SearchWithinMS: p.Time * minuteMS,
+		ExecuteEveryMS: c.executeEveryMS(),
+	}, nil
+}
+
+var aggregationFunctions = map[string]string{
+	"MEAN":   "avg",
+	"MIN":    "min",
+	"MAX":    "max",
+	"SUM":    "sum",
+	"STDDEV": "stddev",
+}
+
+func (c *Converter) convertFieldAggregation(
+	streamID string, p *graylog.FieldAggregationAlertConditionParameters,
+) (*graylog.EventDefinitionConfigAggregation, error) {
+	op := ""
+	switch strings.ToUpper(p.ThresholdType) {
+	case "HIGHER":
+		op = ">"
+	case "LOWER":
+		op = "<"
+	default:
+		return nil, fmt.Errorf("invalid threshold_type of the field aggregation alert condition: %s", p.ThresholdType)
+	}
+	fn, ok := aggregationFunctions[strings.ToUpper(p.Type)]
+	if !ok {
+		return nil, fmt.Errorf("invalid type of the field aggregation alert condition: %s", p.Type)
+	}
+	id := fn + "-" + p.Field
+	return &graylog.EventDefinitionConfigAggregation{
+		Query:   p.Query,
+		Streams: []string{streamID},
+		GroupBy: []string{},
+		Series: []graylog.EventDefinitionConfigSeries{
+			{ID: id, Function: fn, Field: p.Field},
+		},
+		Conditions:     thresholdCondition(op, id, p.Threshold),
+		SearchWithinMS: p.Time * minuteMS,
+		ExecuteEveryMS: c.executeEveryMS(),
+	}, nil
+}
+
+// convertFieldContent converts a field content alert condition to the aggregation
+// which counts messages whose field has the value.
+// Field content alert conditions search messages since the last check,
+// so the search range is the same as the execution interval.
+func (c *Converter) convertFieldContent(
+	streamID string, p *graylog.FieldContentAlertConditionParameters,
+) *graylog.EventDefinitionConfigAggregation {
+	query := p.Field + ":" + strconv.Quote(p.Value)
+	if q := strings.TrimSpace(p.Query); q != "" && q != "*" {
+		query += " AND (" + q + ")"
+	}
+	interval := c.executeEveryMS()
+	return &graylog.EventDefinitionConfigAggregation{
+		Query:   query,
+		Streams: []string{streamID},
+		GroupBy: []string{},
+		Series: []graylog.EventDefinitionConfigSeries{
+			{ID: "count", Function: "count"},
+		},
+		Conditions:     thresholdCondition(">", "count", 0),
+		SearchWithinMS: interval,
+		ExecuteEveryMS: interval,
+	}
+}
+
+// ConvertAlarmCallback converts an alarm callback to an event notification.
+// receivers are the stream's alert receivers, which legacy email alarm callbacks without receivers send emails to.
+//
+// An alarm callback is converted to the native notification type only if the behavior is kept,
+// because the templates of alarm callbacks and event notifications have different variables.
+// Otherwise it is converted to "legacy-alarm-callback-notification-v1".
+func (c *Converter) ConvertAlarmCallback(
+	ac *graylog.AlarmCallback, receivers *graylog.AlertReceivers,
+) (*graylog.EventNotification, error) {
+	notif := &graylog.EventNotification{
+		Title:       ac.Title,
+		Description: "Migrated legacy alarm callback",
+	}
+	if !c.LegacyCallbacks {
+		switch cfg := ac.Configuration.(type) {
+		case *graylog.HTTPAlarmCallbackConfiguration:
+			notif.Config = &graylog.EventNotificationConfigHTTP{URL: cfg.URL}
+			return notif, nil
+		case *graylog.EmailAlarmCallbackConfiguration:
+			if cfg.Body == "" {
+				notif.Config = convertEmailAlarmCallback(cfg, receivers)
+				return notif, nil
+			}
+		case *graylog.SlackAlarmCallbackConfiguration:
+			if cfg.CustomMessage == "" && cfg.ProxyAddress == "" {
+				notif.Config = &graylog.EventNotificationConfigSlack{
+					WebhookURL:    cfg.WebhookURL,
+					Channel:       cfg.Channel,
+					Color:         cfg.Color,
+					BacklogSize:   cfg.BacklogItems,
+					UserName:      cfg.UserName,
+					NotifyChannel: cfg.NotifyChannel,
+					LinkNames:     cfg.LinkNames,
+					IconURL:       cfg.IconURL,
+					IconEmoji:     cfg.IconEmoji,
+				}
+				return notif, nil
+			}
+		}
+	}
+	cfg, err := legacyConfiguration(ac)
+	if err != nil {
+		return nil, err
+	}
+	notif.Config = cfg
+	return notif, nil
+}
+
+func convertEmailAlarmCallback(
+	cfg *graylog.EmailAlarmCallbackConfiguration, receivers *graylog.AlertReceivers,
+) *graylog.EventNotificationConfigEmail {
+	emails := cfg.EmailReceivers.ToList()
+	users := cfg.UserReceivers.ToList()
+	if len(emails) == 0 && len(users) == 0 && receivers != nil {
+		emails = append(emails, receivers.Emails...)
+		users = append(users, receivers.Users...)
+	}
+	return &graylog.EventNotificationConfigEmail{
+		Sender:          cfg.Sender,
+		Subject:         cfg.Subject,
+		BodyTemplate:    DefaultEmailBodyTemplate,
+		EmailRecipients: util.SortedStrings(emails),
+		UserRecipients:  util.SortedStrings(users),
+	}
+}
+
+func legacyConfiguration(ac *graylog.AlarmCallback) (*graylog.EventNotificationConfigLegacyAlarmCallback, error) {
+	if ac.Configuration == nil {
+		return nil, &UnsupportedError{Kind: "alarm callback", Type: "", Reason: "the configuration is empty"}
+	}
+	if cfg, ok := ac.Configuration.(*graylog.GeneralAlarmCallbackConfiguration); ok {
+		return &graylog.EventNotificationConfigLegacyAlarmCallback{
+			CallbackType:  cfg.Type,
+			Configuration: cfg.Configuration,
+		}, nil
+	}
+	m, err := util.ToMap(ac.Configuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the configuration of the alarm callback '%s': %w", ac.Title, err)
+	}
+	return &graylog.EventNotificationConfigLegacyAlarmCallback{
+		CallbackType:  ac.Type(),
+		Configuration: m,
+	}, nil
+}
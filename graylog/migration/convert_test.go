@@ -0,0 +1,184 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/go-set/v6"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/migration"
+)
+
+func TestConverter_ConvertAlertCondition(t *testing.T) {
+	data := []struct {
+		title      string
+		params     graylog.AlertConditionParameters
+		query      string
+		series     []graylog.EventDefinitionConfigSeries
+		conditions string
+		within     int
+		isErr      bool
+	}{
+		{
+			title: "message count",
+			params: graylog.MessageCountAlertConditionParameters{
+				Grace: 5, Backlog: 2, Threshold: 10, Time: 5, ThresholdType: "MORE", Query: "level:3",
+			},
+			query:      "level:3",
+			series:     []graylog.EventDefinitionConfigSeries{{ID: "count", Function: "count"}},
+			conditions: "count > 10",
+			within:     300000,
+		},
+		{
+			title: "field aggregation",
+			params: graylog.FieldAggregationAlertConditionParameters{
+				Grace: 5, Backlog: 2, Threshold: 100, Time: 10, ThresholdType: "LOWER", Type: "MEAN", Field: "took_ms",
+			},
+			series:     []graylog.EventDefinitionConfigSeries{{ID: "avg-took_ms", Function: "avg", Field: "took_ms"}},
+			conditions: "avg-took_ms < 100",
+			within:     600000,
+		},
+		{
+			title: "field content",
+			params: graylog.FieldContentAlertConditionParameters{
+				Grace: 5, Backlog: 2, Field: "message", Value: `say "hello"`, Query: "source:foo",
+			},
+			query:      `message:"say \"hello\"" AND (source:foo)`,
+			series:     []graylog.EventDefinitionConfigSeries{{ID: "count", Function: "count"}},
+			conditions: "count > 0",
+			within:     60000,
+		},
+		{
+			title: "invalid threshold type",
+			params: graylog.MessageCountAlertConditionParameters{
+				ThresholdType: "EQUAL",
+			},
+			isErr: true,
+		},
+		{
+			title: "unsupported",
+			params: graylog.GeneralAlertConditionParameters{
+				Type: "foo",
+			},
+			isErr: true,
+		},
+	}
+	conv := &migration.Converter{}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			definition, err := conv.ConvertAlertCondition("000000000000000000000001", &graylog.AlertCondition{
+				Title: "test", Parameters: d.params,
+			})
+			if d.isErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, "test", definition.Title)
+			require.Equal(t, 2, definition.Priority)
+			require.True(t, definition.Alert)
+			require.Equal(t, graylog.EventDefinitionNotificationSettings{
+				GracePeriodMS: 300000, BacklogSize: 2,
+			}, definition.NotificationSettings)
+			cfg, ok := definition.Config.(*graylog.EventDefinitionConfigAggregation)
+			require.True(t, ok)
+			require.Equal(t, d.query, cfg.Query)
+			require.Equal(t, []string{"000000000000000000000001"}, cfg.Streams)
+			require.Equal(t, d.series, cfg.Series)
+			require.Equal(t, d.conditions, cfg.Conditions.Expression.String())
+			require.Equal(t, d.within, cfg.SearchWithinMS)
+			require.Equal(t, 60000, cfg.ExecuteEveryMS)
+		})
+	}
+}
+
+func TestConverter_ConvertAlarmCallback(t *testing.T) {
+	receivers := &graylog.AlertReceivers{
+		Emails: []string{"foo@example.com"},
+		Users:  []string{"admin"},
+	}
+	data := []struct {
+		title  string
+		conv   migration.Converter
+		config graylog.AlarmCallbackConfiguration
+		exp    graylog.EventNotificationConfig
+	}{
+		{
+			title:  "http",
+			config: &graylog.HTTPAlarmCallbackConfiguration{URL: "https://example.com"},
+			exp:    &graylog.EventNotificationConfigHTTP{URL: "https://example.com"},
+		},
+		{
+			title: "email without receivers",
+			config: &graylog.EmailAlarmCallbackConfiguration{
+				Sender: "graylog@example.com", Subject: "alert",
+			},
+			exp: &graylog.EventNotificationConfigEmail{
+				Sender:          "graylog@example.com",
+				Subject:         "alert",
+				BodyTemplate:    migration.DefaultEmailBodyTemplate,
+				EmailRecipients: []string{"foo@example.com"},
+				UserRecipients:  []string{"admin"},
+			},
+		},
+		{
+			title: "email with custom body",
+			config: &graylog.EmailAlarmCallbackConfiguration{
+				Sender: "graylog@example.com", Subject: "alert", Body: "${stream.title}",
+				EmailReceivers: set.NewStrSet("bar@example.com"),
+			},
+			exp: &graylog.EventNotificationConfigLegacyAlarmCallback{
+				CallbackType: graylog.EmailAlarmCallbackType,
+				Configuration: map[string]interface{}{
+					"sender":          "graylog@example.com",
+					"subject":         "alert",
+					"body":            "${stream.title}",
+					"email_receivers": []interface{}{"bar@example.com"},
+				},
+			},
+		},
+		{
+			title: "slack",
+			config: &graylog.SlackAlarmCallbackConfiguration{
+				Color: "#FF0000", WebhookURL: "https://hooks.slack.com/services/xxx", Channel: "#general", BacklogItems: 5,
+			},
+			exp: &graylog.EventNotificationConfigSlack{
+				Color: "#FF0000", WebhookURL: "https://hooks.slack.com/services/xxx", Channel: "#general", BacklogSize: 5,
+			},
+		},
+		{
+			title: "legacy callbacks",
+			conv:  migration.Converter{LegacyCallbacks: true},
+			config: &graylog.HTTPAlarmCallbackConfiguration{
+				URL: "https://example.com",
+			},
+			exp: &graylog.EventNotificationConfigLegacyAlarmCallback{
+				CallbackType: graylog.HTTPAlarmCallbackType,
+				Configuration: map[string]interface{}{
+					"url": "https://example.com",
+				},
+			},
+		},
+		{
+			title: "third party",
+			config: &graylog.GeneralAlarmCallbackConfiguration{
+				Type: "com.example.FooAlarmCallback", Configuration: map[string]interface{}{"foo": "bar"},
+			},
+			exp: &graylog.EventNotificationConfigLegacyAlarmCallback{
+				CallbackType:  "com.example.FooAlarmCallback",
+				Configuration: map[string]interface{}{"foo": "bar"},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			notif, err := d.conv.ConvertAlarmCallback(&graylog.AlarmCallback{
+				Title: "test", Configuration: d.config,
+			}, receivers)
+			require.Nil(t, err)
+			require.Equal(t, "test", notif.Title)
+			require.Equal(t, d.exp, notif.Config)
+		})
+	}
+}
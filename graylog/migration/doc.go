@@ -0,0 +1,9 @@
+/*
+Package migration converts Graylog 2 alert conditions and alarm callbacks
+to Graylog 3 event definitions and event notifications.
+
+Each alert condition of a stream is converted to an event definition of the type "aggregation-v1",
+and each alarm callback of the stream is converted to an event notification which all the stream's event definitions use.
+The result can be created through the client and written as Terraform configuration.
+*/
+package migration
@@ -0,0 +1,162 @@
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/hcl"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+// WriteHCL writes the result as the configuration of graylog_event_notification and graylog_event_definition.
+// refs maps ids such as stream ids to expressions such as "graylog_stream.foo.id".
+// If an id isn't found in refs, the id is written as a string.
+func WriteHCL(w io.Writer, result *Result, refs map[string]string) error {
+	file, err := NewHCLFile(result, refs)
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteTo(w)
+	return err
+}
+
+// NewHCLFile returns the configuration of the result.
+func NewHCLFile(result *Result, refs map[string]string) (*hcl.File, error) {
+	names := hcl.NewNames()
+	file := &hcl.File{}
+	for _, sr := range result.Streams {
+		notifRefs := make([]string, len(sr.Notifications))
+		for i, n := range sr.Notifications {
+			name := names.New(sr.Stream.Title + " " + n.EventNotification.Title)
+			block, err := newNotificationBlock(name, n.EventNotification)
+			if err != nil {
+				return nil, err
+			}
+			file.Blocks = append(file.Blocks, block)
+			notifRefs[i] = "graylog_event_notification." + name + ".id"
+		}
+		for _, d := range sr.Definitions {
+			name := names.New(sr.Stream.Title + " " + d.EventDefinition.Title)
+			block, err := newDefinitionBlock(name, d.EventDefinition, notifRefs, refs)
+			if err != nil {
+				return nil, err
+			}
+			file.Blocks = append(file.Blocks, block)
+		}
+	}
+	return file, nil
+}
+
+func refExpr(id string, refs map[string]string) hcl.Expr {
+	if ref, ok := refs[id]; ok {
+		return hcl.Raw(ref)
+	}
+	return hcl.String(id)
+}
+
+func newNotificationBlock(name string, notif *graylog.EventNotification) (*hcl.Block, error) {
+	block := hcl.NewBlock("resource", "graylog_event_notification", name)
+	body := &block.Body
+	body.Attr("title", hcl.String(notif.Title))
+	body.Attr("description", hcl.String(notif.Description))
+	switch cfg := notif.Config.(type) {
+	case *graylog.EventNotificationConfigHTTP:
+		b := body.Block("http")
+		b.Attr("url", hcl.String(cfg.URL))
+	case *graylog.EventNotificationConfigEmail:
+		b := body.Block("email")
+		b.Attr("sender", hcl.String(cfg.Sender))
+		b.Attr("subject", hcl.String(cfg.Subject))
+		b.Attr("body_template", hcl.String(cfg.BodyTemplate))
+		b.Attr("email_recipients", hcl.StringList(cfg.EmailRecipients))
+		b.Attr("user_recipients", hcl.StringList(cfg.UserRecipients))
+	case *graylog.EventNotificationConfigSlack:
+		b := body.Block("slack")
+		b.Attr("webhook_url", hcl.String(cfg.WebhookURL))
+		b.Attr("channel", hcl.String(cfg.Channel))
+		b.Attr("color", hcl.String(cfg.Color))
+		b.Attr("backlog_size", hcl.Int(cfg.BacklogSize))
+		b.Attr("user_name", hcl.String(cfg.UserName))
+		b.Attr("notify_channel", hcl.Bool(cfg.NotifyChannel))
+		b.Attr("link_names", hcl.Bool(cfg.LinkNames))
+		b.Attr("icon_url", hcl.String(cfg.IconURL))
+		b.Attr("icon_emoji", hcl.String(cfg.IconEmoji))
+	default:
+		expr, err := jsonencodeExpr(notif.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the config of the event notification '%s': %w", notif.Title, err)
+		}
+		body.Attr("config", expr)
+	}
+	return block, nil
+}
+
+// jsonencodeExpr returns the expression "jsonencode({...})" of the value.
+func jsonencodeExpr(v interface{}) (hcl.Expr, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var a interface{}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil, err
+	}
+	expr, err := hcl.Value(a)
+	if err != nil {
+		return nil, err
+	}
+	return hcl.Call("jsonencode", expr), nil
+}
+
+func newDefinitionBlock(
+	name string, definition *graylog.EventDefinition, notifRefs []string, refs map[string]string,
+) (*hcl.Block, error) {
+	block := hcl.NewBlock("resource", "graylog_event_definition", name)
+	body := &block.Body
+	body.Attr("title", hcl.String(definition.Title))
+	body.Attr("description", hcl.String(definition.Description))
+	body.Attr("priority", hcl.Int(definition.Priority))
+	body.Attr("alert", hcl.Bool(definition.Alert))
+
+	cfg, ok := definition.Config.(*graylog.EventDefinitionConfigAggregation)
+	if !ok {
+		return nil, fmt.Errorf("the config of the event definition '%s' isn't aggregation", definition.Title)
+	}
+	agg := body.Block("aggregation")
+	agg.Attr("query", hcl.String(cfg.Query))
+	streams := make([]hcl.Expr, len(cfg.Streams))
+	for i, id := range cfg.Streams {
+		streams[i] = refExpr(id, refs)
+	}
+	agg.Attr("streams", hcl.List(streams...))
+	agg.Attr("group_by", hcl.StringList(cfg.GroupBy))
+	agg.Attr("search_within", hcl.String(util.FormatDurationMS(cfg.SearchWithinMS)))
+	agg.Attr("execute_every", hcl.String(util.FormatDurationMS(cfg.ExecuteEveryMS)))
+	if cfg.Conditions != nil {
+		agg.Attr("conditions", hcl.String(cfg.Conditions.Expression.String()))
+	}
+	for _, s := range cfg.Series {
+		series := agg.Block("series")
+		series.Attr("id", hcl.String(s.ID))
+		series.Attr("function", hcl.String(s.Function))
+		if s.Field != "" {
+			series.Attr("field", hcl.String(s.Field))
+		}
+	}
+
+	settings := body.Block("notification_settings")
+	settings.Attr("grace_period_ms", hcl.Int(definition.NotificationSettings.GracePeriodMS))
+	settings.Attr("backlog_size", hcl.Int(definition.NotificationSettings.BacklogSize))
+
+	sorted := make([]string, len(notifRefs))
+	copy(sorted, notifRefs)
+	sort.Strings(sorted)
+	for _, ref := range sorted {
+		n := body.Block("notifications")
+		n.Attr("notification_id", hcl.Raw(ref))
+	}
+	return block, nil
+}
@@ -0,0 +1,162 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+type (
+	// Migrator reads alert conditions and alarm callbacks through the client,
+	// converts them and creates the event definitions and event notifications.
+	Migrator struct {
+		Client    *client.Client
+		Converter Converter
+		// If DryRun is true, Migrator doesn't create anything.
+		DryRun bool
+	}
+
+	// Result is the result of the migration.
+	Result struct {
+		Streams []StreamResult
+		Skipped []Skipped
+	}
+
+	// StreamResult is the result of a stream's migration.
+	// All the event definitions use all the event notifications,
+	// because Graylog 2 calls all the stream's alarm callbacks when any alert condition is triggered.
+	StreamResult struct {
+		Stream        graylog.Stream
+		Definitions   []Definition
+		Notifications []Notification
+	}
+
+	// Definition is an event definition converted from an alert condition.
+	Definition struct {
+		AlertCondition  graylog.AlertCondition
+		EventDefinition *graylog.EventDefinition
+	}
+
+	// Notification is an event notification converted from an alarm callback.
+	Notification struct {
+		AlarmCallback     graylog.AlarmCallback
+		EventNotification *graylog.EventNotification
+	}
+
+	// Skipped is an alert condition or an alarm callback which isn't converted.
+	Skipped struct {
+		StreamID string
+		Kind     string
+		ID       string
+		Title    string
+		Err      error
+	}
+)
+
+// Migrate migrates alert conditions and alarm callbacks of given streams.
+// If no stream id is given, all streams are migrated.
+// Alert conditions and alarm callbacks which can't be converted are skipped and reported in the result.
+// When an error occurs, the result so far is returned with the error.
+func (m *Migrator) Migrate(ctx context.Context, streamIDs ...string) (*Result, error) {
+	if m.Client == nil {
+		return nil, errors.New("client is nil")
+	}
+	streams, err := m.getStreams(ctx, streamIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	for i := range streams {
+		sr, skipped, err := m.migrateStream(ctx, &streams[i])
+		result.Skipped = append(result.Skipped, skipped...)
+		if sr != nil {
+			result.Streams = append(result.Streams, *sr)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (m *Migrator) getStreams(ctx context.Context, ids []string) ([]graylog.Stream, error) {
+	if len(ids) == 0 {
+		streams, _, _, err := m.Client.GetStreams(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get streams: %w", err)
+		}
+		return streams, nil
+	}
+	streams := make([]graylog.Stream, len(ids))
+	for i, id := range ids {
+		stream, _, err := m.Client.GetStream(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get a stream %s: %w", id, err)
+		}
+		streams[i] = *stream
+	}
+	return streams, nil
+}
+
+func (m *Migrator) migrateStream(ctx context.Context, stream *graylog.Stream) (*StreamResult, []Skipped, error) {
+	conds, _, _, err := m.Client.GetStreamAlertConditions(ctx, stream.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get alert conditions of the stream %s: %w", stream.ID, err)
+	}
+	if len(conds) == 0 {
+		return nil, nil, nil
+	}
+	callbacks, _, _, err := m.Client.GetStreamAlarmCallbacks(ctx, stream.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get alarm callbacks of the stream %s: %w", stream.ID, err)
+	}
+
+	sr := &StreamResult{Stream: *stream}
+	skipped := []Skipped{}
+	for i := range callbacks {
+		ac := callbacks[i]
+		notif, err := m.Converter.ConvertAlarmCallback(&ac, stream.AlertReceivers)
+		if err != nil {
+			skipped = append(skipped, Skipped{
+				StreamID: stream.ID, Kind: "alarm callback", ID: ac.ID, Title: ac.Title, Err: err,
+			})
+			continue
+		}
+		sr.Notifications = append(sr.Notifications, Notification{AlarmCallback: ac, EventNotification: notif})
+	}
+	for i := range conds {
+		cond := conds[i]
+		definition, err := m.Converter.ConvertAlertCondition(stream.ID, &cond)
+		if err != nil {
+			skipped = append(skipped, Skipped{
+				StreamID: stream.ID, Kind: "alert condition", ID: cond.ID, Title: cond.Title, Err: err,
+			})
+			continue
+		}
+		sr.Definitions = append(sr.Definitions, Definition{AlertCondition: cond, EventDefinition: definition})
+	}
+	if m.DryRun {
+		return sr, skipped, nil
+	}
+	return sr, skipped, m.create(ctx, sr)
+}
+
+func (m *Migrator) create(ctx context.Context, sr *StreamResult) error {
+	notifs := make([]graylog.EventDefinitionNotification, len(sr.Notifications))
+	for i, n := range sr.Notifications {
+		if _, err := m.Client.CreateEventNotification(ctx, n.EventNotification); err != nil {
+			return fmt.Errorf("failed to create an event notification '%s': %w", n.EventNotification.Title, err)
+		}
+		notifs[i] = graylog.EventDefinitionNotification{NotificationID: n.EventNotification.ID}
+	}
+	for _, d := range sr.Definitions {
+		d.EventDefinition.Notifications = notifs
+		if _, err := m.Client.CreateEventDefinition(ctx, d.EventDefinition); err != nil {
+			return fmt.Errorf("failed to create an event definition '%s': %w", d.EventDefinition.Title, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package migration_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/migration"
+)
+
+const streamID = "5d84c1a92ab79c000d35d6c7"
+
+func newTestClient(t *testing.T) *client.Client {
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/streams/" + streamID,
+							},
+							Response: &flute.Response{
+								Base: http.Response{StatusCode: 200},
+								BodyString: `{
+  "id": "` + streamID + `",
+  "title": "Errors",
+  "index_set_id": "5d84c1a92ab79c000d35d6ca",
+  "alert_receivers": {"emails": ["foo@example.com"], "users": []}
+}`,
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/streams/" + streamID + "/alerts/conditions",
+							},
+							Response: &flute.Response{
+								Base: http.Response{StatusCode: 200},
+								BodyString: `{
+  "total": 2,
+  "conditions": [
+    {
+      "id": "a1",
+      "type": "message_count",
+      "title": "too many errors",
+      "parameters": {"grace": 1, "backlog": 0, "threshold": 10, "time": 5, "threshold_type": "MORE", "repeat_notifications": false, "query": "*"}
+    },
+    {
+      "id": "a2",
+      "type": "com.example.FooCondition",
+      "title": "foo",
+      "parameters": {}
+    }
+  ]
+}`,
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "GET",
+								Path:   "/api/streams/" + streamID + "/alarmcallbacks",
+							},
+							Response: &flute.Response{
+								Base: http.Response{StatusCode: 200},
+								BodyString: `{
+  "total": 1,
+  "alarmcallbacks": [
+    {
+      "id": "c1",
+      "stream_id": "` + streamID + `",
+      "title": "webhook",
+      "type": "org.graylog2.alarmcallbacks.HTTPAlarmCallback",
+      "configuration": {"url": "https://example.com/hook"}
+    }
+  ]
+}`,
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/events/notifications",
+							},
+							Tester: &flute.Tester{
+								BodyJSONString: `{
+  "title": "webhook",
+  "description": "Migrated legacy alarm callback",
+  "config": {"type": "http-notification-v1", "url": "https://example.com/hook"}
+}`,
+							},
+							Response: &flute.Response{
+								Base:       http.Response{StatusCode: 200},
+								BodyString: `{"id": "n1", "title": "webhook", "description": "Migrated legacy alarm callback", "config": {"type": "http-notification-v1", "url": "https://example.com/hook"}}`,
+							},
+						},
+						{
+							Matcher: &flute.Matcher{
+								Method: "POST",
+								Path:   "/api/events/definitions",
+							},
+							Tester: &flute.Tester{
+								BodyJSONString: `{
+  "title": "too many errors",
+  "description": "Migrated legacy alert condition",
+  "priority": 2,
+  "alert": true,
+  "key_spec": [],
+  "notification_settings": {"grace_period_ms": 60000, "backlog_size": 0},
+  "notifications": [{"notification_id": "n1"}],
+  "config": {
+    "type": "aggregation-v1",
+    "query": "*",
+    "streams": ["` + streamID + `"],
+    "group_by": [],
+    "series": [{"id": "count", "function": "count"}],
+    "conditions": {
+      "expression": {
+        "expr": ">",
+        "left": {"expr": "number-ref", "ref": "count"},
+        "right": {"expr": "number", "value": 10}
+      }
+    },
+    "search_within_ms": 300000,
+    "execute_every_ms": 60000
+  }
+}`,
+							},
+							Response: &flute.Response{
+								Base:       http.Response{StatusCode: 200},
+								BodyString: `{"id": "d1"}`,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	return cl
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	m := &migration.Migrator{Client: newTestClient(t)}
+	result, err := m.Migrate(context.Background(), streamID)
+	require.Nil(t, err)
+	require.Len(t, result.Streams, 1)
+	require.Len(t, result.Skipped, 1)
+	require.Equal(t, "a2", result.Skipped[0].ID)
+	sr := result.Streams[0]
+	require.Equal(t, "n1", sr.Notifications[0].EventNotification.ID)
+	require.Equal(t, "d1", sr.Definitions[0].EventDefinition.ID)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, migration.WriteHCL(buf, result, map[string]string{
+		streamID: "graylog_stream.errors.id",
+	}))
+	require.Equal(t, `resource "graylog_event_notification" "errors_webhook" {
+  title       = "webhook"
+  description = "Migrated legacy alarm callback"
+
+  http {
+    url = "https://example.com/hook"
+  }
+}
+
+resource "graylog_event_definition" "errors_too_many_errors" {
+  title       = "too many errors"
+  description = "Migrated legacy alert condition"
+  priority    = 2
+  alert       = true
+
+  aggregation {
+    query         = "*"
+    streams       = [graylog_stream.errors.id]
+    group_by      = []
+    search_within = "5m"
+    execute_every = "1m"
+    conditions    = "count > 10"
+
+    series {
+      id       = "count"
+      function = "count"
+    }
+  }
+
+  notification_settings {
+    grace_period_ms = 60000
+    backlog_size    = 0
+  }
+
+  notifications {
+    notification_id = graylog_event_notification.errors_webhook.id
+  }
+}
+`, buf.String())
+}
@@ -0,0 +1,166 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// current is the current state of the cluster. Resources are indexed by the natural keys.
+type current struct {
+	indexSets     map[string]*graylog.IndexSet
+	streams       map[string]*graylog.Stream
+	inputs        map[string]*graylog.Input
+	pipelineRules map[string]*graylog.PipelineRule
+	pipelines     map[string]*graylog.Pipeline
+	// connections maps stream ids to pipeline ids.
+	connections map[string][]string
+	roles       map[string]*graylog.Role
+	users       map[string]*graylog.User
+}
+
+func duplicatedError(kind Kind, key string) error {
+	return fmt.Errorf("%s '%s' is duplicated in the cluster, so it can't be identified by the key", kind, key)
+}
+
+// fetch gets the resources which the spec manages or refers to.
+func fetch(ctx context.Context, cl *client.Client, spec *Spec) (*current, error) {
+	cur := &current{
+		indexSets:     map[string]*graylog.IndexSet{},
+		streams:       map[string]*graylog.Stream{},
+		inputs:        map[string]*graylog.Input{},
+		pipelineRules: map[string]*graylog.PipelineRule{},
+		pipelines:     map[string]*graylog.Pipeline{},
+		connections:   map[string][]string{},
+		roles:         map[string]*graylog.Role{},
+		users:         map[string]*graylog.User{},
+	}
+	connected := false
+	for _, stream := range spec.Streams {
+		if stream.Pipelines != nil {
+			connected = true
+			break
+		}
+	}
+
+	if spec.IndexSets != nil || spec.Streams != nil {
+		indexSets, _, _, _, err := cl.GetIndexSets(ctx, 0, 0, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get index sets: %w", err)
+		}
+		for i := range indexSets {
+			is := &indexSets[i]
+			if _, ok := cur.indexSets[is.Title]; ok {
+				return nil, duplicatedError(KindIndexSet, is.Title)
+			}
+			cur.indexSets[is.Title] = is
+		}
+	}
+	if spec.Streams != nil {
+		streams, _, _, err := cl.GetStreams(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get streams: %w", err)
+		}
+		for i := range streams {
+			stream := &streams[i]
+			if _, ok := cur.streams[stream.Title]; ok {
+				return nil, duplicatedError(KindStream, stream.Title)
+			}
+			cur.streams[stream.Title] = stream
+		}
+	}
+	if spec.Inputs != nil {
+		inputs, _, _, err := cl.GetInputs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inputs: %w", err)
+		}
+		for i := range inputs {
+			input := &inputs[i]
+			if _, ok := cur.inputs[input.Title]; ok {
+				return nil, duplicatedError(KindInput, input.Title)
+			}
+			cur.inputs[input.Title] = input
+		}
+	}
+	if spec.PipelineRules != nil {
+		rules, _, err := cl.GetPipelineRules(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pipeline rules: %w", err)
+		}
+		for i := range rules {
+			rule := &rules[i]
+			if _, ok := cur.pipelineRules[rule.Title]; ok {
+				return nil, duplicatedError(KindPipelineRule, rule.Title)
+			}
+			cur.pipelineRules[rule.Title] = rule
+		}
+	}
+	if spec.Pipelines != nil || connected {
+		pipes, _, err := cl.GetPipelines(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pipelines: %w", err)
+		}
+		for i := range pipes {
+			pipe := &pipes[i]
+			if _, ok := cur.pipelines[pipe.Title]; ok {
+				return nil, duplicatedError(KindPipeline, pipe.Title)
+			}
+			cur.pipelines[pipe.Title] = pipe
+		}
+	}
+	if connected {
+		conns, _, err := cl.GetPipelineConnections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pipeline connections: %w", err)
+		}
+		for _, conn := range conns {
+			cur.connections[conn.StreamID] = conn.PipelineIDs
+		}
+	}
+	if spec.Roles != nil {
+		roles, _, _, err := cl.GetRoles(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get roles: %w", err)
+		}
+		for i := range roles {
+			cur.roles[roles[i].Name] = &roles[i]
+		}
+	}
+	if spec.Users != nil {
+		users, _, err := cl.GetUsers(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+		for i := range users {
+			cur.users[users[i].Username] = &users[i]
+		}
+	}
+	return cur, nil
+}
+
+// setIDs adds the ids of the current resources to the state.
+func (cur *current) setIDs(st *state) {
+	for k, v := range cur.indexSets {
+		st.set(KindIndexSet, k, v.ID)
+	}
+	for k, v := range cur.streams {
+		st.set(KindStream, k, v.ID)
+		for _, rule := range v.Rules {
+			st.set(KindStreamRule, k+"/"+streamRuleKey(rule.Field, rule.Type, rule.Value, rule.Inverted), rule.ID)
+		}
+	}
+	for k, v := range cur.inputs {
+		st.set(KindInput, k, v.ID)
+	}
+	for k, v := range cur.pipelineRules {
+		st.set(KindPipelineRule, k, v.ID)
+	}
+	for k, v := range cur.pipelines {
+		st.set(KindPipeline, k, v.ID)
+	}
+	for k, v := range cur.users {
+		st.set(KindUser, k, v.ID)
+	}
+}
@@ -0,0 +1,18 @@
+/*
+Package reconciler reconciles a declarative spec of Graylog resources against a cluster without Terraform.
+
+The spec is written in YAML or JSON and has index sets, streams with their rules and pipeline connections,
+inputs, pipeline rules, pipelines, roles and users.
+Resources are matched by natural keys instead of ids.
+
+* index sets, streams and inputs: title
+* stream rules: field, type, value and inverted
+* pipeline rules and pipelines: the name in the source
+* roles: name
+* users: username
+
+Reconciler computes a plan of creates, updates and deletes in the dependency order and applies it.
+Only kinds which the spec has are managed, so if the spec doesn't have "users" no user is deleted.
+Built-in resources such as the default index set, the default stream and read only roles are never deleted.
+*/
+package reconciler
@@ -0,0 +1,128 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func indexSetSpecFields(is *IndexSetSpec) map[string]interface{} {
+	return map[string]interface{}{
+		"description":                         is.Description,
+		"index_prefix":                        is.IndexPrefix,
+		"shards":                              is.Shards,
+		"replicas":                            is.Replicas,
+		"rotation_strategy_class":             is.RotationStrategyClass,
+		"rotation_strategy":                   is.RotationStrategy,
+		"retention_strategy_class":            is.RetentionStrategyClass,
+		"retention_strategy":                  is.RetentionStrategy,
+		"index_analyzer":                      is.IndexAnalyzer,
+		"index_optimization_max_num_segments": is.IndexOptimizationMaxNumSegments,
+		"index_optimization_disabled":         is.IndexOptimizationDisabled,
+		"field_type_refresh_interval":         is.FieldTypeRefreshInterval,
+		"writable":                            *is.Writable,
+	}
+}
+
+func indexSetFields(is *graylog.IndexSet) map[string]interface{} {
+	return map[string]interface{}{
+		"description":                         is.Description,
+		"index_prefix":                        is.IndexPrefix,
+		"shards":                              is.Shards,
+		"replicas":                            is.Replicas,
+		"rotation_strategy_class":             is.RotationStrategyClass,
+		"rotation_strategy":                   is.RotationStrategy,
+		"retention_strategy_class":            is.RetentionStrategyClass,
+		"retention_strategy":                  is.RetentionStrategy,
+		"index_analyzer":                      is.IndexAnalyzer,
+		"index_optimization_max_num_segments": is.IndexOptimizationMaxNumSegments,
+		"index_optimization_disabled":         is.IndexOptimizationDisabled,
+		"field_type_refresh_interval":         is.FieldTypeRefreshInterval,
+		"writable":                            is.Writable,
+	}
+}
+
+// setIndexSetSpec sets the spec's fields to the index set.
+func setIndexSetSpec(is *graylog.IndexSet, spec *IndexSetSpec) error {
+	is.Title = spec.Title
+	is.Description = spec.Description
+	is.IndexPrefix = spec.IndexPrefix
+	is.Shards = spec.Shards
+	is.Replicas = spec.Replicas
+	is.RotationStrategyClass = spec.RotationStrategyClass
+	is.RetentionStrategyClass = spec.RetentionStrategyClass
+	is.IndexAnalyzer = spec.IndexAnalyzer
+	is.IndexOptimizationMaxNumSegments = spec.IndexOptimizationMaxNumSegments
+	is.IndexOptimizationDisabled = spec.IndexOptimizationDisabled
+	is.FieldTypeRefreshInterval = spec.FieldTypeRefreshInterval
+	is.Writable = *spec.Writable
+	is.RotationStrategy = &graylog.RotationStrategy{}
+	if err := convert(spec.RotationStrategy, is.RotationStrategy); err != nil {
+		return fmt.Errorf("invalid rotation_strategy: %w", err)
+	}
+	is.RetentionStrategy = &graylog.RetentionStrategy{}
+	if err := convert(spec.RetentionStrategy, is.RetentionStrategy); err != nil {
+		return fmt.Errorf("invalid retention_strategy: %w", err)
+	}
+	return nil
+}
+
+func (pl *planner) planIndexSets() error {
+	if pl.spec.IndexSets == nil {
+		return nil
+	}
+	desired := make(map[string]struct{}, len(pl.spec.IndexSets))
+	for i := range pl.spec.IndexSets {
+		spec := pl.spec.IndexSets[i]
+		desired[spec.Title] = struct{}{}
+		is := &graylog.IndexSet{}
+		if err := setIndexSetSpec(is, &spec); err != nil {
+			return fmt.Errorf("index set '%s': %w", spec.Title, err)
+		}
+		cur, exists := pl.cur.indexSets[spec.Title]
+		fields := indexSetSpecFields(&spec)
+		var curFields map[string]interface{}
+		if exists {
+			if cur.IndexPrefix != spec.IndexPrefix {
+				return fmt.Errorf("index_prefix of the index set '%s' can't be changed", spec.Title)
+			}
+			curFields = indexSetFields(cur)
+			if cur.FieldTypeRefreshInterval == 0 {
+				// Graylog v2 doesn't support field_type_refresh_interval
+				delete(fields, "field_type_refresh_interval")
+			}
+		}
+		err := pl.addDiff(KindIndexSet, spec.Title, exists, curFields, fields,
+			func(ctx context.Context, st *state) error {
+				if _, err := st.client.CreateIndexSet(ctx, is); err != nil {
+					return err
+				}
+				st.set(KindIndexSet, spec.Title, is.ID)
+				return nil
+			},
+			func(ctx context.Context, st *state) error {
+				is.ID = cur.ID
+				_, _, err := st.client.UpdateIndexSet(ctx, is.NewUpdateParams())
+				return err
+			})
+		if err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(pl.cur.indexSets))
+	for k := range pl.cur.indexSets {
+		keys = append(keys, k)
+	}
+	pl.addDeletes(KindIndexSet, keys, desired, func(key string) func(context.Context, *state) error {
+		cur := pl.cur.indexSets[key]
+		if cur.Default {
+			return nil
+		}
+		return func(ctx context.Context, st *state) error {
+			_, err := st.client.DeleteIndexSet(ctx, cur.ID)
+			return err
+		}
+	})
+	return nil
+}
@@ -0,0 +1,111 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func inputSpecFields(input *InputSpec) map[string]interface{} {
+	attrs := input.Attributes
+	if attrs == nil {
+		attrs = map[string]interface{}{}
+	}
+	fields := map[string]interface{}{
+		"type":       input.Type,
+		"global":     input.Global,
+		"attributes": attrs,
+	}
+	if input.Node != "" {
+		fields["node"] = input.Node
+	}
+	return fields
+}
+
+func (pl *planner) planInputs() error {
+	if pl.spec.Inputs == nil {
+		return nil
+	}
+	desired := make(map[string]struct{}, len(pl.spec.Inputs))
+	for i := range pl.spec.Inputs {
+		spec := pl.spec.Inputs[i]
+		desired[spec.Title] = struct{}{}
+		cur, exists := pl.cur.inputs[spec.Title]
+		var (
+			curFields map[string]interface{}
+			data      *graylog.InputData
+		)
+		if exists {
+			if cur.Type() != spec.Type {
+				return fmt.Errorf("type of the input '%s' can't be changed", spec.Title)
+			}
+			d, err := cur.ToData()
+			if err != nil {
+				return fmt.Errorf("failed to convert the input '%s': %w", spec.Title, err)
+			}
+			data = d
+			// compare only attributes in the spec
+			attrs := make(map[string]interface{}, len(spec.Attributes))
+			for k := range spec.Attributes {
+				attrs[k] = d.Attrs[k]
+			}
+			curFields = map[string]interface{}{
+				"type":       cur.Type(),
+				"global":     cur.Global,
+				"node":       cur.Node,
+				"attributes": attrs,
+			}
+		}
+		err := pl.addDiff(KindInput, spec.Title, exists, curFields, inputSpecFields(&spec),
+			func(ctx context.Context, st *state) error {
+				d := &graylog.InputData{
+					Title:  spec.Title,
+					Type:   spec.Type,
+					Global: spec.Global,
+					Node:   spec.Node,
+					Attrs:  spec.Attributes,
+				}
+				input := &graylog.Input{}
+				if err := d.ToInput(input); err != nil {
+					return err
+				}
+				if _, err := st.client.CreateInput(ctx, input); err != nil {
+					return err
+				}
+				st.set(KindInput, spec.Title, input.ID)
+				return nil
+			},
+			func(ctx context.Context, st *state) error {
+				// Graylog overwrites attributes, so the attributes which aren't in the spec are kept
+				for k, v := range spec.Attributes {
+					data.Attrs[k] = v
+				}
+				data.Global = spec.Global
+				if spec.Node != "" {
+					data.Node = spec.Node
+				}
+				input := &graylog.Input{}
+				if err := data.ToInput(input); err != nil {
+					return err
+				}
+				_, _, err := st.client.UpdateInput(ctx, input.NewUpdateParams())
+				return err
+			})
+		if err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(pl.cur.inputs))
+	for k := range pl.cur.inputs {
+		keys = append(keys, k)
+	}
+	pl.addDeletes(KindInput, keys, desired, func(key string) func(context.Context, *state) error {
+		cur := pl.cur.inputs[key]
+		return func(ctx context.Context, st *state) error {
+			_, err := st.client.DeleteInput(ctx, cur.ID)
+			return err
+		}
+	})
+	return nil
+}
@@ -0,0 +1,113 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// planPipelineRules plans pipeline rules.
+// Graylog takes the title from the source, so the title isn't sent.
+func (pl *planner) planPipelineRules() error {
+	if pl.spec.PipelineRules == nil {
+		return nil
+	}
+	desired := make(map[string]struct{}, len(pl.spec.PipelineRules))
+	for i := range pl.spec.PipelineRules {
+		spec := pl.spec.PipelineRules[i]
+		title, err := pipelineRuleTitle(spec.Source)
+		if err != nil {
+			return err
+		}
+		desired[title] = struct{}{}
+		cur, exists := pl.cur.pipelineRules[title]
+		var curFields map[string]interface{}
+		if exists {
+			curFields = map[string]interface{}{"description": cur.Description, "source": cur.Source}
+		}
+		err = pl.addDiff(KindPipelineRule, title, exists, curFields,
+			map[string]interface{}{"description": spec.Description, "source": spec.Source},
+			func(ctx context.Context, st *state) error {
+				rule := &graylog.PipelineRule{Source: spec.Source, Description: spec.Description}
+				if _, err := st.client.CreatePipelineRule(ctx, rule); err != nil {
+					return err
+				}
+				st.set(KindPipelineRule, title, rule.ID)
+				return nil
+			},
+			func(ctx context.Context, st *state) error {
+				_, err := st.client.UpdatePipelineRule(ctx, &graylog.PipelineRule{
+					ID: cur.ID, Source: spec.Source, Description: spec.Description,
+				})
+				return err
+			})
+		if err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(pl.cur.pipelineRules))
+	for k := range pl.cur.pipelineRules {
+		keys = append(keys, k)
+	}
+	pl.addDeletes(KindPipelineRule, keys, desired, func(key string) func(context.Context, *state) error {
+		cur := pl.cur.pipelineRules[key]
+		return func(ctx context.Context, st *state) error {
+			_, err := st.client.DeletePipelineRule(ctx, cur.ID)
+			return err
+		}
+	})
+	return nil
+}
+
+// planPipelines plans pipelines.
+// Graylog takes the title and stages from the source, so they aren't sent.
+func (pl *planner) planPipelines() error {
+	if pl.spec.Pipelines == nil {
+		return nil
+	}
+	desired := make(map[string]struct{}, len(pl.spec.Pipelines))
+	for i := range pl.spec.Pipelines {
+		spec := pl.spec.Pipelines[i]
+		title, err := pipelineTitle(spec.Source)
+		if err != nil {
+			return err
+		}
+		desired[title] = struct{}{}
+		cur, exists := pl.cur.pipelines[title]
+		var curFields map[string]interface{}
+		if exists {
+			curFields = map[string]interface{}{"description": cur.Description, "source": cur.Source}
+		}
+		err = pl.addDiff(KindPipeline, title, exists, curFields,
+			map[string]interface{}{"description": spec.Description, "source": spec.Source},
+			func(ctx context.Context, st *state) error {
+				pipe := &graylog.Pipeline{Source: spec.Source, Description: spec.Description}
+				if _, err := st.client.CreatePipeline(ctx, pipe); err != nil {
+					return err
+				}
+				st.set(KindPipeline, title, pipe.ID)
+				return nil
+			},
+			func(ctx context.Context, st *state) error {
+				_, err := st.client.UpdatePipeline(ctx, &graylog.Pipeline{
+					ID: cur.ID, Source: spec.Source, Description: spec.Description,
+				})
+				return err
+			})
+		if err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(pl.cur.pipelines))
+	for k := range pl.cur.pipelines {
+		keys = append(keys, k)
+	}
+	pl.addDeletes(KindPipeline, keys, desired, func(key string) func(context.Context, *state) error {
+		cur := pl.cur.pipelines[key]
+		return func(ctx context.Context, st *state) error {
+			_, err := st.client.DeletePipeline(ctx, cur.ID)
+			return err
+		}
+	})
+	return nil
+}
@@ -0,0 +1,177 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Kind is the kind of resources.
+type Kind string
+
+const (
+	// KindIndexSet is the kind of index sets.
+	KindIndexSet Kind = "index_set"
+	// KindStream is the kind of streams.
+	KindStream Kind = "stream"
+	// KindStreamRule is the kind of stream rules. The key is "<stream title>/<field> <type> <value>".
+	KindStreamRule Kind = "stream_rule"
+	// KindInput is the kind of inputs.
+	KindInput Kind = "input"
+	// KindPipelineRule is the kind of pipeline rules.
+	KindPipelineRule Kind = "pipeline_rule"
+	// KindPipeline is the kind of pipelines.
+	KindPipeline Kind = "pipeline"
+	// KindPipelineConnection is the kind of the connections between a stream and pipelines. The key is the stream title.
+	KindPipelineConnection Kind = "pipeline_connection"
+	// KindRole is the kind of roles.
+	KindRole Kind = "role"
+	// KindUser is the kind of users.
+	KindUser Kind = "user"
+)
+
+// Action is the action of a change.
+type Action string
+
+const (
+	// ActionCreate creates a resource.
+	ActionCreate Action = "create"
+	// ActionUpdate updates a resource.
+	ActionUpdate Action = "update"
+	// ActionDelete deletes a resource.
+	ActionDelete Action = "delete"
+)
+
+type (
+	// Plan is a list of changes in the order to apply.
+	Plan struct {
+		Changes []Change `json:"changes"`
+
+		state *state
+	}
+
+	// Change is a change of a resource.
+	Change struct {
+		Action Action      `json:"action"`
+		Kind   Kind        `json:"kind"`
+		Key    string      `json:"key"`
+		Fields []FieldDiff `json:"fields,omitempty"`
+
+		apply func(ctx context.Context, st *state) error
+	}
+
+	// FieldDiff is a difference of a field.
+	// Old is nil when the resource is created and New is nil when the resource is deleted.
+	FieldDiff struct {
+		Name string      `json:"name"`
+		Old  interface{} `json:"old"`
+		New  interface{} `json:"new"`
+	}
+)
+
+// Empty returns true if the plan has no change.
+func (plan *Plan) Empty() bool {
+	return len(plan.Changes) == 0
+}
+
+// Filter returns a plan which has only changes of given actions.
+func (plan *Plan) Filter(actions ...Action) *Plan {
+	p := &Plan{Changes: []Change{}, state: plan.state}
+	for _, c := range plan.Changes {
+		for _, a := range actions {
+			if c.Action == a {
+				p.Changes = append(p.Changes, c)
+				break
+			}
+		}
+	}
+	return p
+}
+
+// Summary returns the numbers of the changes per action such as "1 to create, 2 to update, 0 to delete".
+func (plan *Plan) Summary() string {
+	n := map[Action]int{}
+	for _, c := range plan.Changes {
+		n[c.Action]++
+	}
+	return fmt.Sprintf(
+		"%d to create, %d to update, %d to delete",
+		n[ActionCreate], n[ActionUpdate], n[ActionDelete])
+}
+
+// WriteTo writes the human readable diff of the plan.
+// Each change starts with "+" (create), "~" (update) or "-" (delete) and the kind and key,
+// followed by the fields of the created resource or the changed fields as "old => new".
+func (plan *Plan) WriteTo(w io.Writer) (int64, error) {
+	buf := &strings.Builder{}
+	marks := map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}
+	for _, c := range plan.Changes {
+		fmt.Fprintf(buf, "%s %s %q\n", marks[c.Action], c.Kind, c.Key)
+		for _, f := range c.Fields {
+			switch c.Action {
+			case ActionCreate:
+				fmt.Fprintf(buf, "    %s: %s\n", f.Name, formatValue(f.New))
+			case ActionUpdate:
+				fmt.Fprintf(buf, "    %s: %s => %s\n", f.Name, formatValue(f.Old), formatValue(f.New))
+			}
+		}
+	}
+	fmt.Fprintf(buf, "%s\n", plan.Summary())
+	n, err := io.WriteString(w, buf.String())
+	return int64(n), err
+}
+
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// normalize converts a value to the JSON compatible value such as map[string]interface{} and float64,
+// so that values of different types are compared.
+func normalize(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var a interface{}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// diffFields compares fields of the current and desired resources.
+// If current is nil, all fields of desired are returned.
+func diffFields(current, desired map[string]interface{}) ([]FieldDiff, error) {
+	names := make([]string, 0, len(desired))
+	for k := range desired {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	diffs := []FieldDiff{}
+	for _, name := range names {
+		n, err := normalize(desired[name])
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			diffs = append(diffs, FieldDiff{Name: name, New: n})
+			continue
+		}
+		o, err := normalize(current[name])
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(o, n) {
+			diffs = append(diffs, FieldDiff{Name: name, Old: o, New: n})
+		}
+	}
+	return diffs, nil
+}
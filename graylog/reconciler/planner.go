@@ -0,0 +1,91 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-set/v6"
+)
+
+const (
+	allEventsStreamID       = "000000000000000000000002"
+	allSystemEventsStreamID = "000000000000000000000003"
+)
+
+// planner computes the changes of each kind.
+type planner struct {
+	spec    *Spec
+	cur     *current
+	prune   bool
+	changes []Change
+	// deletes are the deletes of each kind in the order of planning.
+	deletes [][]Change
+}
+
+func (pl *planner) add(action Action, kind Kind, key string, fields []FieldDiff, fn func(context.Context, *state) error) {
+	pl.changes = append(pl.changes, Change{
+		Action: action, Kind: kind, Key: key, Fields: fields, apply: fn,
+	})
+}
+
+// addDiff adds a create or update change unless the resource is up to date.
+func (pl *planner) addDiff(
+	kind Kind, key string, exists bool, current, desired map[string]interface{},
+	create, update func(context.Context, *state) error,
+) error {
+	if !exists {
+		fields, err := diffFields(nil, desired)
+		if err != nil {
+			return err
+		}
+		pl.add(ActionCreate, kind, key, fields, create)
+		return nil
+	}
+	fields, err := diffFields(current, desired)
+	if err != nil {
+		return err
+	}
+	if len(fields) != 0 {
+		pl.add(ActionUpdate, kind, key, fields, update)
+	}
+	return nil
+}
+
+// addDeletes adds deletes of resources which aren't desired, if prune is enabled.
+// del returns nil if the resource must not be deleted.
+func (pl *planner) addDeletes(kind Kind, keys []string, desired map[string]struct{}, del func(key string) func(context.Context, *state) error) {
+	changes := []Change{}
+	if pl.prune {
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, ok := desired[key]; ok {
+				continue
+			}
+			fn := del(key)
+			if fn == nil {
+				continue
+			}
+			changes = append(changes, Change{Action: ActionDelete, Kind: kind, Key: key, apply: fn})
+		}
+	}
+	pl.deletes = append(pl.deletes, changes)
+}
+
+// convert converts a value to another type through JSON.
+func convert(src, dst interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+func sortedSet(s set.StrSet) []string {
+	if s == nil {
+		return []string{}
+	}
+	a := s.ToList()
+	sort.Strings(a)
+	return a
+}
@@ -0,0 +1,114 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
+type (
+	// Reconciler computes and applies plans to make a cluster match a spec.
+	Reconciler struct {
+		Client *client.Client
+		// If Prune is true, resources of the kinds in the spec which aren't in the spec are deleted.
+		// Otherwise nothing is deleted.
+		Prune bool
+	}
+
+	// state has the ids of resources by kind and key.
+	// Ids of resources created by the plan are added when they are created,
+	// so that later changes can refer to them.
+	state struct {
+		client *client.Client
+		ids    map[Kind]map[string]string
+	}
+)
+
+func newState(cl *client.Client) *state {
+	return &state{client: cl, ids: map[Kind]map[string]string{}}
+}
+
+func (st *state) set(kind Kind, key, id string) {
+	m, ok := st.ids[kind]
+	if !ok {
+		m = map[string]string{}
+		st.ids[kind] = m
+	}
+	m[key] = id
+}
+
+func (st *state) id(kind Kind, key string) (string, error) {
+	if id, ok := st.ids[kind][key]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("%s '%s' isn't found", kind, key)
+}
+
+// Plan fetches the current state through the client and returns the plan to make the cluster match the spec.
+// Creates and updates are ordered by the dependency, and deletes come last in the reverse order.
+func (r *Reconciler) Plan(ctx context.Context, spec *Spec) (*Plan, error) {
+	if r.Client == nil {
+		return nil, errors.New("client is nil")
+	}
+	if spec == nil {
+		return nil, errors.New("spec is nil")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	cur, err := fetch(ctx, r.Client, spec)
+	if err != nil {
+		return nil, err
+	}
+	st := newState(r.Client)
+	cur.setIDs(st)
+
+	pl := &planner{spec: spec, cur: cur, prune: r.Prune}
+	planners := []struct {
+		kind Kind
+		fn   func() error
+	}{
+		{KindIndexSet, pl.planIndexSets},
+		{KindRole, pl.planRoles},
+		{KindStream, pl.planStreams},
+		{KindStreamRule, pl.planStreamRules},
+		{KindInput, pl.planInputs},
+		{KindPipelineRule, pl.planPipelineRules},
+		{KindPipeline, pl.planPipelines},
+		{KindPipelineConnection, pl.planPipelineConnections},
+		{KindUser, pl.planUsers},
+	}
+	for _, p := range planners {
+		if err := p.fn(); err != nil {
+			return nil, fmt.Errorf("failed to plan %s: %w", p.kind, err)
+		}
+	}
+	plan := &Plan{Changes: pl.changes, state: st}
+	for i := len(pl.deletes) - 1; i >= 0; i-- {
+		plan.Changes = append(plan.Changes, pl.deletes[i]...)
+	}
+	if plan.Changes == nil {
+		plan.Changes = []Change{}
+	}
+	return plan, nil
+}
+
+// Apply applies the plan's changes in order.
+// It stops at the first error, so changes before the failed one remain applied.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) error {
+	if plan == nil {
+		return errors.New("plan is nil")
+	}
+	st := plan.state
+	if st == nil {
+		return errors.New("the plan isn't created by Reconciler.Plan")
+	}
+	for _, c := range plan.Changes {
+		if err := c.apply(ctx, st); err != nil {
+			return fmt.Errorf("failed to %s %s '%s': %w", c.Action, c.Kind, c.Key, err)
+		}
+	}
+	return nil
+}
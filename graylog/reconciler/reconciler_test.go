@@ -0,0 +1,139 @@
+package reconciler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/reconciler"
+)
+
+func newTestClient(t *testing.T) *client.Client {
+	cl, err := client.NewClient("http://example.com/api", "admin", "admin")
+	require.Nil(t, err)
+	route := func(method, path string, status int, body string) flute.Route {
+		return flute.Route{
+			Matcher:  &flute.Matcher{Method: method, Path: path},
+			Response: &flute.Response{Base: http.Response{StatusCode: status}, BodyString: body},
+		}
+	}
+	cl.SetHTTPClient(&http.Client{
+		Transport: &flute.Transport{
+			T: t,
+			Services: []flute.Service{
+				{
+					Endpoint: "http://example.com",
+					Routes: []flute.Route{
+						route("GET", "/api/system/indices/index_sets", 200, `{
+  "total": 1,
+  "index_sets": [{"id": "is1", "title": "Default", "index_prefix": "graylog", "default": true}],
+  "stats": {}
+}`),
+						route("GET", "/api/streams", 200, `{
+  "total": 2,
+  "streams": [
+    {"id": "000000000000000000000001", "title": "All messages", "index_set_id": "is1", "is_default": true},
+    {
+      "id": "s1", "title": "app", "index_set_id": "is1", "description": "old", "matching_type": "AND",
+      "rules": [{"id": "r0", "stream_id": "s1", "field": "tag", "value": "old", "type": 1, "inverted": false}]
+    }
+  ]
+}`),
+						route("GET", "/api/roles", 200, `{
+  "total": 2,
+  "roles": [
+    {"name": "Admin", "permissions": ["*"], "read_only": true},
+    {"name": "old-role", "permissions": ["streams:read"], "read_only": false}
+  ]
+}`),
+						route("POST", "/api/roles", 201, `{"name": "viewer", "permissions": ["streams:read"]}`),
+						route("PUT", "/api/streams/s1", 200, `{"id": "s1", "title": "app"}`),
+						route("POST", "/api/streams", 201, `{"stream_id": "s2"}`),
+						route("POST", "/api/streams/s2/resume", 204, ""),
+						route("POST", "/api/streams/s1/rules", 201, `{"streamrule_id": "r1"}`),
+						route("DELETE", "/api/streams/s1/rules/r0", 204, ""),
+						route("DELETE", "/api/roles/old-role", 204, ""),
+					},
+				},
+			},
+		},
+	})
+	return cl
+}
+
+const testSpec = `
+streams:
+- title: app
+  description: new
+  index_set: Default
+  rules:
+  - field: tag
+    value: app
+- title: web
+  index_set: Default
+roles:
+- name: viewer
+  description: viewer
+  permissions: ["streams:read"]
+`
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+	spec, err := reconciler.ParseSpec([]byte(testSpec))
+	require.Nil(t, err)
+
+	r := &reconciler.Reconciler{Client: newTestClient(t)}
+	plan, err := r.Plan(ctx, spec)
+	require.Nil(t, err)
+	// nothing is deleted without prune
+	require.Empty(t, plan.Filter(reconciler.ActionDelete).Changes)
+
+	r.Prune = true
+	plan, err = r.Plan(ctx, spec)
+	require.Nil(t, err)
+	type change struct {
+		action reconciler.Action
+		kind   reconciler.Kind
+		key    string
+	}
+	changes := make([]change, len(plan.Changes))
+	for i, c := range plan.Changes {
+		changes[i] = change{c.Action, c.Kind, c.Key}
+	}
+	require.Equal(t, []change{
+		{reconciler.ActionCreate, reconciler.KindRole, "viewer"},
+		{reconciler.ActionUpdate, reconciler.KindStream, "app"},
+		{reconciler.ActionCreate, reconciler.KindStream, "web"},
+		{reconciler.ActionCreate, reconciler.KindStreamRule, "app/tag 1 app"},
+		{reconciler.ActionDelete, reconciler.KindStreamRule, "app/tag 1 old"},
+		{reconciler.ActionDelete, reconciler.KindRole, "old-role"},
+	}, changes)
+	require.Equal(t, []reconciler.FieldDiff{
+		{Name: "description", Old: "old", New: "new"},
+	}, plan.Changes[1].Fields)
+
+	buf := &bytes.Buffer{}
+	_, err = plan.WriteTo(buf)
+	require.Nil(t, err)
+	require.Contains(t, buf.String(), `~ stream "app"
+    description: "old" => "new"
+`)
+	require.Contains(t, buf.String(), `- role "old-role"
+`)
+	require.Contains(t, buf.String(), "3 to create, 1 to update, 2 to delete\n")
+
+	require.Nil(t, r.Apply(ctx, plan))
+}
+
+func TestReconciler_Plan_unknownIndexSet(t *testing.T) {
+	spec, err := reconciler.ParseSpec([]byte(`streams: [{title: foo, index_set: unknown}]`))
+	require.Nil(t, err)
+	r := &reconciler.Reconciler{Client: newTestClient(t)}
+	_, err = r.Plan(context.Background(), spec)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,298 @@
+package reconciler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Spec is the desired state of a cluster.
+	// A nil list means the kind isn't managed, and an empty list means all resources of the kind should be deleted.
+	Spec struct {
+		IndexSets     []IndexSetSpec     `json:"index_sets"`
+		Streams       []StreamSpec       `json:"streams"`
+		Inputs        []InputSpec        `json:"inputs"`
+		PipelineRules []PipelineRuleSpec `json:"pipeline_rules"`
+		Pipelines     []PipelineSpec     `json:"pipelines"`
+		Roles         []RoleSpec         `json:"roles"`
+		Users         []UserSpec         `json:"users"`
+	}
+
+	// IndexSetSpec is the spec of an index set.
+	IndexSetSpec struct {
+		Title                           string                 `json:"title"`
+		Description                     string                 `json:"description"`
+		IndexPrefix                     string                 `json:"index_prefix"`
+		Shards                          int                    `json:"shards"`
+		Replicas                        int                    `json:"replicas"`
+		RotationStrategyClass           string                 `json:"rotation_strategy_class"`
+		RotationStrategy                map[string]interface{} `json:"rotation_strategy"`
+		RetentionStrategyClass          string                 `json:"retention_strategy_class"`
+		RetentionStrategy               map[string]interface{} `json:"retention_strategy"`
+		IndexAnalyzer                   string                 `json:"index_analyzer"`
+		IndexOptimizationMaxNumSegments int                    `json:"index_optimization_max_num_segments"`
+		IndexOptimizationDisabled       bool                   `json:"index_optimization_disabled"`
+		FieldTypeRefreshInterval        int                    `json:"field_type_refresh_interval"`
+		// Writable is true by default.
+		Writable *bool `json:"writable"`
+	}
+
+	// StreamSpec is the spec of a stream.
+	StreamSpec struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		// IndexSet is the title of the index set.
+		IndexSet                       string `json:"index_set"`
+		MatchingType                   string `json:"matching_type"`
+		RemoveMatchesFromDefaultStream bool   `json:"remove_matches_from_default_stream"`
+		Disabled                       bool   `json:"disabled"`
+		// If Rules is nil, the stream's rules aren't managed.
+		Rules []StreamRuleSpec `json:"rules"`
+		// Pipelines are the titles of the pipelines connected to the stream.
+		// If Pipelines is nil, the connection isn't managed.
+		Pipelines []string `json:"pipelines"`
+	}
+
+	// StreamRuleSpec is the spec of a stream rule.
+	StreamRuleSpec struct {
+		Field       string `json:"field"`
+		Value       string `json:"value"`
+		Type        int    `json:"type"`
+		Inverted    bool   `json:"inverted"`
+		Description string `json:"description"`
+	}
+
+	// InputSpec is the spec of an input.
+	// Only the attributes in the spec are compared, because Graylog adds default attributes.
+	// Node is compared only if it is set.
+	InputSpec struct {
+		Title      string                 `json:"title"`
+		Type       string                 `json:"type"`
+		Global     bool                   `json:"global"`
+		Node       string                 `json:"node"`
+		Attributes map[string]interface{} `json:"attributes"`
+	}
+
+	// PipelineRuleSpec is the spec of a pipeline rule. The title is the rule name in the source.
+	PipelineRuleSpec struct {
+		Description string `json:"description"`
+		Source      string `json:"source"`
+	}
+
+	// PipelineSpec is the spec of a pipeline. The title is the pipeline name in the source.
+	PipelineSpec struct {
+		Description string `json:"description"`
+		Source      string `json:"source"`
+	}
+
+	// RoleSpec is the spec of a role.
+	RoleSpec struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Permissions []string `json:"permissions"`
+	}
+
+	// UserSpec is the spec of a user.
+	// Password is used only when the user is created, because Graylog doesn't return the password.
+	// Timezone and SessionTimeoutMs are compared only if they are set.
+	UserSpec struct {
+		Username         string   `json:"username"`
+		Email            string   `json:"email"`
+		FullName         string   `json:"full_name"`
+		Password         string   `json:"password"`
+		Roles            []string `json:"roles"`
+		Permissions      []string `json:"permissions"`
+		Timezone         string   `json:"timezone"`
+		SessionTimeoutMs int      `json:"session_timeout_ms"`
+	}
+)
+
+var (
+	pipelineRuleNamePattern = regexp.MustCompile(`^\s*rule\s+"((?:[^"\\]|\\.)*)"`)
+	pipelineNamePattern     = regexp.MustCompile(`^\s*pipeline\s+"((?:[^"\\]|\\.)*)"`)
+)
+
+// ReadSpecFile reads a spec from a YAML or JSON file.
+func ReadSpecFile(path string) (*Spec, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := ParseSpec(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ParseSpec parses a spec in YAML or JSON, sets default values and validates it.
+// YAML is converted to JSON, so the keys are the same as JSON.
+func ParseSpec(b []byte) (*Spec, error) {
+	var a interface{}
+	if err := yaml.Unmarshal(b, &a); err != nil {
+		return nil, err
+	}
+	j, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err := json.Unmarshal(j, spec); err != nil {
+		return nil, err
+	}
+	spec.setDefaults()
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (spec *Spec) setDefaults() {
+	for i := range spec.IndexSets {
+		is := &spec.IndexSets[i]
+		if is.Shards == 0 {
+			is.Shards = 4
+		}
+		if is.IndexAnalyzer == "" {
+			is.IndexAnalyzer = "standard"
+		}
+		if is.IndexOptimizationMaxNumSegments == 0 {
+			is.IndexOptimizationMaxNumSegments = 1
+		}
+		if is.FieldTypeRefreshInterval == 0 {
+			is.FieldTypeRefreshInterval = 5000
+		}
+		if is.Writable == nil {
+			t := true
+			is.Writable = &t
+		}
+	}
+	for i := range spec.Streams {
+		stream := &spec.Streams[i]
+		if stream.MatchingType == "" {
+			stream.MatchingType = "AND"
+		}
+		for j := range stream.Rules {
+			if stream.Rules[j].Type == 0 {
+				stream.Rules[j].Type = 1
+			}
+		}
+	}
+}
+
+// pipelineRuleTitle returns the rule name in the source.
+func pipelineRuleTitle(source string) (string, error) {
+	a := pipelineRuleNamePattern.FindStringSubmatch(source)
+	if a == nil {
+		return "", errors.New(`the source of the pipeline rule must start with 'rule "<name>"'`)
+	}
+	return strconv.Unquote(`"` + a[1] + `"`)
+}
+
+// pipelineTitle returns the pipeline name in the source.
+func pipelineTitle(source string) (string, error) {
+	a := pipelineNamePattern.FindStringSubmatch(source)
+	if a == nil {
+		return "", errors.New(`the source of the pipeline must start with 'pipeline "<name>"'`)
+	}
+	return strconv.Unquote(`"` + a[1] + `"`)
+}
+
+// Validate checks required fields, duplicated keys and references in the spec.
+func (spec *Spec) Validate() error {
+	keys := map[string]struct{}{}
+	unique := func(kind Kind, key string) error {
+		if key == "" {
+			return fmt.Errorf("the key of %s is required", kind)
+		}
+		k := string(kind) + "\x00" + key
+		if _, ok := keys[k]; ok {
+			return fmt.Errorf("%s '%s' is duplicated", kind, key)
+		}
+		keys[k] = struct{}{}
+		return nil
+	}
+	for _, is := range spec.IndexSets {
+		if err := unique(KindIndexSet, is.Title); err != nil {
+			return err
+		}
+		if is.IndexPrefix == "" || is.RotationStrategyClass == "" || is.RetentionStrategyClass == "" ||
+			is.RotationStrategy == nil || is.RetentionStrategy == nil {
+			return fmt.Errorf(
+				"index_prefix, rotation_strategy_class, rotation_strategy, retention_strategy_class and retention_strategy of the index set '%s' are required",
+				is.Title)
+		}
+	}
+	for _, stream := range spec.Streams {
+		if err := unique(KindStream, stream.Title); err != nil {
+			return err
+		}
+		if stream.IndexSet == "" {
+			return fmt.Errorf("index_set of the stream '%s' is required", stream.Title)
+		}
+		for _, rule := range stream.Rules {
+			if err := unique(KindStreamRule, stream.Title+"/"+rule.key()); err != nil {
+				return err
+			}
+			if rule.Field == "" {
+				return fmt.Errorf("field of the rule of the stream '%s' is required", stream.Title)
+			}
+		}
+	}
+	for _, input := range spec.Inputs {
+		if err := unique(KindInput, input.Title); err != nil {
+			return err
+		}
+		if input.Type == "" {
+			return fmt.Errorf("type of the input '%s' is required", input.Title)
+		}
+	}
+	for _, rule := range spec.PipelineRules {
+		title, err := pipelineRuleTitle(rule.Source)
+		if err != nil {
+			return err
+		}
+		if err := unique(KindPipelineRule, title); err != nil {
+			return err
+		}
+	}
+	for _, pipe := range spec.Pipelines {
+		title, err := pipelineTitle(pipe.Source)
+		if err != nil {
+			return err
+		}
+		if err := unique(KindPipeline, title); err != nil {
+			return err
+		}
+	}
+	for _, role := range spec.Roles {
+		if err := unique(KindRole, role.Name); err != nil {
+			return err
+		}
+	}
+	for _, user := range spec.Users {
+		if err := unique(KindUser, user.Username); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// key returns the natural key of the stream rule.
+func (rule *StreamRuleSpec) key() string {
+	return streamRuleKey(rule.Field, rule.Type, rule.Value, rule.Inverted)
+}
+
+func streamRuleKey(field string, typ int, value string, inverted bool) string {
+	k := fmt.Sprintf("%s %d %s", field, typ, value)
+	if inverted {
+		return "not " + k
+	}
+	return k
+}
@@ -0,0 +1,80 @@
+package reconciler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/reconciler"
+)
+
+func TestParseSpec(t *testing.T) {
+	spec, err := reconciler.ParseSpec([]byte(`
+index_sets:
+- title: app
+  index_prefix: app
+  rotation_strategy_class: org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy
+  rotation_strategy:
+    type: org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig
+    max_docs_per_index: 20000000
+  retention_strategy_class: org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy
+  retention_strategy:
+    type: org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig
+    max_number_of_indices: 20
+streams:
+- title: app
+  index_set: app
+  rules:
+  - field: tag
+    value: app
+pipeline_rules:
+- source: |
+    rule "foo"
+    when true
+    then
+    end
+`))
+	require.Nil(t, err)
+	require.Nil(t, spec.Roles)
+	require.Equal(t, 4, spec.IndexSets[0].Shards)
+	require.True(t, *spec.IndexSets[0].Writable)
+	require.Equal(t, "AND", spec.Streams[0].MatchingType)
+	require.Nil(t, spec.Streams[0].Pipelines)
+	require.Equal(t, 1, spec.Streams[0].Rules[0].Type)
+
+	// JSON is also accepted
+	spec, err = reconciler.ParseSpec([]byte(`{"roles": []}`))
+	require.Nil(t, err)
+	require.NotNil(t, spec.Roles)
+	require.Empty(t, spec.Roles)
+}
+
+func TestParseSpec_invalid(t *testing.T) {
+	data := []struct {
+		title string
+		spec  string
+	}{
+		{
+			title: "duplicated role",
+			spec:  "roles: [{name: foo}, {name: foo}]",
+		},
+		{
+			title: "stream without index set",
+			spec:  "streams: [{title: foo}]",
+		},
+		{
+			title: "pipeline rule without name",
+			spec:  `pipeline_rules: [{source: "when true then end"}]`,
+		},
+		{
+			title: "user without username",
+			spec:  "users: [{email: foo@example.com}]",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			_, err := reconciler.ParseSpec([]byte(d.spec))
+			require.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+// indexSetTitle returns the title of the index set or the id if the index set isn't found.
+func (pl *planner) indexSetTitle(id string) string {
+	for title, is := range pl.cur.indexSets {
+		if is.ID == id {
+			return title
+		}
+	}
+	return id
+}
+
+// pipelineTitle returns the title of the pipeline or the id if the pipeline isn't found.
+func (pl *planner) pipelineTitle(id string) string {
+	for title, pipe := range pl.cur.pipelines {
+		if pipe.ID == id {
+			return title
+		}
+	}
+	return id
+}
+
+func (pl *planner) hasIndexSet(title string) bool {
+	if _, ok := pl.cur.indexSets[title]; ok {
+		return true
+	}
+	for _, is := range pl.spec.IndexSets {
+		if is.Title == title {
+			return true
+		}
+	}
+	return false
+}
+
+func (pl *planner) hasPipeline(title string) bool {
+	if _, ok := pl.cur.pipelines[title]; ok {
+		return true
+	}
+	for _, pipe := range pl.spec.Pipelines {
+		if t, err := pipelineTitle(pipe.Source); err == nil && t == title {
+			return true
+		}
+	}
+	return false
+}
+
+func isBuiltinStream(stream *graylog.Stream) bool {
+	return stream.IsDefault || stream.ID == allEventsStreamID || stream.ID == allSystemEventsStreamID
+}
+
+func (pl *planner) planStreams() error {
+	if pl.spec.Streams == nil {
+		return nil
+	}
+	desired := make(map[string]struct{}, len(pl.spec.Streams))
+	for i := range pl.spec.Streams {
+		spec := pl.spec.Streams[i]
+		desired[spec.Title] = struct{}{}
+		if !pl.hasIndexSet(spec.IndexSet) {
+			return fmt.Errorf("the index set '%s' of the stream '%s' isn't found", spec.IndexSet, spec.Title)
+		}
+		fields := map[string]interface{}{
+			"description":                        spec.Description,
+			"index_set":                          spec.IndexSet,
+			"matching_type":                      spec.MatchingType,
+			"remove_matches_from_default_stream": spec.RemoveMatchesFromDefaultStream,
+			"disabled":                           spec.Disabled,
+		}
+		cur, exists := pl.cur.streams[spec.Title]
+		var curFields map[string]interface{}
+		if exists {
+			curFields = map[string]interface{}{
+				"description":                        cur.Description,
+				"index_set":                          pl.indexSetTitle(cur.IndexSetID),
+				"matching_type":                      cur.MatchingType,
+				"remove_matches_from_default_stream": cur.RemoveMatchesFromDefaultStream,
+				"disabled":                           cur.Disabled,
+			}
+		}
+		newStream := func(st *state) (*graylog.Stream, error) {
+			isID, err := st.id(KindIndexSet, spec.IndexSet)
+			if err != nil {
+				return nil, err
+			}
+			return &graylog.Stream{
+				Title:                          spec.Title,
+				Description:                    spec.Description,
+				IndexSetID:                     isID,
+				MatchingType:                   spec.MatchingType,
+				RemoveMatchesFromDefaultStream: spec.RemoveMatchesFromDefaultStream,
+			}, nil
+		}
+		err := pl.addDiff(KindStream, spec.Title, exists, curFields, fields,
+			func(ctx context.Context, st *state) error {
+				stream, err := newStream(st)
+				if err != nil {
+					return err
+				}
+				if _, err := st.client.CreateStream(ctx, stream); err != nil {
+					return err
+				}
+				st.set(KindStream, spec.Title, stream.ID)
+				if spec.Disabled {
+					return nil
+				}
+				_, err = st.client.ResumeStream(ctx, stream.ID)
+				return err
+			},
+			func(ctx context.Context, st *state) error {
+				stream, err := newStream(st)
+				if err != nil {
+					return err
+				}
+				stream.ID = cur.ID
+				if stream.Description != cur.Description || stream.IndexSetID != cur.IndexSetID ||
+					stream.MatchingType != cur.MatchingType ||
+					stream.RemoveMatchesFromDefaultStream != cur.RemoveMatchesFromDefaultStream {
+					if _, err := st.client.UpdateStream(ctx, stream); err != nil {
+						return err
+					}
+				}
+				if spec.Disabled == cur.Disabled {
+					return nil
+				}
+				if spec.Disabled {
+					_, err = st.client.PauseStream(ctx, cur.ID)
+					return err
+				}
+				_, err = st.client.ResumeStream(ctx, cur.ID)
+				return err
+			})
+		if err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(pl.cur.streams))
+	for k := range pl.cur.streams {
+		keys = append(keys, k)
+	}
+	pl.addDeletes(KindStream, keys, desired, func(key string) func(context.Context, *state) error {
+		cur := pl.cur.streams[key]
+		if isBuiltinStream(cur) {
+			return nil
+		}
+		return func(ctx context.Context, st *state) error {
+			_, err := st.client.DeleteStream(ctx, cur.ID)
+			return err
+		}
+	})
+	return nil
+}
+
+// planStreamRules plans the rules of streams whose rules are managed.
+// Rules of deleted streams aren't deleted separately, because Graylog deletes them with the streams.
+func (pl *planner) planStreamRules() error {
+	keys := []string{}
+	desired := map[string]struct{}{}
+	curRules := map[string]graylog.StreamRule{}
+	for i := range pl.spec.Streams {
+		stream := pl.spec.Streams[i]
+		if stream.Rules == nil {
+			continue
+		}
+		if cur, ok := pl.cur.streams[stream.Title]; ok {
+			for _, rule := range cur.Rules {
+				key := stream.Title + "/" + streamRuleKey(rule.Field, rule.Type, rule.Value, rule.Inverted)
+				rule.StreamID = cur.ID
+				curRules[key] = rule
+				keys = append(keys, key)
+			}
+		}
+		for j := range stream.Rules {
+			spec := stream.Rules[j]
+			key := stream.Title + "/" + spec.key()
+			desired[key] = struct{}{}
+			cur, exists := curRules[key]
+			fields := map[string]interface{}{
+				"field":       spec.Field,
+				"type":        spec.Type,
+				"value":       spec.Value,
+				"inverted":    spec.Inverted,
+				"description": spec.Description,
+			}
+			curFields := map[string]interface{}{
+				"field":       cur.Field,
+				"type":        cur.Type,
+				"value":       cur.Value,
+				"inverted":    cur.Inverted,
+				"description": cur.Description,
+			}
+			newRule := func(st *state) (*graylog.StreamRule, error) {
+				streamID, err := st.id(KindStream, stream.Title)
+				if err != nil {
+					return nil, err
+				}
+				return &graylog.StreamRule{
+					StreamID:    streamID,
+					Field:       spec.Field,
+					Value:       spec.Value,
+					Type:        spec.Type,
+					Inverted:    spec.Inverted,
+					Description: spec.Description,
+				}, nil
+			}
+			err := pl.addDiff(KindStreamRule, key, exists, curFields, fields,
+				func(ctx context.Context, st *state) error {
+					rule, err := newRule(st)
+					if err != nil {
+						return err
+					}
+					if _, err := st.client.CreateStreamRule(ctx, rule); err != nil {
+						return err
+					}
+					st.set(KindStreamRule, key, rule.ID)
+					return nil
+				},
+				func(ctx context.Context, st *state) error {
+					rule, err := newRule(st)
+					if err != nil {
+						return err
+					}
+					rule.ID = cur.ID
+					_, err = st.client.UpdateStreamRule(ctx, rule)
+					return err
+				})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	pl.addDeletes(KindStreamRule, keys, desired, func(key string) func(context.Context, *state) error {
+		cur := curRules[key]
+		return func(ctx context.Context, st *state) error {
+			_, err := st.client.DeleteStreamRule(ctx, cur.StreamID, cur.ID)
+			return err
+		}
+	})
+	return nil
+}
+
+// planPipelineConnections plans the connections of streams whose pipelines are managed.
+// The pipelines of a stream are replaced with the pipelines in the spec.
+func (pl *planner) planPipelineConnections() error {
+	for i := range pl.spec.Streams {
+		stream := pl.spec.Streams[i]
+		if stream.Pipelines == nil {
+			continue
+		}
+		titles := util.SortedStrings(stream.Pipelines)
+		for _, title := range titles {
+			if !pl.hasPipeline(title) {
+				return fmt.Errorf("the pipeline '%s' of the stream '%s' isn't found", title, stream.Title)
+			}
+		}
+		curTitles := []string{}
+		if cur, ok := pl.cur.streams[stream.Title]; ok {
+			for _, id := range pl.cur.connections[cur.ID] {
+				curTitles = append(curTitles, pl.pipelineTitle(id))
+			}
+			sort.Strings(curTitles)
+		}
+		if len(titles) == 0 && len(curTitles) == 0 {
+			continue
+		}
+		err := pl.addDiff(KindPipelineConnection, stream.Title, len(curTitles) != 0,
+			map[string]interface{}{"pipelines": curTitles},
+			map[string]interface{}{"pipelines": titles},
+			func(ctx context.Context, st *state) error {
+				return connectPipelines(ctx, st, stream.Title, titles)
+			},
+			func(ctx context.Context, st *state) error {
+				return connectPipelines(ctx, st, stream.Title, titles)
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func connectPipelines(ctx context.Context, st *state, streamTitle string, pipelineTitles []string) error {
+	streamID, err := st.id(KindStream, streamTitle)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, len(pipelineTitles))
+	for i, title := range pipelineTitles {
+		id, err := st.id(KindPipeline, title)
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+	}
+	_, err = st.client.ConnectPipelinesToStream(ctx, &graylog.PipelineConnection{
+		StreamID: streamID, PipelineIDs: ids,
+	})
+	return err
+}
@@ -0,0 +1,173 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-set/v6"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+func (pl *planner) planRoles() error {
+	if pl.spec.Roles == nil {
+		return nil
+	}
+	desired := make(map[string]struct{}, len(pl.spec.Roles))
+	for i := range pl.spec.Roles {
+		spec := pl.spec.Roles[i]
+		desired[spec.Name] = struct{}{}
+		fields := map[string]interface{}{
+			"description": spec.Description,
+			"permissions": util.SortedStrings(spec.Permissions),
+		}
+		cur, exists := pl.cur.roles[spec.Name]
+		var curFields map[string]interface{}
+		if exists {
+			curFields = map[string]interface{}{
+				"description": cur.Description,
+				"permissions": sortedSet(cur.Permissions),
+			}
+		}
+		err := pl.addDiff(KindRole, spec.Name, exists, curFields, fields,
+			func(ctx context.Context, st *state) error {
+				_, err := st.client.CreateRole(ctx, &graylog.Role{
+					Name:        spec.Name,
+					Description: spec.Description,
+					Permissions: set.NewStrSet(spec.Permissions...),
+				})
+				return err
+			},
+			func(ctx context.Context, st *state) error {
+				if cur.ReadOnly {
+					return fmt.Errorf("the role '%s' is read only", spec.Name)
+				}
+				_, _, err := st.client.UpdateRole(ctx, spec.Name, &graylog.RoleUpdateParams{
+					Name:        spec.Name,
+					Description: &spec.Description,
+					Permissions: set.NewStrSet(spec.Permissions...),
+				})
+				return err
+			})
+		if err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(pl.cur.roles))
+	for k := range pl.cur.roles {
+		keys = append(keys, k)
+	}
+	pl.addDeletes(KindRole, keys, desired, func(key string) func(context.Context, *state) error {
+		if pl.cur.roles[key].ReadOnly {
+			return nil
+		}
+		return func(ctx context.Context, st *state) error {
+			_, err := st.client.DeleteRole(ctx, key)
+			return err
+		}
+	})
+	return nil
+}
+
+func userSpecFields(user *UserSpec) map[string]interface{} {
+	fields := map[string]interface{}{
+		"email":       user.Email,
+		"full_name":   user.FullName,
+		"roles":       util.SortedStrings(user.Roles),
+		"permissions": util.SortedStrings(user.Permissions),
+	}
+	if user.Timezone != "" {
+		fields["timezone"] = user.Timezone
+	}
+	if user.SessionTimeoutMs != 0 {
+		fields["session_timeout_ms"] = user.SessionTimeoutMs
+	}
+	return fields
+}
+
+func userFields(user *graylog.User) map[string]interface{} {
+	return map[string]interface{}{
+		"email":              user.Email,
+		"full_name":          user.FullName,
+		"roles":              sortedSet(user.Roles),
+		"permissions":        sortedSet(user.Permissions),
+		"timezone":           user.Timezone,
+		"session_timeout_ms": user.SessionTimeoutMs,
+	}
+}
+
+func (pl *planner) planUsers() error {
+	if pl.spec.Users == nil {
+		return nil
+	}
+	desired := make(map[string]struct{}, len(pl.spec.Users))
+	for i := range pl.spec.Users {
+		spec := pl.spec.Users[i]
+		desired[spec.Username] = struct{}{}
+		cur, exists := pl.cur.users[spec.Username]
+		if !exists && spec.Password == "" {
+			return fmt.Errorf("password of the user '%s' is required to create the user", spec.Username)
+		}
+		var curFields map[string]interface{}
+		if exists {
+			curFields = userFields(cur)
+		}
+		err := pl.addDiff(KindUser, spec.Username, exists, curFields, userSpecFields(&spec),
+			func(ctx context.Context, st *state) error {
+				user := &graylog.User{
+					Username:         spec.Username,
+					Email:            spec.Email,
+					FullName:         spec.FullName,
+					Password:         spec.Password,
+					Timezone:         spec.Timezone,
+					SessionTimeoutMs: spec.SessionTimeoutMs,
+					Roles:            set.NewStrSet(spec.Roles...),
+					Permissions:      set.NewStrSet(spec.Permissions...),
+				}
+				if _, err := st.client.CreateUser(ctx, user); err != nil {
+					return err
+				}
+				st.set(KindUser, spec.Username, user.ID)
+				return nil
+			},
+			func(ctx context.Context, st *state) error {
+				if cur.ReadOnly {
+					return fmt.Errorf("the user '%s' is read only", spec.Username)
+				}
+				prms := &graylog.UserUpdateParams{
+					Username:    spec.Username,
+					Email:       &spec.Email,
+					FullName:    &spec.FullName,
+					Roles:       set.NewStrSet(spec.Roles...),
+					Permissions: set.NewStrSet(spec.Permissions...),
+				}
+				if spec.Timezone != "" {
+					prms.Timezone = &spec.Timezone
+				}
+				if spec.SessionTimeoutMs != 0 {
+					prms.SessionTimeoutMs = &spec.SessionTimeoutMs
+				}
+				_, err := st.client.UpdateUser(ctx, prms)
+				return err
+			})
+		if err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(pl.cur.users))
+	for k := range pl.cur.users {
+		keys = append(keys, k)
+	}
+	pl.addDeletes(KindUser, keys, desired, func(key string) func(context.Context, *state) error {
+		cur := pl.cur.users[key]
+		if cur.ReadOnly || cur.External {
+			return nil
+		}
+		return func(ctx context.Context, st *state) error {
+			_, err := st.client.DeleteUser(ctx, key)
+			return err
+		}
+	})
+	return nil
+}
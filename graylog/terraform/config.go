@@ -1,14 +1,70 @@
 package terraform
 
+import (
+	"errors"
+	"sync"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
 // Config represents terraform provider's configuration.
+// MaxConcurrency and RequestsPerSecond limit requests of all resources. 0 means no limit.
 type Config struct {
-	Endpoint     string
-	AuthName     string
-	AuthPassword string
-	XRequestedBy string
-	APIVersion   string
+	Endpoint          string
+	AuthName          string
+	AuthPassword      string
+	XRequestedBy      string
+	APIVersion        string
+	MaxConcurrency    int
+	RequestsPerSecond float64
+
+	client    *client.Client
+	clientErr error
+	once      sync.Once
+
+	streamOwners streamOwners
 }
 
 func (c *Config) loadAndValidate() error {
+	if c.MaxConcurrency < 0 {
+		return errors.New("max_concurrency must not be negative")
+	}
+	if c.RequestsPerSecond < 0 {
+		return errors.New("requests_per_second must not be negative")
+	}
 	return nil
 }
+
+// NewClient returns a new client with the configuration.
+func (c *Config) NewClient() (*client.Client, error) {
+	var (
+		cl  *client.Client
+		err error
+	)
+	if c.APIVersion == "v3" {
+		cl, err = client.NewClientV3(
+			c.Endpoint, c.AuthName, c.AuthPassword)
+	} else {
+		cl, err = client.NewClient(
+			c.Endpoint, c.AuthName, c.AuthPassword)
+	}
+	if err != nil {
+		return cl, err
+	}
+	if c.XRequestedBy != "" {
+		cl.SetXRequestedBy(c.XRequestedBy)
+	}
+	cl.SetMaxConcurrency(c.MaxConcurrency)
+	cl.SetRateLimit(c.RequestsPerSecond, c.MaxConcurrency)
+	return cl, nil
+}
+
+// Client returns the client shared by resources and data sources,
+// so that the limits of the concurrency and the rate are shared.
+// The client is created at the first call.
+func (c *Config) Client() (*client.Client, error) {
+	c.once.Do(func() {
+		c.client, c.clientErr = c.NewClient()
+	})
+	return c.client, c.clientErr
+}
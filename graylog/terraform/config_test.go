@@ -0,0 +1,20 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Client(t *testing.T) {
+	cfg := &Config{Endpoint: "http://example.com/api", AuthName: "admin", AuthPassword: "admin", MaxConcurrency: 2}
+	require.Nil(t, cfg.loadAndValidate())
+	cl1, err := cfg.Client()
+	require.Nil(t, err)
+	cl2, err := cfg.Client()
+	require.Nil(t, err)
+	require.True(t, cl1 == cl2, "the client is shared")
+
+	require.NotNil(t, (&Config{MaxConcurrency: -1}).loadAndValidate())
+	require.NotNil(t, (&Config{RequestsPerSecond: -1}).loadAndValidate())
+}
@@ -4,14 +4,14 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func dataSourceDashboard() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceDashboardRead,
+		ReadContext: wrapCRUD(dataSourceDashboardRead),
 
 		Schema: map[string]*schema.Schema{
 			"dashboard_id": {
@@ -38,8 +38,7 @@ func dataSourceDashboard() *schema.Resource {
 	}
 }
 
-func dataSourceDashboardRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func dataSourceDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -5,14 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func dataSourceIndexSet() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceIndexSetRead,
+		ReadContext: wrapCRUD(dataSourceIndexSetRead),
 
 		Schema: map[string]*schema.Schema{
 			"index_set_id": {
@@ -218,8 +218,7 @@ func setIndexSet(d *schema.ResourceData, is *graylog.IndexSet, cfg *Config) erro
 	return setBoolToRD(d, "default", is.Default)
 }
 
-func dataSourceIndexSetRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func dataSourceIndexSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
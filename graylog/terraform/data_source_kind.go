@@ -0,0 +1,216 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
+type (
+	// dataSourceKind describes a kind of objects such as inputs,
+	// which is read by a singular data source such as graylog_input and a plural data source such as graylog_inputs.
+	dataSourceKind struct {
+		// name is used in error messages such as "input".
+		name string
+		// idKey is the attribute of the id such as "input_id".
+		// If the kind doesn't have the id such as roles, idKey is empty.
+		idKey string
+		// titleKey is the attribute to look up an object such as "title".
+		titleKey string
+		// filterKeys are the attributes which narrow down objects with the same title such as "type" of inputs.
+		filterKeys []string
+		// resource returns the resource of the kind, whose schema is used as the computed attributes.
+		resource func() *schema.Resource
+		list     func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error)
+	}
+
+	// dataSourceItem is an object of a data source.
+	dataSourceItem struct {
+		id    string
+		title string
+		// set sets the object's attributes to ResourceData.
+		set func(d *schema.ResourceData) error
+	}
+)
+
+// computedSchema converts a resource schema to the computed schema of a data source.
+// Sensitive attributes are removed.
+func computedSchema(src map[string]*schema.Schema) map[string]*schema.Schema {
+	dest := make(map[string]*schema.Schema, len(src))
+	for k, s := range src {
+		if s.Sensitive {
+			continue
+		}
+		a := &schema.Schema{
+			Type:     s.Type,
+			Computed: true,
+			Elem:     s.Elem,
+			Set:      s.Set,
+		}
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			a.Elem = &schema.Resource{Schema: computedSchema(r.Schema)}
+		}
+		dest[k] = a
+	}
+	return dest
+}
+
+// itemSchema returns the computed attributes of an object.
+func (kind *dataSourceKind) itemSchema() map[string]*schema.Schema {
+	sch := computedSchema(kind.resource().Schema)
+	for _, k := range []string{kind.idKey, kind.titleKey} {
+		if k == "" {
+			continue
+		}
+		if _, ok := sch[k]; !ok {
+			sch[k] = &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			}
+		}
+	}
+	return sch
+}
+
+// setItem sets an object and its id and title to ResourceData.
+func (kind *dataSourceKind) setItem(d *schema.ResourceData, item *dataSourceItem) error {
+	if err := item.set(d); err != nil {
+		return err
+	}
+	d.SetId(item.id)
+	if kind.idKey != "" {
+		if err := setStrToRD(d, kind.idKey, item.id); err != nil {
+			return err
+		}
+	}
+	return setStrToRD(d, kind.titleKey, item.title)
+}
+
+// itemData returns ResourceData which has an object's attributes,
+// which is used to compare the attributes and convert an object to a map.
+func (kind *dataSourceKind) itemData(sch map[string]*schema.Schema, item *dataSourceItem) (*schema.ResourceData, error) {
+	d := (&schema.Resource{Schema: sch}).Data(nil)
+	if err := kind.setItem(d, item); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// singular returns the singular data source of the kind.
+func (kind *dataSourceKind) singular() *schema.Resource {
+	sch := kind.itemSchema()
+	keys := append([]string{kind.titleKey}, kind.filterKeys...)
+	if kind.idKey != "" {
+		keys = append(keys, kind.idKey)
+	}
+	for _, k := range keys {
+		sch[k].Optional = true
+	}
+	return &schema.Resource{
+		ReadContext: wrapCRUD(kind.readSingular),
+		Schema:      sch,
+	}
+}
+
+func (kind *dataSourceKind) readSingular(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+
+	id, hasID := "", false
+	if kind.idKey != "" {
+		if v, ok := d.GetOk(kind.idKey); ok {
+			id, hasID = v.(string), true
+		}
+	}
+	title, hasTitle := d.GetOk(kind.titleKey)
+	switch {
+	case hasID && hasTitle:
+		return fmt.Errorf("only one of %s or %s must be set", kind.idKey, kind.titleKey)
+	case !hasID && !hasTitle:
+		if kind.idKey == "" {
+			return fmt.Errorf("%s must be set", kind.titleKey)
+		}
+		return fmt.Errorf("one of %s or %s must be set", kind.idKey, kind.titleKey)
+	}
+
+	items, err := kind.list(ctx, cl)
+	if err != nil {
+		return err
+	}
+	if hasID {
+		for i := range items {
+			if items[i].id == id {
+				return kind.setItem(d, &items[i])
+			}
+		}
+		return fmt.Errorf("matched %s is not found", kind.name)
+	}
+
+	keys := []string{kind.titleKey}
+	filters := map[string]interface{}{}
+	for _, k := range kind.filterKeys {
+		if v, ok := d.GetOk(k); ok {
+			filters[k] = v
+			keys = append(keys, k)
+		}
+	}
+	sch := kind.itemSchema()
+	matched := []int{}
+	for i := range items {
+		if items[i].title != title.(string) {
+			continue
+		}
+		f, err := matchItemFilters(kind, sch, &items[i], filters)
+		if err != nil {
+			return err
+		}
+		if f {
+			matched = append(matched, i)
+		}
+	}
+	i, err := findUnique(kind.name, strings.Join(keys, " and "), matched)
+	if err != nil {
+		return err
+	}
+	return kind.setItem(d, &items[i])
+}
+
+func matchItemFilters(
+	kind *dataSourceKind, sch map[string]*schema.Schema, item *dataSourceItem, filters map[string]interface{},
+) (bool, error) {
+	if len(filters) == 0 {
+		return true, nil
+	}
+	data, err := kind.itemData(sch, item)
+	if err != nil {
+		return false, err
+	}
+	for k, v := range filters {
+		if data.Get(k) != v {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// findUnique returns the only one matched index.
+// It returns an error if no object matches or multiple objects match, like the data source graylog_stream.
+func findUnique(name, key string, matched []int) (int, error) {
+	switch len(matched) {
+	case 0:
+		return 0, errors.New("matched " + name + " is not found")
+	case 1:
+		return matched[0], nil
+	}
+	if strings.Contains(key, " and ") {
+		return 0, errors.New("the combination of " + key + " isn't unique")
+	}
+	return 0, errors.New(key + " isn't unique")
+}
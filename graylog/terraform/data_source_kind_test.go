@@ -0,0 +1,368 @@
+package terraform
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+)
+
+func TestFindUnique(t *testing.T) {
+	_, err := findUnique("input", "title", []int{})
+	require.EqualError(t, err, "matched input is not found")
+	i, err := findUnique("input", "title", []int{2})
+	require.Nil(t, err)
+	require.Equal(t, 2, i)
+	_, err = findUnique("input", "title", []int{0, 2})
+	require.EqualError(t, err, "title isn't unique")
+	_, err = findUnique("input", "title and type", []int{0, 2})
+	require.EqualError(t, err, "the combination of title and type isn't unique")
+}
+
+func TestMatchListFilter(t *testing.T) {
+	require.True(t, matchListFilter("foo", []string{"bar", "foo"}))
+	require.False(t, matchListFilter("foo", []string{"bar"}))
+	require.True(t, matchListFilter(true, []string{"true"}))
+	require.True(t, matchListFilter(10, []string{"10"}))
+	require.True(t, matchListFilter([]interface{}{"Admin", "Reader"}, []string{"Reader"}))
+	require.True(t, matchListFilter(schema.NewSet(schema.HashString, []interface{}{"Admin"}), []string{"Admin"}))
+	require.False(t, matchListFilter(schema.NewSet(schema.HashString, []interface{}{}), []string{"Admin"}))
+}
+
+const testInputsBody = `{
+  "inputs": [
+    {
+      "id": "000000000000000000000001",
+      "title": "gelf",
+      "type": "org.graylog2.inputs.gelf.udp.GELFUDPInput",
+      "global": true,
+      "attributes": {"bind_address": "0.0.0.0", "port": 12201}
+    },
+    {
+      "id": "000000000000000000000002",
+      "title": "gelf",
+      "type": "org.graylog2.inputs.gelf.tcp.GELFTCPInput",
+      "global": true,
+      "attributes": {"bind_address": "0.0.0.0", "port": 12201}
+    },
+    {
+      "id": "000000000000000000000003",
+      "title": "syslog",
+      "type": "org.graylog2.inputs.syslog.udp.SyslogUDPInput",
+      "global": false,
+      "attributes": {"bind_address": "0.0.0.0", "port": 514}
+    }
+  ],
+  "total": 3
+}`
+
+func setTestInputsTransport(t *testing.T) func() {
+	defaultTransport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = &flute.Transport{
+		T: t,
+		Services: []flute.Service{
+			{
+				Endpoint: "http://example.com",
+				Routes: []flute.Route{
+					{
+						Name: "Get inputs",
+						Matcher: &flute.Matcher{
+							Method: "GET",
+							Path:   "/api/system/inputs",
+						},
+						Tester: &flute.Tester{
+							PartOfHeader: getTestHeader(),
+						},
+						Response: &flute.Response{
+							Base: http.Response{
+								StatusCode: 200,
+							},
+							BodyString: testInputsBody,
+						},
+					},
+				},
+			},
+		},
+	}
+	return func() {
+		http.DefaultClient.Transport = defaultTransport
+	}
+}
+
+func TestAccDataSourceInput(t *testing.T) {
+	setEnv()
+	defer setTestInputsTransport(t)()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "graylog_input" "test" {
+  title = "gelf"
+  type  = "org.graylog2.inputs.gelf.tcp.GELFTCPInput"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_input.test", "input_id", "000000000000000000000002"),
+					resource.TestCheckResourceAttr("data.graylog_input.test", "global", "true"),
+					resource.TestCheckResourceAttr("data.graylog_input.test", "attributes.0.port", "12201"),
+				),
+			},
+			{
+				Config: `
+data "graylog_input" "test" {
+  input_id = "000000000000000000000003"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.graylog_input.test", "title", "syslog"),
+			},
+			{
+				Config: `
+data "graylog_input" "test" {
+  title = "gelf"
+}
+`,
+				ExpectError: regexp.MustCompile(`title isn't unique`),
+			},
+			{
+				Config: `
+data "graylog_input" "test" {
+  title = "beats"
+}
+`,
+				ExpectError: regexp.MustCompile(`matched input is not found`),
+			},
+			{
+				Config: `
+data "graylog_input" "test" {
+  input_id = "000000000000000000000003"
+  title    = "syslog"
+}
+`,
+				ExpectError: regexp.MustCompile(`only one of input_id or title must be set`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceInputs(t *testing.T) {
+	setEnv()
+	defer setTestInputsTransport(t)()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "graylog_inputs" "test" {
+  title_regex = "^gelf$"
+  filter {
+    name   = "type"
+    values = ["org.graylog2.inputs.gelf.tcp.GELFTCPInput", "org.graylog2.inputs.syslog.udp.SyslogUDPInput"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_inputs.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_inputs.test", "ids.0", "000000000000000000000002"),
+					resource.TestCheckResourceAttr("data.graylog_inputs.test", "inputs.0.title", "gelf"),
+					resource.TestCheckResourceAttr("data.graylog_inputs.test", "inputs.0.attributes.0.port", "12201"),
+				),
+			},
+			{
+				Config: `
+data "graylog_inputs" "test" {
+  filter {
+    name   = "global"
+    values = ["false"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_inputs.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_inputs.test", "inputs.0.input_id", "000000000000000000000003"),
+				),
+			},
+			{
+				Config: `
+data "graylog_inputs" "test" {
+  filter {
+    name   = "port"
+    values = ["514"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`input doesn't have the attribute port`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRole(t *testing.T) {
+	setEnv()
+	defaultTransport := http.DefaultClient.Transport
+	defer func() {
+		http.DefaultClient.Transport = defaultTransport
+	}()
+	http.DefaultClient.Transport = &flute.Transport{
+		T: t,
+		Services: []flute.Service{
+			{
+				Endpoint: "http://example.com",
+				Routes: []flute.Route{
+					{
+						Name: "Get roles",
+						Matcher: &flute.Matcher{
+							Method: "GET",
+							Path:   "/api/roles",
+						},
+						Tester: &flute.Tester{
+							PartOfHeader: getTestHeader(),
+						},
+						Response: &flute.Response{
+							Base: http.Response{
+								StatusCode: 200,
+							},
+							BodyString: `{
+  "roles": [
+    {"name": "Reader", "description": "reader", "permissions": ["inputs:read", "streams:read"], "read_only": true},
+    {"name": "Admin", "description": "admin", "permissions": ["*"], "read_only": true},
+    {"name": "Stream Editors", "description": "", "permissions": ["streams:edit", "streams:read"], "read_only": false}
+  ],
+  "total": 3
+}`,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "graylog_role" "test" {
+  name = "Reader"
+}
+
+data "graylog_roles" "test" {
+  filter {
+    name   = "permissions"
+    values = ["streams:read"]
+  }
+  filter {
+    name   = "read_only"
+    values = ["false"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_role.test", "id", "Reader"),
+					resource.TestCheckResourceAttr("data.graylog_role.test", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("data.graylog_roles.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_roles.test", "roles.0.name", "Stream Editors"),
+				),
+			},
+			{
+				Config: `
+data "graylog_role" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`name must be set`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEventNotification(t *testing.T) {
+	setEnv()
+	genRoute := func(page, body string) flute.Route {
+		return flute.Route{
+			Name: "Get event notifications page " + page,
+			Matcher: &flute.Matcher{
+				Method:      "GET",
+				Path:        "/api/events/notifications",
+				PartOfQuery: map[string][]string{"page": {page}},
+			},
+			Tester: &flute.Tester{
+				PartOfHeader: getTestHeader(),
+			},
+			Response: &flute.Response{
+				Base: http.Response{
+					StatusCode: 200,
+				},
+				BodyString: body,
+			},
+		}
+	}
+	defaultTransport := http.DefaultClient.Transport
+	defer func() {
+		http.DefaultClient.Transport = defaultTransport
+	}()
+	// the list API is paginated and the notifications of the second page are also found
+	http.DefaultClient.Transport = &flute.Transport{
+		T: t,
+		Services: []flute.Service{
+			{
+				Endpoint: "http://example.com",
+				Routes: []flute.Route{
+					genRoute("1", `{
+  "notifications": [
+    {"id": "n1", "title": "slack", "description": "", "config": {"type": "http-notification-v1", "url": "http://example.com/slack"}}
+  ],
+  "total": 3, "page": 1, "per_page": 1
+}`),
+					genRoute("2", `{
+  "notifications": [
+    {"id": "n2", "title": "pagerduty", "description": "", "config": {"type": "http-notification-v1", "url": "http://example.com/pagerduty"}}
+  ],
+  "total": 3, "page": 2, "per_page": 1
+}`),
+					genRoute("3", `{
+  "notifications": [
+    {"id": "n3", "title": "slack", "description": "", "config": {"type": "http-notification-v1", "url": "http://example.com/slack2"}}
+  ],
+  "total": 3, "page": 3, "per_page": 1
+}`),
+				},
+			},
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "graylog_event_notification" "test" {
+  title = "pagerduty"
+}
+
+data "graylog_event_notifications" "test" {
+  title_regex = "^slack$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_event_notification.test", "notification_id", "n2"),
+					resource.TestCheckResourceAttr("data.graylog_event_notifications.test", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.graylog_event_notifications.test", "ids.1", "n3"),
+				),
+			},
+			{
+				// the duplicated title is on the other page
+				Config: `
+data "graylog_event_notification" "test" {
+  title = "slack"
+}
+`,
+				ExpectError: regexp.MustCompile(`title isn't unique`),
+			},
+		},
+	})
+}
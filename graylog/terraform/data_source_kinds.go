@@ -0,0 +1,213 @@
+package terraform
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+)
+
+var (
+	dataSourceKindInput = &dataSourceKind{
+		name:       "input",
+		idKey:      "input_id",
+		titleKey:   "title",
+		filterKeys: []string{"type"},
+		resource:   resourceInput,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			inputs, _, _, err := cl.GetInputs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]dataSourceItem, len(inputs))
+			for i := range inputs {
+				input := &inputs[i]
+				items[i] = dataSourceItem{
+					id: input.ID, title: input.Title,
+					set: func(d *schema.ResourceData) error { return setInput(d, input) },
+				}
+			}
+			return items, nil
+		},
+	}
+
+	dataSourceKindUser = &dataSourceKind{
+		name:     "user",
+		idKey:    "user_id",
+		titleKey: "username",
+		resource: resourceUser,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			users, _, err := cl.GetUsers(ctx)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]dataSourceItem, len(users))
+			for i := range users {
+				user := &users[i]
+				items[i] = dataSourceItem{
+					id: user.ID, title: user.Username,
+					set: func(d *schema.ResourceData) error { return setUser(d, user) },
+				}
+			}
+			return items, nil
+		},
+	}
+
+	// the name of a role is the id
+	dataSourceKindRole = &dataSourceKind{
+		name:     "role",
+		titleKey: "name",
+		resource: resourceRole,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			roles, _, _, err := cl.GetRoles(ctx)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]dataSourceItem, len(roles))
+			for i := range roles {
+				role := &roles[i]
+				items[i] = dataSourceItem{
+					id: role.Name, title: role.Name,
+					set: func(d *schema.ResourceData) error { return setRole(d, role) },
+				}
+			}
+			return items, nil
+		},
+	}
+
+	dataSourceKindPipeline = &dataSourceKind{
+		name:     "pipeline",
+		idKey:    "pipeline_id",
+		titleKey: "title",
+		resource: resourcePipeline,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			pipes, _, err := cl.GetPipelines(ctx)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]dataSourceItem, len(pipes))
+			for i := range pipes {
+				pipe := &pipes[i]
+				items[i] = dataSourceItem{
+					id: pipe.ID, title: pipe.Title,
+					set: func(d *schema.ResourceData) error { return setPipeline(d, pipe) },
+				}
+			}
+			return items, nil
+		},
+	}
+
+	dataSourceKindPipelineRule = &dataSourceKind{
+		name:     "pipeline rule",
+		idKey:    "rule_id",
+		titleKey: "title",
+		resource: resourcePipelineRule,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			rules, _, err := cl.GetPipelineRules(ctx)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]dataSourceItem, len(rules))
+			for i := range rules {
+				rule := &rules[i]
+				items[i] = dataSourceItem{
+					id: rule.ID, title: rule.Title,
+					set: func(d *schema.ResourceData) error { return setPipelineRule(d, rule) },
+				}
+			}
+			return items, nil
+		},
+	}
+
+	dataSourceKindOutput = &dataSourceKind{
+		name:       "output",
+		idKey:      "output_id",
+		titleKey:   "title",
+		filterKeys: []string{"type"},
+		resource:   resourceOutput,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			outputs, _, _, err := cl.GetOutputs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]dataSourceItem, len(outputs))
+			for i := range outputs {
+				output := &outputs[i]
+				items[i] = dataSourceItem{
+					id: output.ID, title: output.Title,
+					set: func(d *schema.ResourceData) error { return setOutput(d, output) },
+				}
+			}
+			return items, nil
+		},
+	}
+
+	dataSourceKindEventNotification = &dataSourceKind{
+		name:     "event notification",
+		idKey:    "notification_id",
+		titleKey: "title",
+		resource: resourceEventNotification,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			body, _, err := cl.GetEventNotifications(ctx)
+			if err != nil {
+				return nil, err
+			}
+			notifs := body.EventNotifications
+			items := make([]dataSourceItem, len(notifs))
+			for i := range notifs {
+				notif := &notifs[i]
+				items[i] = dataSourceItem{
+					id: notif.ID, title: notif.Title,
+					set: func(d *schema.ResourceData) error { return setEventNotification(d, notif) },
+				}
+			}
+			return items, nil
+		},
+	}
+
+	dataSourceKindEventDefinition = &dataSourceKind{
+		name:     "event definition",
+		idKey:    "event_definition_id",
+		titleKey: "title",
+		resource: resourceEventDefinition,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			body, _, err := cl.GetEventDefinitions(ctx)
+			if err != nil {
+				return nil, err
+			}
+			definitions := body.EventDefinitions
+			items := make([]dataSourceItem, len(definitions))
+			for i := range definitions {
+				definition := &definitions[i]
+				items[i] = dataSourceItem{
+					id: definition.ID, title: definition.Title,
+					set: func(d *schema.ResourceData) error { return setEventDefinition(d, definition) },
+				}
+			}
+			return items, nil
+		},
+	}
+
+	dataSourceKindGrokPattern = &dataSourceKind{
+		name:     "grok pattern",
+		idKey:    "grok_pattern_id",
+		titleKey: "name",
+		resource: resourceGrokPattern,
+		list: func(ctx context.Context, cl *client.Client) ([]dataSourceItem, error) {
+			patterns, _, err := cl.GetGrokPatterns(ctx)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]dataSourceItem, len(patterns))
+			for i := range patterns {
+				pattern := &patterns[i]
+				items[i] = dataSourceItem{
+					id: pattern.ID, title: pattern.Name,
+					set: func(d *schema.ResourceData) error { return setGrokPattern(d, pattern) },
+				}
+			}
+			return items, nil
+		},
+	}
+)
@@ -0,0 +1,179 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// plural returns the plural data source of the kind such as graylog_inputs.
+// key is the attribute of the list of objects such as "inputs".
+//
+// Objects are filtered with the regular expression of the title such as "title_regex"
+// and "filter" blocks, each of which matches if the attribute equals one of the values.
+// If the attribute is a list or a set, the filter matches if one of the elements equals one of the values.
+func (kind *dataSourceKind) plural(key string) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: wrapCRUD(func(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+			return kind.readPlural(ctx, d, m, key)
+		}),
+		Schema: map[string]*schema.Schema{
+			kind.titleKey + "_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: wrapValidateFunc(validateFuncRegexp),
+			},
+			"filter": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			// computed
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			key: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Resource{Schema: kind.itemSchema()},
+			},
+		},
+	}
+}
+
+func validateFuncRegexp(v interface{}, k string) error {
+	if _, err := regexp.Compile(v.(string)); err != nil {
+		return fmt.Errorf("'%s' must be a regular expression: %w", k, err)
+	}
+	return nil
+}
+
+type listFilter struct {
+	name   string
+	values []string
+}
+
+func (kind *dataSourceKind) readPlural(ctx context.Context, d *schema.ResourceData, m interface{}, key string) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	sch := kind.itemSchema()
+
+	var titleRegexp *regexp.Regexp
+	if v, ok := d.GetOk(kind.titleKey + "_regex"); ok {
+		titleRegexp, err = regexp.Compile(v.(string))
+		if err != nil {
+			return err
+		}
+	}
+	filters := []listFilter{}
+	for _, a := range d.Get("filter").(*schema.Set).List() {
+		f := a.(map[string]interface{})
+		name := f["name"].(string)
+		if _, ok := sch[name]; !ok {
+			return &attrError{key: "filter", err: fmt.Errorf("%s doesn't have the attribute %s", kind.name, name)}
+		}
+		filters = append(filters, listFilter{name: name, values: getStringArray(f["values"].([]interface{}))})
+	}
+
+	items, err := kind.list(ctx, cl)
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	objects := []map[string]interface{}{}
+	for i := range items {
+		item := &items[i]
+		if titleRegexp != nil && !titleRegexp.MatchString(item.title) {
+			continue
+		}
+		data, err := kind.itemData(sch, item)
+		if err != nil {
+			return err
+		}
+		if !matchListFilters(data, filters) {
+			continue
+		}
+		obj := make(map[string]interface{}, len(sch))
+		for k := range sch {
+			obj[k] = data.Get(k)
+		}
+		ids = append(ids, item.id)
+		objects = append(objects, obj)
+	}
+
+	// the id is the filters, because the result isn't an object of Graylog
+	idParts := []string{kind.name}
+	if titleRegexp != nil {
+		idParts = append(idParts, titleRegexp.String())
+	}
+	for _, f := range filters {
+		idParts = append(idParts, f.name+"="+strings.Join(f.values, ","))
+	}
+	d.SetId(strings.Join(idParts, "/"))
+	if err := setStrListToRD(d, "ids", ids); err != nil {
+		return err
+	}
+	return setToRD(d, key, objects)
+}
+
+func matchListFilters(data *schema.ResourceData, filters []listFilter) bool {
+	for _, f := range filters {
+		if !matchListFilter(data.Get(f.name), f.values) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchListFilter(v interface{}, values []string) bool {
+	var elems []interface{}
+	switch a := v.(type) {
+	case *schema.Set:
+		elems = a.List()
+	case []interface{}:
+		elems = a
+	default:
+		elems = []interface{}{a}
+	}
+	for _, elem := range elems {
+		var s string
+		switch a := elem.(type) {
+		case string:
+			s = a
+		case bool:
+			s = strconv.FormatBool(a)
+		case int:
+			s = strconv.Itoa(a)
+		default:
+			continue
+		}
+		for _, value := range values {
+			if s == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -4,14 +4,14 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func dataSourceStream() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceStreamRead,
+		ReadContext: wrapCRUD(dataSourceStreamRead),
 
 		Schema: map[string]*schema.Schema{
 			"title": {
@@ -102,8 +102,7 @@ func setStream(d *schema.ResourceData, stream *graylog.Stream, cfg *Config) erro
 	return setBoolToRD(d, "is_default", stream.IsDefault)
 }
 
-func dataSourceStreamRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func dataSourceStreamRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -1,7 +1,10 @@
 package terraform
 
 import (
-	"github.com/hashicorp/terraform/helper/schema"
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Provider returns a terraform resource provider for graylog.
@@ -37,52 +40,97 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"GRAYLOG_API_VERSION"}, "v2"),
 			},
+			"max_concurrency": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"GRAYLOG_MAX_CONCURRENCY"}, 0),
+			},
+			"requests_per_second": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"GRAYLOG_REQUESTS_PER_SECOND"}, 0.0),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"graylog_alert_condition":            resourceAlertCondition(),
-			"graylog_alarm_callback":             resourceAlarmCallback(),
-			"graylog_dashboard":                  resourceDashboard(),
-			"graylog_dashboard_widget":           resourceDashboardWidget(),
-			"graylog_dashboard_widget_positions": resourceDashboardWidgetPositions(),
-			"graylog_event_definition":           resourceEventDefinition(),
-			"graylog_event_notification":         resourceEventNotification(),
-			"graylog_extractor":                  resourceExtractor(),
-			"graylog_grok_pattern":               resourceGrokPattern(),
-			"graylog_index_set":                  resourceIndexSet(),
-			"graylog_input":                      resourceInput(),
-			"graylog_input_static_fields":        resourceInputStaticFields(),
-			"graylog_ldap_setting":               resourceLDAPSetting(),
-			"graylog_output":                     resourceOutput(),
-			"graylog_stream_output":              resourceStreamOutput(),
-			"graylog_pipeline":                   resourcePipeline(),
-			"graylog_pipeline_rule":              resourcePipelineRule(),
-			"graylog_pipeline_connection":        resourcePipelineConnection(),
-			"graylog_role":                       resourceRole(),
-			"graylog_stream":                     resourceStream(),
-			"graylog_stream_rule":                resourceStreamRule(),
-			"graylog_user":                       resourceUser(),
+			"graylog_alert_condition":               resourceAlertCondition(),
+			"graylog_alarm_callback":                resourceAlarmCallback(),
+			"graylog_active_authentication_backend": resourceActiveAuthenticationBackend(),
+			"graylog_authentication_backend":        resourceAuthenticationBackend(),
+			"graylog_cluster_config":                resourceClusterConfig(),
+			"graylog_dashboard":                     resourceDashboard(),
+			"graylog_dashboard_widget":              resourceDashboardWidget(),
+			"graylog_dashboard_widget_positions":    resourceDashboardWidgetPositions(),
+			"graylog_entity_share":                  resourceEntityShare(),
+			"graylog_event_definition":              resourceEventDefinition(),
+			"graylog_event_notification":            resourceEventNotification(),
+			"graylog_extractor":                     resourceExtractor(),
+			"graylog_grok_pattern":                  resourceGrokPattern(),
+			"graylog_grok_patterns":                 resourceGrokPatterns(),
+			"graylog_index_set":                     resourceIndexSet(),
+			"graylog_input":                         resourceInput(),
+			"graylog_input_static_fields":           resourceInputStaticFields(),
+			"graylog_ldap_group_role_mapping":       resourceLDAPGroupRoleMapping(),
+			"graylog_ldap_setting":                  resourceLDAPSetting(),
+			"graylog_message_processors":            resourceMessageProcessors(),
+			"graylog_output":                        resourceOutput(),
+			"graylog_stream_output":                 resourceStreamOutput(),
+			"graylog_pipeline":                      resourcePipeline(),
+			"graylog_pipeline_rule":                 resourcePipelineRule(),
+			"graylog_pipeline_connection":           resourcePipelineConnection(),
+			"graylog_role":                          resourceRole(),
+			"graylog_role_members":                  resourceRoleMembers(),
+			"graylog_stream":                        resourceStream(),
+			"graylog_stream_rule":                   resourceStreamRule(),
+			"graylog_user":                          resourceUser(),
+			"graylog_user_role":                     resourceUserRole(),
+			"graylog_user_token":                    resourceUserToken(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"graylog_index_set": dataSourceIndexSet(),
 			"graylog_stream":    dataSourceStream(),
 			"graylog_dashboard": dataSourceDashboard(),
+
+			"graylog_input":              dataSourceKindInput.singular(),
+			"graylog_user":               dataSourceKindUser.singular(),
+			"graylog_role":               dataSourceKindRole.singular(),
+			"graylog_pipeline":           dataSourceKindPipeline.singular(),
+			"graylog_pipeline_rule":      dataSourceKindPipelineRule.singular(),
+			"graylog_output":             dataSourceKindOutput.singular(),
+			"graylog_event_notification": dataSourceKindEventNotification.singular(),
+			"graylog_event_definition":   dataSourceKindEventDefinition.singular(),
+			"graylog_grok_pattern":       dataSourceKindGrokPattern.singular(),
+
+			"graylog_inputs":              dataSourceKindInput.plural("inputs"),
+			"graylog_users":               dataSourceKindUser.plural("users"),
+			"graylog_roles":               dataSourceKindRole.plural("roles"),
+			"graylog_pipelines":           dataSourceKindPipeline.plural("pipelines"),
+			"graylog_pipeline_rules":      dataSourceKindPipelineRule.plural("pipeline_rules"),
+			"graylog_outputs":             dataSourceKindOutput.plural("outputs"),
+			"graylog_event_notifications": dataSourceKindEventNotification.plural("event_notifications"),
+			"graylog_event_definitions":   dataSourceKindEventDefinition.plural("event_definitions"),
+			"graylog_grok_patterns":       dataSourceKindGrokPattern.plural("grok_patterns"),
 		},
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	config := Config{
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	config := &Config{
 		Endpoint:     d.Get("web_endpoint_uri").(string),
 		AuthName:     d.Get("auth_name").(string),
 		AuthPassword: d.Get("auth_password").(string),
 		XRequestedBy: d.Get("x_requested_by").(string),
 		APIVersion:   d.Get("api_version").(string),
+
+		MaxConcurrency:    d.Get("max_concurrency").(int),
+		RequestsPerSecond: d.Get("requests_per_second").(float64),
 	}
 
 	if err := config.loadAndValidate(); err != nil {
-		return nil, err
+		return nil, diag.FromErr(err)
 	}
 
-	return &config, nil
+	return config, nil
 }
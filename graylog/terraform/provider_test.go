@@ -0,0 +1,11 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider(t *testing.T) {
+	require.Nil(t, Provider().InternalValidate())
+}
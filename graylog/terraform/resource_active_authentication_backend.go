@@ -0,0 +1,85 @@
+package terraform
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	activeAuthenticationBackendID = "active_authentication_backend_id"
+)
+
+// resourceActiveAuthenticationBackend activates an authentication backend.
+// Only one backend can be active at the same time.
+func resourceActiveAuthenticationBackend() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceActiveAuthenticationBackendCreate),
+		ReadContext:   wrapCRUD(resourceActiveAuthenticationBackendRead),
+		UpdateContext: wrapCRUD(resourceActiveAuthenticationBackendUpdate),
+		DeleteContext: wrapCRUD(resourceActiveAuthenticationBackendDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"backend_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceActiveAuthenticationBackendCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.ActivateAuthenticationBackend(ctx, d.Get("backend_id").(string)); err != nil {
+		return err
+	}
+	d.SetId(activeAuthenticationBackendID)
+	return resourceActiveAuthenticationBackendRead(ctx, d, m)
+}
+
+func resourceActiveAuthenticationBackendRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	cfg, _, err := cl.GetAuthenticationServiceConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg.ActiveBackend == "" {
+		// the backend is deactivated out of band
+		d.SetId("")
+		return nil
+	}
+	return setStrToRD(d, "backend_id", cfg.ActiveBackend)
+}
+
+func resourceActiveAuthenticationBackendUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.ActivateAuthenticationBackend(ctx, d.Get("backend_id").(string)); err != nil {
+		return err
+	}
+	return resourceActiveAuthenticationBackendRead(ctx, d, m)
+}
+
+func resourceActiveAuthenticationBackendDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	_, err = cl.ActivateAuthenticationBackend(ctx, "")
+	return err
+}
@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-set/v6"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
@@ -13,15 +13,17 @@ import (
 
 func resourceAlarmCallback() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAlarmCallbackCreate,
-		Read:   resourceAlarmCallbackRead,
-		Update: resourceAlarmCallbackUpdate,
-		Delete: resourceAlarmCallbackDelete,
+		CreateContext: wrapCRUD(resourceAlarmCallbackCreate),
+		ReadContext:   wrapCRUD(resourceAlarmCallbackRead),
+		UpdateContext: wrapCRUD(resourceAlarmCallbackUpdate),
+		DeleteContext: wrapCRUD(resourceAlarmCallbackDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: genImport("stream_id", "alarm_callback_id"),
+			StateContext: genImport("stream_id", "alarm_callback_id"),
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"type": {
@@ -284,8 +286,7 @@ func newAlarmCallback(d *schema.ResourceData) (*graylog.AlarmCallback, error) {
 	return &ac, nil
 }
 
-func resourceAlarmCallbackCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceAlarmCallbackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -302,8 +303,7 @@ func resourceAlarmCallbackCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAlarmCallbackRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceAlarmCallbackRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -405,8 +405,7 @@ func resourceAlarmCallbackRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAlarmCallbackUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceAlarmCallbackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -421,8 +420,7 @@ func resourceAlarmCallbackUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAlarmCallbackDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceAlarmCallbackDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -7,26 +7,28 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourceAlertCondition() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAlertConditionCreate,
-		Read:   resourceAlertConditionRead,
-		Update: resourceAlertConditionUpdate,
-		Delete: resourceAlertConditionDelete,
+		CreateContext: wrapCRUD(resourceAlertConditionCreate),
+		ReadContext:   wrapCRUD(resourceAlertConditionRead),
+		UpdateContext: wrapCRUD(resourceAlertConditionUpdate),
+		DeleteContext: wrapCRUD(resourceAlertConditionDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: genImport("stream_id", "alert_condition_id"),
+			StateContext: genImport("stream_id", "alert_condition_id"),
 		},
 
 		SchemaVersion: 1,
 		MigrateState:  alertConditionStateMigrateFunc,
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"type": {
@@ -410,8 +412,7 @@ func newAlertCondition(d *schema.ResourceData) (*graylog.AlertCondition, error)
 	return &cond, nil
 }
 
-func resourceAlertConditionCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceAlertConditionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -428,8 +429,7 @@ func resourceAlertConditionCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAlertConditionRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceAlertConditionRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -544,8 +544,7 @@ func resourceAlertConditionRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAlertConditionUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceAlertConditionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -560,8 +559,7 @@ func resourceAlertConditionUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAlertConditionDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceAlertConditionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
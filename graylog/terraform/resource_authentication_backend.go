@@ -0,0 +1,360 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func resourceAuthenticationBackend() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceAuthenticationBackendCreate),
+		ReadContext:   wrapCRUD(resourceAuthenticationBackendRead),
+		UpdateContext: wrapCRUD(resourceAuthenticationBackendUpdate),
+		DeleteContext: wrapCRUD(resourceAuthenticationBackendDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: validateFuncOneOf(
+					graylog.AuthenticationBackendTypeLDAP, graylog.AuthenticationBackendTypeActiveDirectory),
+			},
+			"default_roles": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"server": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"user_search_base": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_search_pattern": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_name_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_full_name_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Optional
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"transport_security": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  graylog.TransportSecurityTLS,
+				ValidateDiagFunc: validateFuncOneOf(
+					graylog.TransportSecurityNone, graylog.TransportSecurityTLS, graylog.TransportSecurityStartTLS),
+			},
+			"verify_certificates": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"system_user_dn": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Graylog doesn't return the password, so the change of the password out of band isn't detected
+			"system_user_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			// user_unique_id_attribute is only for LDAP
+			"user_unique_id_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// group_sync requires Graylog Enterprise
+			"group_sync": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_search_base": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"group_search_pattern": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"group_name_attribute": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"default_roles": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newAuthenticationBackend(d *schema.ResourceData) (*graylog.AuthenticationBackend, error) {
+	cfg := &graylog.AuthenticationBackendConfig{
+		Type:                  d.Get("type").(string),
+		TransportSecurity:     d.Get("transport_security").(string),
+		VerifyCertificates:    d.Get("verify_certificates").(bool),
+		SystemUserDN:          d.Get("system_user_dn").(string),
+		UserSearchBase:        d.Get("user_search_base").(string),
+		UserSearchPattern:     d.Get("user_search_pattern").(string),
+		UserNameAttribute:     d.Get("user_name_attribute").(string),
+		UserFullNameAttribute: d.Get("user_full_name_attribute").(string),
+	}
+	if v, ok := d.GetOk("user_unique_id_attribute"); ok {
+		if cfg.Type != graylog.AuthenticationBackendTypeLDAP {
+			return nil, &attrError{
+				key: "user_unique_id_attribute",
+				err: errors.New("user_unique_id_attribute can be set only if type is " + graylog.AuthenticationBackendTypeLDAP),
+			}
+		}
+		cfg.UserUniqueIDAttribute = v.(string)
+	}
+	for _, a := range d.Get("server").([]interface{}) {
+		server := a.(map[string]interface{})
+		cfg.Servers = append(cfg.Servers, graylog.AuthenticationServer{
+			Host: server["host"].(string),
+			Port: server["port"].(int),
+		})
+	}
+
+	// the secret is sent only if it is changed, otherwise it is kept
+	password := d.Get("system_user_password").(string)
+	switch {
+	case d.IsNewResource():
+		if password != "" {
+			cfg.SystemUserPassword = &graylog.EncryptedValue{SetValue: password}
+		}
+	case !d.HasChange("system_user_password"):
+		cfg.SystemUserPassword = &graylog.EncryptedValue{KeepValue: true}
+	case password == "":
+		cfg.SystemUserPassword = &graylog.EncryptedValue{DeleteValue: true}
+	default:
+		cfg.SystemUserPassword = &graylog.EncryptedValue{SetValue: password}
+	}
+
+	return &graylog.AuthenticationBackend{
+		ID:           d.Id(),
+		Title:        d.Get("title").(string),
+		Description:  d.Get("description").(string),
+		DefaultRoles: getStringArray(d.Get("default_roles").(*schema.Set).List()),
+		Config:       cfg,
+	}, nil
+}
+
+// newAuthenticationBackendGroupSync returns nil if group_sync isn't set.
+func newAuthenticationBackendGroupSync(d *schema.ResourceData) *graylog.AuthenticationBackendGroupSync {
+	list := d.Get("group_sync").([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	a := list[0].(map[string]interface{})
+	return &graylog.AuthenticationBackendGroupSync{
+		DefaultRoles: getStringArray(a["default_roles"].(*schema.Set).List()),
+		Config: &graylog.AuthenticationBackendGroupSyncConfig{
+			Type:               d.Get("type").(string),
+			GroupSearchBase:    a["group_search_base"].(string),
+			GroupSearchPattern: a["group_search_pattern"].(string),
+			GroupNameAttribute: a["group_name_attribute"].(string),
+		},
+	}
+}
+
+func resourceAuthenticationBackendCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	backend, err := newAuthenticationBackend(d)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.CreateAuthenticationBackend(ctx, backend); err != nil {
+		return err
+	}
+	if backend.ID == "" {
+		return errors.New("the created authentication backend's id is empty")
+	}
+	d.SetId(backend.ID)
+	if groupSync := newAuthenticationBackendGroupSync(d); groupSync != nil {
+		if _, err := cl.CreateAuthenticationBackendGroupSync(ctx, backend.ID, groupSync); err != nil {
+			return err
+		}
+	}
+	return resourceAuthenticationBackendRead(ctx, d, m)
+}
+
+func resourceAuthenticationBackendRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	backend, ei, err := cl.GetAuthenticationBackend(ctx, d.Id())
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	if err := setAuthenticationBackend(d, backend); err != nil {
+		return err
+	}
+
+	groupSync, ei, err := cl.GetAuthenticationBackendGroupSync(ctx, d.Id())
+	if err != nil {
+		// the group synchronization isn't configured or Graylog Enterprise isn't installed
+		if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+			return err
+		}
+		return setToRD(d, "group_sync", []interface{}{})
+	}
+	a := map[string]interface{}{
+		"default_roles": groupSync.DefaultRoles,
+	}
+	if groupSync.Config != nil {
+		a["group_search_base"] = groupSync.Config.GroupSearchBase
+		a["group_search_pattern"] = groupSync.Config.GroupSearchPattern
+		a["group_name_attribute"] = groupSync.Config.GroupNameAttribute
+	}
+	return setToRD(d, "group_sync", []interface{}{a})
+}
+
+func setAuthenticationBackend(d *schema.ResourceData, backend *graylog.AuthenticationBackend) error {
+	if err := setStrToRD(d, "title", backend.Title); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "description", backend.Description); err != nil {
+		return err
+	}
+	if err := setStrListToRD(d, "default_roles", backend.DefaultRoles); err != nil {
+		return err
+	}
+	cfg := backend.Config
+	if cfg == nil {
+		return errors.New("the authentication backend doesn't have the config")
+	}
+	if err := setStrToRD(d, "type", cfg.Type); err != nil {
+		return err
+	}
+	servers := make([]interface{}, len(cfg.Servers))
+	for i, server := range cfg.Servers {
+		servers[i] = map[string]interface{}{
+			"host": server.Host,
+			"port": server.Port,
+		}
+	}
+	if err := setToRD(d, "server", servers); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "transport_security", cfg.TransportSecurity); err != nil {
+		return err
+	}
+	if err := setBoolToRD(d, "verify_certificates", cfg.VerifyCertificates); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "system_user_dn", cfg.SystemUserDN); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "user_search_base", cfg.UserSearchBase); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "user_search_pattern", cfg.UserSearchPattern); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "user_unique_id_attribute", cfg.UserUniqueIDAttribute); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "user_name_attribute", cfg.UserNameAttribute); err != nil {
+		return err
+	}
+	return setStrToRD(d, "user_full_name_attribute", cfg.UserFullNameAttribute)
+}
+
+func resourceAuthenticationBackendUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	backend, err := newAuthenticationBackend(d)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.UpdateAuthenticationBackend(ctx, backend); err != nil {
+		return err
+	}
+	if d.HasChange("group_sync") {
+		oldV, _ := d.GetChange("group_sync")
+		groupSync := newAuthenticationBackendGroupSync(d)
+		switch {
+		case groupSync == nil:
+			if _, err := cl.DeleteAuthenticationBackendGroupSync(ctx, d.Id()); err != nil {
+				return err
+			}
+		case len(oldV.([]interface{})) == 0:
+			if _, err := cl.CreateAuthenticationBackendGroupSync(ctx, d.Id(), groupSync); err != nil {
+				return err
+			}
+		default:
+			if _, err := cl.UpdateAuthenticationBackendGroupSync(ctx, d.Id(), groupSync); err != nil {
+				return err
+			}
+		}
+	}
+	return resourceAuthenticationBackendRead(ctx, d, m)
+}
+
+func resourceAuthenticationBackendDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.DeleteAuthenticationBackend(ctx, d.Id()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// fakeAuthenticationServer is a fake API of Graylog 4's authentication services.
+type fakeAuthenticationServer struct {
+	fakeAPI
+	backends      map[string]*graylog.AuthenticationBackend
+	groupSyncs    map[string]*graylog.AuthenticationBackendGroupSync
+	passwords     map[string]string
+	activeBackend string
+	count         int
+}
+
+func newFakeAuthenticationServer() *fakeAuthenticationServer {
+	return &fakeAuthenticationServer{
+		backends:   map[string]*graylog.AuthenticationBackend{},
+		groupSyncs: map[string]*graylog.AuthenticationBackendGroupSync{},
+		passwords:  map[string]string{},
+	}
+}
+
+// savePassword changes the password according to the encrypted value like Graylog.
+func (s *fakeAuthenticationServer) savePassword(id string, backend *graylog.AuthenticationBackend) error {
+	v := backend.Config.SystemUserPassword
+	switch {
+	case v == nil:
+		if _, ok := s.passwords[id]; ok {
+			return fmt.Errorf("the password of the backend %s isn't kept", id)
+		}
+	case v.SetValue != "":
+		s.passwords[id] = v.SetValue
+	case v.DeleteValue:
+		delete(s.passwords, id)
+	case !v.KeepValue:
+		return fmt.Errorf("the password of the backend %s is invalid: %+v", id, v)
+	}
+	_, ok := s.passwords[id]
+	backend.Config.SystemUserPassword = &graylog.EncryptedValue{IsSet: ok}
+	return nil
+}
+
+// serviceConfig returns the response body of the authentication service configuration API.
+func (s *fakeAuthenticationServer) serviceConfig() *graylog.AuthenticationServiceConfigBody {
+	return &graylog.AuthenticationServiceConfigBody{
+		Configuration: graylog.AuthenticationServiceConfig{ActiveBackend: s.activeBackend},
+	}
+}
+
+func (s *fakeAuthenticationServer) handle(req *http.Request) (int, interface{}, error) {
+	const (
+		backendsPath  = "/api/system/authentication/services/backends"
+		configPath    = "/api/system/authentication/services/configuration"
+		groupSyncPath = "/api/plugins/org.graylog.plugins.security/team-sync/backend/"
+	)
+	switch {
+	case req.URL.Path == configPath && req.Method == "GET":
+		return 200, s.serviceConfig(), nil
+	case req.URL.Path == configPath && req.Method == "POST":
+		cfg := graylog.AuthenticationServiceConfig{}
+		if err := json.NewDecoder(req.Body).Decode(&cfg); err != nil {
+			return 0, nil, err
+		}
+		if _, ok := s.backends[cfg.ActiveBackend]; cfg.ActiveBackend != "" && !ok {
+			return fakeNotFound()
+		}
+		s.activeBackend = cfg.ActiveBackend
+		return 200, s.serviceConfig(), nil
+	case req.URL.Path == backendsPath && req.Method == "POST":
+		backend := &graylog.AuthenticationBackend{}
+		if err := json.NewDecoder(req.Body).Decode(backend); err != nil {
+			return 0, nil, err
+		}
+		s.count++
+		backend.ID = fmt.Sprintf("backend%d", s.count)
+		if err := s.savePassword(backend.ID, backend); err != nil {
+			return 0, nil, err
+		}
+		s.backends[backend.ID] = backend
+		return 200, graylog.AuthenticationBackendBody{Backend: backend}, nil
+	case strings.HasPrefix(req.URL.Path, backendsPath+"/"):
+		id := strings.TrimPrefix(req.URL.Path, backendsPath+"/")
+		backend, ok := s.backends[id]
+		if !ok {
+			return fakeNotFound()
+		}
+		switch req.Method {
+		case "GET":
+			return 200, graylog.AuthenticationBackendBody{Backend: backend}, nil
+		case "PUT":
+			backend := &graylog.AuthenticationBackend{}
+			if err := json.NewDecoder(req.Body).Decode(backend); err != nil {
+				return 0, nil, err
+			}
+			if err := s.savePassword(id, backend); err != nil {
+				return 0, nil, err
+			}
+			s.backends[id] = backend
+			return 200, graylog.AuthenticationBackendBody{Backend: backend}, nil
+		case "DELETE":
+			if s.activeBackend == id {
+				return 400, map[string]string{"message": "the active backend can't be deleted"}, nil
+			}
+			delete(s.backends, id)
+			delete(s.groupSyncs, id)
+			delete(s.passwords, id)
+			return 204, nil, nil
+		}
+	case strings.HasPrefix(req.URL.Path, groupSyncPath):
+		id := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, groupSyncPath), "/config")
+		switch req.Method {
+		case "GET":
+			if groupSync, ok := s.groupSyncs[id]; ok {
+				return 200, groupSync, nil
+			}
+		case "POST", "PUT":
+			groupSync := &graylog.AuthenticationBackendGroupSync{}
+			if err := json.NewDecoder(req.Body).Decode(groupSync); err != nil {
+				return 0, nil, err
+			}
+			s.groupSyncs[id] = groupSync
+			return 200, nil, nil
+		case "DELETE":
+			delete(s.groupSyncs, id)
+			return 204, nil, nil
+		}
+	}
+	return fakeNotFound()
+}
+
+func (s *fakeAuthenticationServer) checkPassword(id, password string) resource.TestCheckFunc {
+	return s.check(func() error {
+		if actual := s.passwords[id]; actual != password {
+			return fmt.Errorf("the password of the backend %s: expected %q, got %q", id, password, actual)
+		}
+		return nil
+	})
+}
+
+func TestAccAuthenticationBackend(t *testing.T) {
+	setEnv()
+	server := newFakeAuthenticationServer()
+
+	defer server.use(t, server.handle)()
+
+	genConfig := func(title, password, groupSync string) string {
+		return fmt.Sprintf(`
+resource "graylog_authentication_backend" "test" {
+  title         = %q
+  type          = "ldap"
+  default_roles = ["Reader"]
+  server {
+    host = "ldap.example.com"
+    port = 636
+  }
+  system_user_dn           = "cn=admin,dc=example,dc=com"
+  system_user_password     = %q
+  user_search_base         = "ou=users,dc=example,dc=com"
+  user_search_pattern      = "(&(objectClass=person)(uid={0}))"
+  user_name_attribute      = "uid"
+  user_full_name_attribute = "cn"
+  %s
+}
+
+resource "graylog_active_authentication_backend" "test" {
+  backend_id = graylog_authentication_backend.test.id
+}
+`, title, password, groupSync)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy: server.check(func() error {
+			if server.activeBackend != "" || len(server.backends) != 0 {
+				return fmt.Errorf("the backends should be deleted and deactivated: %v %s", server.backends, server.activeBackend)
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				PlanOnly: true,
+				Config: `
+resource "graylog_authentication_backend" "test" {
+  title                    = "ad"
+  type                     = "active-directory"
+  default_roles            = ["Reader"]
+  server {
+    host = "ad.example.com"
+    port = 636
+  }
+  transport_security       = "ssl"
+  user_search_base         = "dc=example,dc=com"
+  user_search_pattern      = "(&(objectClass=user)(sAMAccountName={0}))"
+  user_name_attribute      = "sAMAccountName"
+  user_full_name_attribute = "displayName"
+}
+`,
+				ExpectError: regexp.MustCompile(`'transport_security' must be one of none, tls, start_tls`),
+			},
+			{
+				Config: genConfig("ldap", "password", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_authentication_backend.test", "id", "backend1"),
+					resource.TestCheckResourceAttr("graylog_authentication_backend.test", "transport_security", "tls"),
+					resource.TestCheckResourceAttr("graylog_authentication_backend.test", "group_sync.#", "0"),
+					resource.TestCheckResourceAttr("graylog_active_authentication_backend.test", "backend_id", "backend1"),
+					server.checkPassword("backend1", "password"),
+				),
+			},
+			{
+				ResourceName:            "graylog_authentication_backend.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"system_user_password"},
+			},
+			{
+				// the password is kept if it isn't changed
+				Config: genConfig("ldap2", "password", `
+  group_sync {
+    group_search_base    = "ou=groups,dc=example,dc=com"
+    group_search_pattern = "(objectClass=groupOfNames)"
+    group_name_attribute = "cn"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_authentication_backend.test", "title", "ldap2"),
+					resource.TestCheckResourceAttr("graylog_authentication_backend.test", "group_sync.0.group_name_attribute", "cn"),
+					server.checkPassword("backend1", "password"),
+				),
+			},
+			{
+				// the backend is deactivated out of band, so it is activated again
+				PreConfig: func() {
+					server.update(func() {
+						server.activeBackend = ""
+					})
+				},
+				Config: genConfig("ldap2", "password2", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_active_authentication_backend.test", "backend_id", "backend1"),
+					resource.TestCheckResourceAttr("graylog_authentication_backend.test", "group_sync.#", "0"),
+					server.checkPassword("backend1", "password2"),
+				),
+			},
+		},
+	})
+}
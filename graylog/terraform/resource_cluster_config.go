@@ -0,0 +1,195 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// resourceClusterConfig manages a cluster configuration such as the order of message processors.
+// The fields of "config" are merged over the current configuration, and the other fields are kept.
+// When the resource is destroyed, only the fields of "config" are removed.
+// The resource id is the class of the configuration.
+func resourceClusterConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceClusterConfigCreate),
+		ReadContext:   wrapCRUD(resourceClusterConfigRead),
+		UpdateContext: wrapCRUD(resourceClusterConfigUpdate),
+		DeleteContext: wrapCRUD(resourceClusterConfigDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceClusterConfigCustomizeDiff,
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"class": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"config": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: schemaDiffSuppressJSONString,
+				ValidateDiagFunc: wrapValidateFunc(validateFuncJSONObject),
+			},
+		},
+	}
+}
+
+func validateFuncJSONObject(v interface{}, k string) error {
+	c, err := jsoneq.ConvertByte([]byte(v.(string)))
+	if err != nil {
+		return fmt.Errorf("'%s' must be a JSON string: %w", k, err)
+	}
+	if _, ok := c.(map[string]interface{}); !ok {
+		return fmt.Errorf("'%s' should be a JSON string which represents object", k)
+	}
+	return nil
+}
+
+// resourceClusterConfigCustomizeDiff checks the types of the fields of a known class at plan time.
+// Unknown fields aren't an error because newer Graylog may have them.
+func resourceClusterConfigCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	class := d.Get("class").(string)
+	cfg := d.Get("config").(string)
+	if class == "" || cfg == "" || !graylog.IsKnownClusterConfigClass(class) {
+		// the value is unknown or the configuration can't be checked
+		return nil
+	}
+	if _, err := graylog.UnmarshalClusterConfig(class, []byte(cfg), false); err != nil {
+		return &attrError{key: "config", err: err}
+	}
+	return nil
+}
+
+// getClusterConfigFields returns the fields of the current configuration.
+// If the configuration isn't stored, nil is returned.
+func getClusterConfigFields(ctx context.Context, cl *client.Client, class string) (map[string]interface{}, error) {
+	cfg, ei, err := cl.GetRawClusterConfig(ctx, class)
+	if err != nil {
+		if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return cfg.Fields, nil
+}
+
+// updateClusterConfig merges the fields of "config" over the current configuration and updates it,
+// so that the fields which aren't set in "config" are kept.
+func updateClusterConfig(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	class := d.Get("class").(string)
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(d.Get("config").(string)), &fields); err != nil {
+		return &attrError{key: "config", err: err}
+	}
+	current, err := getClusterConfigFields(ctx, cl, class)
+	if err != nil {
+		return err
+	}
+	merged := make(map[string]interface{}, len(current)+len(fields))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	_, err = cl.UpdateClusterConfig(ctx, &graylog.RawClusterConfig{ClassName: class, Fields: merged})
+	return err
+}
+
+func resourceClusterConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateClusterConfig(ctx, d, m); err != nil {
+		return err
+	}
+	d.SetId(d.Get("class").(string))
+	return resourceClusterConfigRead(ctx, d, m)
+}
+
+// resourceClusterConfigRead sets only the fields which are set in "config".
+// When the resource is imported, all fields are set.
+func resourceClusterConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	cfg, ei, err := cl.GetRawClusterConfig(ctx, d.Id())
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	fields := cfg.Fields
+	managed := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(d.Get("config").(string)), &managed); err == nil && len(managed) != 0 {
+		fields = make(map[string]interface{}, len(managed))
+		for k := range managed {
+			if v, ok := cfg.Fields[k]; ok {
+				fields[k] = v
+			}
+		}
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return errors.New("failed to convert the cluster config to JSON: " + err.Error())
+	}
+	if err := setStrToRD(d, "class", d.Id()); err != nil {
+		return err
+	}
+	return setStrToRD(d, "config", string(b))
+}
+
+func resourceClusterConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateClusterConfig(ctx, d, m); err != nil {
+		return err
+	}
+	return resourceClusterConfigRead(ctx, d, m)
+}
+
+// resourceClusterConfigDelete removes only the fields which are set in "config" and keeps the other fields.
+// If no field is left, the configuration is deleted.
+func resourceClusterConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	current, err := getClusterConfigFields(ctx, cl, d.Id())
+	if err != nil {
+		return err
+	}
+	managed := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(d.Get("config").(string)), &managed); err != nil {
+		return &attrError{key: "config", err: err}
+	}
+	fields := make(map[string]interface{}, len(current))
+	for k, v := range current {
+		if _, ok := managed[k]; !ok {
+			fields[k] = v
+		}
+	}
+	if len(fields) != 0 {
+		_, err := cl.UpdateClusterConfig(ctx, &graylog.RawClusterConfig{ClassName: d.Id(), Fields: fields})
+		return err
+	}
+	if ei, err := cl.DeleteClusterConfig(ctx, d.Id()); err != nil {
+		if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+			return err
+		}
+	}
+	return nil
+}
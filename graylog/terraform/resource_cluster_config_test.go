@@ -0,0 +1,141 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// fakeClusterConfigServer is a fake API of cluster configurations.
+type fakeClusterConfigServer struct {
+	fakeAPI
+	configs map[string]map[string]interface{}
+}
+
+func (s *fakeClusterConfigServer) handle(req *http.Request) (int, interface{}, error) {
+	class := strings.TrimPrefix(req.URL.Path, "/api/system/cluster_config/")
+	switch req.Method {
+	case "GET":
+		if cfg, ok := s.configs[class]; ok {
+			return 200, cfg, nil
+		}
+	case "PUT":
+		cfg := map[string]interface{}{}
+		if err := json.NewDecoder(req.Body).Decode(&cfg); err != nil {
+			return 0, nil, err
+		}
+		s.configs[class] = cfg
+		return 202, nil, nil
+	case "DELETE":
+		delete(s.configs, class)
+		return 204, nil, nil
+	}
+	return fakeNotFound()
+}
+
+func (s *fakeClusterConfigServer) checkConfig(class, expected string) resource.TestCheckFunc {
+	return s.check(func() error {
+		cfg, ok := s.configs[class]
+		if !ok {
+			if expected == "" {
+				return nil
+			}
+			return fmt.Errorf("the config %s isn't found", class)
+		}
+		if actual := fmt.Sprint(cfg); actual != expected {
+			return fmt.Errorf("the config %s: expected %s, got %s", class, expected, actual)
+		}
+		return nil
+	})
+}
+
+func TestAccClusterConfig(t *testing.T) {
+	setEnv()
+	server := &fakeClusterConfigServer{configs: map[string]map[string]interface{}{}}
+
+	defer server.use(t, server.handle)()
+
+	const eventsClass = "org.graylog.events.configuration.EventsConfiguration"
+	// the stored configuration has the field which the typed configuration doesn't have
+	server.configs[eventsClass] = map[string]interface{}{
+		"events_search_timeout":               60000,
+		"events_notification_retry_period":    300000,
+		"events_notification_default_backlog": 50,
+		"events_catchup_window":               3600000,
+		"events_notification_tcp_keepalive":   false,
+		"events_future_field":                 "foo",
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			// only the managed fields are removed
+			server.checkConfig(eventsClass, "map[events_catchup_window:3.6e+06 events_notification_default_backlog:100 events_notification_retry_period:300000 events_notification_tcp_keepalive:false]"),
+			server.checkConfig("org.example.FooConfig", ""),
+		),
+		Steps: []resource.TestStep{
+			{
+				PlanOnly: true,
+				Config: `
+resource "graylog_cluster_config" "events" {
+  class  = "` + eventsClass + `"
+  config = jsonencode({
+    events_search_timeout = "1m"
+  })
+}
+`,
+				ExpectError: regexp.MustCompile(`failed to parse the cluster config`),
+			},
+			{
+				// only the given fields are updated and the other fields are kept.
+				// the key order and the format are ignored
+				Config: `
+resource "graylog_cluster_config" "events" {
+  class  = "` + eventsClass + `"
+  config = <<JSON
+{
+  "events_notification_default_backlog": 100,
+  "events_search_timeout": 120000
+}
+JSON
+}
+
+resource "graylog_cluster_config" "foo" {
+  class  = "org.example.FooConfig"
+  config = jsonencode({
+    foo = "bar"
+  })
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_cluster_config.events", "id", eventsClass),
+					server.checkConfig(eventsClass, "map[events_catchup_window:3.6e+06 events_future_field:foo events_notification_default_backlog:100 events_notification_retry_period:300000 events_notification_tcp_keepalive:false events_search_timeout:120000]"),
+					server.checkConfig("org.example.FooConfig", "map[foo:bar]"),
+				),
+			},
+			{
+				ResourceName:      "graylog_cluster_config.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the field which newer Graylog has is accepted
+				Config: `
+resource "graylog_cluster_config" "events" {
+  class  = "` + eventsClass + `"
+  config = jsonencode({
+    events_search_timeout = 60000
+    events_future_field   = "bar"
+  })
+}
+`,
+				Check: server.checkConfig(eventsClass, "map[events_catchup_window:3.6e+06 events_future_field:bar events_notification_default_backlog:100 events_notification_retry_period:300000 events_notification_tcp_keepalive:false events_search_timeout:60000]"),
+			},
+		},
+	})
+}
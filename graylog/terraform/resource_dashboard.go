@@ -3,22 +3,24 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourceDashboard() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDashboardCreate,
-		Read:   resourceDashboardRead,
-		Update: resourceDashboardUpdate,
-		Delete: resourceDashboardDelete,
+		CreateContext: wrapCRUD(resourceDashboardCreate),
+		ReadContext:   wrapCRUD(resourceDashboardRead),
+		UpdateContext: wrapCRUD(resourceDashboardUpdate),
+		DeleteContext: wrapCRUD(resourceDashboardDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"title": {
@@ -84,8 +86,7 @@ func newDashboard(d *schema.ResourceData) (*graylog.Dashboard, error) {
 	}, nil
 }
 
-func resourceDashboardCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -101,8 +102,7 @@ func resourceDashboardCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDashboardRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -114,8 +114,7 @@ func resourceDashboardRead(d *schema.ResourceData, m interface{}) error {
 	return setDashboard(d, db)
 }
 
-func resourceDashboardUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -131,8 +130,7 @@ func resourceDashboardUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDashboardDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
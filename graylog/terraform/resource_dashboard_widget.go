@@ -6,7 +6,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
 	"github.com/suzuki-shunsuke/go-ptr"
 
@@ -15,15 +15,17 @@ import (
 
 func resourceDashboardWidget() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDashboardWidgetCreate,
-		Read:   resourceDashboardWidgetRead,
-		Update: resourceDashboardWidgetUpdate,
-		Delete: resourceDashboardWidgetDelete,
+		CreateContext: wrapCRUD(resourceDashboardWidgetCreate),
+		ReadContext:   wrapCRUD(resourceDashboardWidgetRead),
+		UpdateContext: wrapCRUD(resourceDashboardWidgetUpdate),
+		DeleteContext: wrapCRUD(resourceDashboardWidgetDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: genImport("dashboard_id", "dashboard_widget_id"),
+			StateContext: genImport("dashboard_id", "dashboard_widget_id"),
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"type": {
@@ -56,7 +58,7 @@ func resourceDashboardWidget() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				DiffSuppressFunc: schemaDiffSuppressJSONString,
-				ValidateFunc:     wrapValidateFunc(validateFuncDashboardWidgetJSONConfiguration),
+				ValidateDiagFunc: wrapValidateFunc(validateFuncDashboardWidgetJSONConfiguration),
 			},
 
 			"quick_values_configuration": {
@@ -477,8 +479,7 @@ func newDashboardWidget(d *schema.ResourceData) (*graylog.Widget, string, error)
 	}, d.Get("dashboard_id").(string), nil
 }
 
-func resourceDashboardWidgetCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardWidgetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -496,8 +497,7 @@ func resourceDashboardWidgetCreate(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardWidgetRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -648,8 +648,7 @@ func resourceDashboardWidgetRead(d *schema.ResourceData, m interface{}) error {
 	return setStrToRD(d, "creator_user_id", widget.CreatorUserID)
 }
 
-func resourceDashboardWidgetUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardWidgetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -676,8 +675,7 @@ func resourceDashboardWidgetUpdate(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceDashboardWidgetDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardWidgetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -3,22 +3,24 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourceDashboardWidgetPositions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDashboardWidgetPositionsCreate,
-		Read:   resourceDashboardWidgetPositionsRead,
-		Update: resourceDashboardWidgetPositionsUpdate,
-		Delete: resourceDashboardWidgetPositionsDelete,
+		CreateContext: wrapCRUD(resourceDashboardWidgetPositionsCreate),
+		ReadContext:   wrapCRUD(resourceDashboardWidgetPositionsRead),
+		UpdateContext: wrapCRUD(resourceDashboardWidgetPositionsUpdate),
+		DeleteContext: wrapCRUD(resourceDashboardWidgetPositionsDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"dashboard_id": {
@@ -77,8 +79,7 @@ func newDashboardWidgetPositions(d *schema.ResourceData) (
 	return positions, d.Get("dashboard_id").(string), nil
 }
 
-func resourceDashboardWidgetPositionsCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardWidgetPositionsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -94,8 +95,7 @@ func resourceDashboardWidgetPositionsCreate(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceDashboardWidgetPositionsRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardWidgetPositionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -120,8 +120,7 @@ func resourceDashboardWidgetPositionsRead(d *schema.ResourceData, m interface{})
 	return d.Set("positions", positions)
 }
 
-func resourceDashboardWidgetPositionsUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardWidgetPositionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -136,8 +135,7 @@ func resourceDashboardWidgetPositionsUpdate(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceDashboardWidgetPositionsDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceDashboardWidgetPositionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -0,0 +1,182 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// resourceEntityShare manages all grantees of an entity such as a stream with Graylog 4's entity shares API.
+func resourceEntityShare() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceEntityShareCreate),
+		ReadContext:   wrapCRUD(resourceEntityShareRead),
+		UpdateContext: wrapCRUD(resourceEntityShareUpdate),
+		DeleteContext: wrapCRUD(resourceEntityShareDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"entity_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: validateFuncOneOf(
+					graylog.GRNTypeStream, graylog.GRNTypeDashboard, graylog.GRNTypeSearch,
+					graylog.GRNTypeEventDefinition, graylog.GRNTypeEventNotification),
+			},
+			"entity_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Optional
+			// if no grantee is set, the entity is shared with nobody
+			"grantee": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validateFuncOneOf(
+								graylog.GRNTypeUser, graylog.GRNTypeTeam, graylog.GRNTypeBuiltinTeam),
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"capability": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validateFuncOneOf(
+								graylog.CapabilityView, graylog.CapabilityManage, graylog.CapabilityOwn),
+						},
+					},
+				},
+			},
+
+			// Computed
+			"grn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func newEntityShareRequest(d *schema.ResourceData) (*graylog.EntityShareRequest, error) {
+	capabilities := map[string]string{}
+	for _, a := range d.Get("grantee").(*schema.Set).List() {
+		grantee := a.(map[string]interface{})
+		grn := graylog.NewGRN(grantee["type"].(string), grantee["id"].(string)).String()
+		if _, ok := capabilities[grn]; ok {
+			return nil, &attrError{key: "grantee", err: fmt.Errorf("the grantee %s is duplicated", grn)}
+		}
+		capabilities[grn] = grantee["capability"].(string)
+	}
+	return &graylog.EntityShareRequest{SelectedGranteeCapabilities: capabilities}, nil
+}
+
+func resourceEntityShareCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	req, err := newEntityShareRequest(d)
+	if err != nil {
+		return err
+	}
+	grn := graylog.NewGRN(d.Get("entity_type").(string), d.Get("entity_id").(string)).String()
+	if _, _, err := cl.UpdateEntityShares(ctx, grn, req); err != nil {
+		return err
+	}
+	d.SetId(grn)
+	return resourceEntityShareRead(ctx, d, m)
+}
+
+func resourceEntityShareRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	entity, err := graylog.ParseGRN(d.Id())
+	if err != nil {
+		return err
+	}
+	resp, ei, err := cl.GetEntityShares(ctx, d.Id())
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	grantees := []interface{}{}
+	for k, capability := range resp.Grantees() {
+		grantee, err := graylog.ParseGRN(k)
+		if err != nil {
+			return err
+		}
+		grantees = append(grantees, map[string]interface{}{
+			"type":       grantee.Type,
+			"id":         grantee.ID,
+			"capability": capability,
+		})
+	}
+	if err := setStrToRD(d, "entity_type", entity.Type); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "entity_id", entity.ID); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "grn", d.Id()); err != nil {
+		return err
+	}
+	return setToRD(d, "grantee", grantees)
+}
+
+func resourceEntityShareUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	req, err := newEntityShareRequest(d)
+	if err != nil {
+		return err
+	}
+	if _, _, err := cl.UpdateEntityShares(ctx, d.Id(), req); err != nil {
+		return err
+	}
+	return resourceEntityShareRead(ctx, d, m)
+}
+
+func resourceEntityShareDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	req, err := newEntityShareRequest(d)
+	if err != nil {
+		return err
+	}
+	// owners are kept because Graylog doesn't allow an entity to be ownerless
+	for grn, capability := range req.SelectedGranteeCapabilities {
+		if capability != graylog.CapabilityOwn {
+			delete(req.SelectedGranteeCapabilities, grn)
+		}
+	}
+	if _, ei, err := cl.UpdateEntityShares(ctx, d.Id(), req); err != nil {
+		// the entity is deleted
+		if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// fakeEntityShareServer is a fake API of the shares of a stream.
+type fakeEntityShareServer struct {
+	fakeAPI
+	grn      string
+	grantees map[string]string
+}
+
+func (s *fakeEntityShareServer) handle(req *http.Request) (int, interface{}, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/api/authz/shares/entities/")
+	if req.Method != "POST" || strings.TrimSuffix(path, "/prepare") != s.grn {
+		return fakeNotFound()
+	}
+	shareReq := &graylog.EntityShareRequest{}
+	if err := json.NewDecoder(req.Body).Decode(shareReq); err != nil {
+		return 0, nil, err
+	}
+	validation := &graylog.ShareValidationResult{Errors: map[string][]string{}}
+	selected := shareReq.SelectedGranteeCapabilities
+	if selected != nil && !hasOwner(selected) && hasOwner(s.grantees) {
+		validation.Failed = true
+		validation.Errors["selected_grantee_capabilities"] = []string{"the entity will be ownerless"}
+	}
+	if !strings.HasSuffix(path, "/prepare") && !validation.Failed {
+		s.grantees = selected
+	}
+	shares := []graylog.ActiveShare{}
+	for grantee, capability := range s.grantees {
+		shares = append(shares, graylog.ActiveShare{Grantee: grantee, Capability: capability})
+	}
+	return 200, &graylog.EntityShareResponse{
+		Entity:           s.grn,
+		ActiveShares:     shares,
+		ValidationResult: validation,
+	}, nil
+}
+
+func hasOwner(grantees map[string]string) bool {
+	for _, capability := range grantees {
+		if capability == graylog.CapabilityOwn {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeEntityShareServer) checkGrantees(expected string) resource.TestCheckFunc {
+	return s.check(func() error {
+		if actual := fmt.Sprint(s.grantees); actual != expected {
+			return fmt.Errorf("grantees: expected %s, got %s", expected, actual)
+		}
+		return nil
+	})
+}
+
+func TestAccEntityShare(t *testing.T) {
+	setEnv()
+	streamID := "5d84c1a92ab79c000d35d6ca"
+	server := &fakeEntityShareServer{
+		grn:      "grn::::stream:" + streamID,
+		grantees: map[string]string{"grn::::user:admin": "own"},
+	}
+
+	defer server.use(t, server.handle)()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		// the owner is kept
+		CheckDestroy: server.checkGrantees("map[grn::::user:admin:own]"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "graylog_entity_share" "test" {
+  entity_type = "stream"
+  entity_id   = "` + streamID + `"
+  grantee {
+    type       = "user"
+    id         = "foo"
+    capability = "view"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`the entity will be ownerless`),
+			},
+			{
+				Config: `
+resource "graylog_entity_share" "test" {
+  entity_type = "stream"
+  entity_id   = "` + streamID + `"
+  grantee {
+    type       = "user"
+    id         = "admin"
+    capability = "own"
+  }
+  grantee {
+    type       = "user"
+    id         = "foo"
+    capability = "view"
+  }
+  grantee {
+    type       = "builtin-team"
+    id         = "everyone"
+    capability = "view"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_entity_share.test", "id", "grn::::stream:"+streamID),
+					resource.TestCheckResourceAttr("graylog_entity_share.test", "grantee.#", "3"),
+					server.checkGrantees("map[grn::::builtin-team:everyone:view grn::::user:admin:own grn::::user:foo:view]"),
+				),
+			},
+			{
+				ResourceName:      "graylog_entity_share.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the grantee which is added out of band is removed
+				PreConfig: func() {
+					server.update(func() {
+						server.grantees["grn::::user:bar"] = "manage"
+					})
+				},
+				Config: `
+resource "graylog_entity_share" "test" {
+  entity_type = "stream"
+  entity_id   = "` + streamID + `"
+  grantee {
+    type       = "user"
+    id         = "admin"
+    capability = "own"
+  }
+  grantee {
+    type       = "user"
+    id         = "foo"
+    capability = "manage"
+  }
+}
+`,
+				Check: server.checkGrantees("map[grn::::user:admin:own grn::::user:foo:manage]"),
+			},
+		},
+	})
+}
@@ -8,7 +8,8 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
 	"github.com/suzuki-shunsuke/go-set/v6"
 
@@ -17,15 +18,28 @@ import (
 
 func resourceEventDefinition() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceEventDefinitionCreate,
-		Read:   resourceEventDefinitionRead,
-		Update: resourceEventDefinitionUpdate,
-		Delete: resourceEventDefinitionDelete,
+		CreateContext: wrapCRUD(resourceEventDefinitionCreate),
+		ReadContext:   wrapCRUD(resourceEventDefinitionRead),
+		UpdateContext: wrapCRUD(resourceEventDefinitionUpdate),
+		DeleteContext: wrapCRUD(resourceEventDefinitionDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceEventDefinitionCustomizeDiff,
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceEventDefinitionV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceEventDefinitionStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"title": {
 				Type:     schema.TypeString,
@@ -34,7 +48,7 @@ func resourceEventDefinition() *schema.Resource {
 			"priority": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ValidateFunc: wrapValidateFunc(func(v interface{}, k string) error {
+				ValidateDiagFunc: wrapValidateFunc(func(v interface{}, k string) error {
 					priority := v.(int)
 					if priority < 1 || priority > 3 {
 						return errors.New("'priority' should be either 1, 2, and 3")
@@ -42,12 +56,15 @@ func resourceEventDefinition() *schema.Resource {
 					return nil
 				}),
 			},
+			// either config or aggregation is required
 			"config": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				ConflictsWith:    []string{"aggregation"},
 				DiffSuppressFunc: schemaDiffSuppressEventDefinitionConfig,
-				ValidateFunc:     validateFuncEventDefinitionConfig,
+				ValidateDiagFunc: validation.ToDiagFunc(validateFuncEventDefinitionConfig),
 			},
+			"aggregation": schemaEventDefinitionAggregation(),
 			"notification_settings": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -80,8 +97,9 @@ func resourceEventDefinition() *schema.Resource {
 				Optional:         true,
 				Default:          "{}",
 				DiffSuppressFunc: schemaDiffSuppressJSONString,
-				ValidateFunc:     wrapValidateFunc(validateFuncEventDefinitionFieldSpec),
+				ValidateDiagFunc: wrapValidateFunc(validateFuncEventDefinitionFieldSpec),
 			},
+			"field": schemaEventDefinitionField(),
 			"notifications": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -122,6 +140,9 @@ func validateFuncEventDefinitionFieldSpec(v interface{}, k string) error {
 }
 
 func getFieldSpec(d *schema.ResourceData) (map[string]graylog.EventDefinitionFieldSpec, error) {
+	if d.Get("field").(*schema.Set).Len() != 0 {
+		return getDefinitionFields(d), nil
+	}
 	s := strings.TrimSpace(d.Get("field_spec").(string))
 	if len(s) == 0 {
 		return nil, nil
@@ -220,8 +241,11 @@ func validateFuncEventDefinitionConfig(v interface{}, k string) (s []string, es
 	return
 }
 
-func getDefinitionCfg(d *schema.ResourceData) (map[string]interface{}, error) {
+func getDefinitionCfg(d *schema.ResourceData) (graylog.EventDefinitionConfig, error) {
 	cfgS := d.Get("config").(string)
+	if cfgS == "" {
+		return getDefinitionAggregation(d)
+	}
 	c, err := jsoneq.ConvertByte([]byte(cfgS))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse the 'config'. 'config' must be a JSON string '%s': %w", cfgS, err)
@@ -249,7 +273,11 @@ func getDefinitionCfg(d *schema.ResourceData) (map[string]interface{}, error) {
 			}
 		}
 	}
-	return cfg, nil
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return graylog.UnmarshalEventDefinitionConfig(b)
 }
 
 func getDefinitionSettings(d *schema.ResourceData) graylog.EventDefinitionNotificationSettings {
@@ -306,8 +334,7 @@ func newEventDefinition(d *schema.ResourceData) (*graylog.EventDefinition, error
 	}, nil
 }
 
-func resourceEventDefinitionCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceEventDefinitionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -323,8 +350,7 @@ func resourceEventDefinitionCreate(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceEventDefinitionRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceEventDefinitionRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -333,6 +359,10 @@ func resourceEventDefinitionRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setEventDefinition(d, notif)
+}
+
+func setEventDefinition(d *schema.ResourceData, notif *graylog.EventDefinition) error {
 	if err := setStrToRD(d, "title", notif.Title); err != nil {
 		return err
 	}
@@ -353,25 +383,38 @@ func resourceEventDefinitionRead(d *schema.ResourceData, m interface{}) error {
 	}); err != nil {
 		return err
 	}
-	b, err := json.Marshal(notif.FieldSpec)
-	if err != nil {
+	if err := setStrListToRD(d, "key_spec", notif.KeySpec.ToList()); err != nil {
 		return err
 	}
-	if err := setStrToRD(d, "field_spec", string(b)); err != nil {
-		return err
+	// the structured blocks are used only if they are used in the configuration,
+	// otherwise the JSON string attributes are used for backward compatibility.
+	if d.Get("field").(*schema.Set).Len() != 0 {
+		if err := d.Set("field", flattenDefinitionFields(notif.FieldSpec)); err != nil {
+			return err
+		}
+	} else {
+		b, err := json.Marshal(notif.FieldSpec)
+		if err != nil {
+			return err
+		}
+		if err := setStrToRD(d, "field_spec", string(b)); err != nil {
+			return err
+		}
 	}
-	b, err = json.Marshal(notif.Config)
+	if agg, ok := notif.Config.(*graylog.EventDefinitionConfigAggregation); ok && len(d.Get("aggregation").([]interface{})) != 0 {
+		return d.Set("aggregation", flattenDefinitionAggregation(agg))
+	}
+	b, err := json.Marshal(notif.Config)
 	if err != nil {
 		return err
 	}
-	if err := setStrListToRD(d, "key_spec", notif.KeySpec.ToList()); err != nil {
+	if err := d.Set("aggregation", nil); err != nil {
 		return err
 	}
 	return setStrToRD(d, "config", string(b))
 }
 
-func resourceEventDefinitionUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceEventDefinitionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -384,8 +427,7 @@ func resourceEventDefinitionUpdate(d *schema.ResourceData, m interface{}) error
 	return err
 }
 
-func resourceEventDefinitionDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceEventDefinitionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
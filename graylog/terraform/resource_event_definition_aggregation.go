@@ -0,0 +1,300 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+func schemaEventDefinitionAggregation() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"config"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"search_within": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: schemaDiffSuppressDuration,
+					ValidateDiagFunc: wrapValidateFunc(validateFuncDuration),
+				},
+				"execute_every": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: schemaDiffSuppressDuration,
+					ValidateDiagFunc: wrapValidateFunc(validateFuncDuration),
+				},
+				"query": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"streams": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"group_by": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"series": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"function": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"field": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"conditions": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: schemaDiffSuppressEventDefinitionConditions,
+					ValidateDiagFunc: wrapValidateFunc(validateFuncEventDefinitionConditions),
+				},
+			},
+		},
+	}
+}
+
+func schemaEventDefinitionField() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeSet,
+		Optional:      true,
+		ConflictsWith: []string{"field_spec"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"data_type": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "string",
+				},
+				"provider": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"template": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"require_values": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"table_name": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"key_field": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateFuncEventDefinitionConditions(v interface{}, k string) error {
+	if _, err := graylog.ParseEventDefinitionConditions(v.(string)); err != nil {
+		return fmt.Errorf("failed to parse the '%s': %w", k, err)
+	}
+	return nil
+}
+
+func schemaDiffSuppressEventDefinitionConditions(k, oldV, newV string, d *schema.ResourceData) bool {
+	oldE, err := graylog.ParseEventDefinitionConditions(oldV)
+	if err != nil {
+		return false
+	}
+	newE, err := graylog.ParseEventDefinitionConditions(newV)
+	if err != nil {
+		return false
+	}
+	return oldE.String() == newE.String()
+}
+
+// resourceEventDefinitionCustomizeDiff checks that the aggregation's conditions refer to defined series.
+func resourceEventDefinitionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	a := d.Get("aggregation").([]interface{})
+	if len(a) == 0 || a[0] == nil {
+		return nil
+	}
+	agg := a[0].(map[string]interface{})
+	expr, err := graylog.ParseEventDefinitionConditions(agg["conditions"].(string))
+	if err != nil {
+		return err
+	}
+	ids := map[string]struct{}{}
+	for _, b := range agg["series"].([]interface{}) {
+		id := b.(map[string]interface{})["id"].(string)
+		if id == "" {
+			// the id isn't known until apply
+			return nil
+		}
+		ids[id] = struct{}{}
+	}
+	for _, ref := range expr.Refs() {
+		if _, ok := ids[ref]; !ok {
+			return fmt.Errorf("the conditions refer to the undefined series '%s'", ref)
+		}
+	}
+	return nil
+}
+
+func getDefinitionAggregation(d *schema.ResourceData) (*graylog.EventDefinitionConfigAggregation, error) {
+	a := d.Get("aggregation").([]interface{})
+	if len(a) == 0 || a[0] == nil {
+		return nil, errors.New("either 'config' or 'aggregation' is required")
+	}
+	agg := a[0].(map[string]interface{})
+	searchWithin, err := parseDurationMS(agg["search_within"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the 'aggregation.search_within': %w", err)
+	}
+	executeEvery, err := parseDurationMS(agg["execute_every"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the 'aggregation.execute_every': %w", err)
+	}
+
+	b := agg["series"].([]interface{})
+	series := make([]graylog.EventDefinitionConfigSeries, len(b))
+	for i, c := range b {
+		s := c.(map[string]interface{})
+		series[i] = graylog.EventDefinitionConfigSeries{
+			ID:       s["id"].(string),
+			Function: s["function"].(string),
+			Field:    s["field"].(string),
+		}
+	}
+
+	streams := getStringArray(agg["streams"].(*schema.Set).List())
+	sort.Strings(streams)
+	cfg := &graylog.EventDefinitionConfigAggregation{
+		Query:          agg["query"].(string),
+		Streams:        streams,
+		GroupBy:        getStringArray(agg["group_by"].([]interface{})),
+		Series:         series,
+		SearchWithinMS: searchWithin,
+		ExecuteEveryMS: executeEvery,
+	}
+	expr, err := graylog.ParseEventDefinitionConditions(agg["conditions"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the 'aggregation.conditions': %w", err)
+	}
+	if expr != nil {
+		cfg.Conditions = &graylog.EventDefinitionConfigConditions{Expression: expr}
+	}
+	return cfg, nil
+}
+
+func flattenDefinitionAggregation(cfg *graylog.EventDefinitionConfigAggregation) []map[string]interface{} {
+	series := make([]map[string]interface{}, len(cfg.Series))
+	for i, s := range cfg.Series {
+		series[i] = map[string]interface{}{
+			"id":       s.ID,
+			"function": s.Function,
+			"field":    s.Field,
+		}
+	}
+	conditions := ""
+	if cfg.Conditions != nil {
+		conditions = cfg.Conditions.Expression.String()
+	}
+	return []map[string]interface{}{
+		{
+			"query":         cfg.Query,
+			"streams":       cfg.Streams,
+			"group_by":      cfg.GroupBy,
+			"series":        series,
+			"conditions":    conditions,
+			"search_within": util.FormatDurationMS(cfg.SearchWithinMS),
+			"execute_every": util.FormatDurationMS(cfg.ExecuteEveryMS),
+		},
+	}
+}
+
+func getDefinitionFields(d *schema.ResourceData) map[string]graylog.EventDefinitionFieldSpec {
+	a := d.Get("field").(*schema.Set).List()
+	spec := make(map[string]graylog.EventDefinitionFieldSpec, len(a))
+	for _, b := range a {
+		field := b.(map[string]interface{})
+		c := field["provider"].([]interface{})
+		providers := make([]graylog.EventDefinitionFieldSpecProvider, len(c))
+		for i, e := range c {
+			p := e.(map[string]interface{})
+			providers[i] = graylog.EventDefinitionFieldSpecProvider{
+				Type:          p["type"].(string),
+				Template:      p["template"].(string),
+				RequireValues: p["require_values"].(bool),
+				TableName:     p["table_name"].(string),
+				KeyField:      p["key_field"].(string),
+			}
+		}
+		spec[field["name"].(string)] = graylog.EventDefinitionFieldSpec{
+			DataType:  field["data_type"].(string),
+			Providers: providers,
+		}
+	}
+	return spec
+}
+
+func flattenDefinitionFields(spec map[string]graylog.EventDefinitionFieldSpec) []map[string]interface{} {
+	names := make([]string, 0, len(spec))
+	for name := range spec {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fields := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		s := spec[name]
+		providers := make([]map[string]interface{}, len(s.Providers))
+		for j, p := range s.Providers {
+			providers[j] = map[string]interface{}{
+				"type":           p.Type,
+				"template":       p.Template,
+				"require_values": p.RequireValues,
+				"table_name":     p.TableName,
+				"key_field":      p.KeyField,
+			}
+		}
+		fields[i] = map[string]interface{}{
+			"name":      name,
+			"data_type": s.DataType,
+			"provider":  providers,
+		}
+	}
+	return fields
+}
@@ -0,0 +1,26 @@
+package terraform
+
+import (
+	"testing"
+)
+
+func TestAccEventDefinition(t *testing.T) {
+	setEnv()
+
+	tc := &testCase{
+		t:          t,
+		Name:       "event definition",
+		CreatePath: "/api/events/definitions",
+		GetPath:    "/api/events/definitions/5de5a9e7a1de18000cdfe192",
+
+		CreateReqBodyPath:  "event_definition/create_aggregation_request.json",
+		UpdateReqBodyPath:  "event_definition/update_aggregation_request.json",
+		CreatedDataPath:    "event_definition/aggregation.json",
+		UpdatedDataPath:    "event_definition/update_aggregation.json",
+		CreateRespBodyPath: "event_definition/aggregation.json",
+		UpdateRespBodyPath: "event_definition/update_aggregation.json",
+		CreateTFPath:       "event_definition/aggregation.tf",
+		UpdateTFPath:       "event_definition/update_aggregation.tf",
+	}
+	tc.Test()
+}
@@ -0,0 +1,99 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// resourceEventDefinitionV0 returns the schema of graylog_event_definition before
+// the attributes "aggregation" and "field" were added.
+func resourceEventDefinitionV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"priority": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"config": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"notification_settings": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"grace_period_ms": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"backlog_size": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"alert": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"field_spec": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "{}",
+			},
+			"notifications": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"notification_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"key_spec": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourceEventDefinitionStateUpgradeV0 keeps using the JSON string "config"
+// and normalizes it with the typed configuration so that the state equals what Read sets.
+func resourceEventDefinitionStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	s, ok := rawState["config"].(string)
+	if !ok || s == "" {
+		return rawState, nil
+	}
+	cfg, err := graylog.UnmarshalEventDefinitionConfig([]byte(s))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the 'config' of the state: %w", err)
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	rawState["config"] = string(b)
+	return rawState, nil
+}
@@ -5,23 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourceEventNotification() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceEventNotificationCreate,
-		Read:   resourceEventNotificationRead,
-		Update: resourceEventNotificationUpdate,
-		Delete: resourceEventNotificationDelete,
+		CreateContext: wrapCRUD(resourceEventNotificationCreate),
+		ReadContext:   wrapCRUD(resourceEventNotificationRead),
+		UpdateContext: wrapCRUD(resourceEventNotificationUpdate),
+		DeleteContext: wrapCRUD(resourceEventNotificationDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"title": {
 				Type:     schema.TypeString,
@@ -31,21 +32,37 @@ func resourceEventNotification() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			// one of config, email, http and slack is required
 			"config": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				ConflictsWith:    conflictingEventNotificationConfigKeys("config"),
 				DiffSuppressFunc: schemaDiffSuppressJSONString,
 			},
+			"email": schemaEventNotificationEmail(),
+			"http":  schemaEventNotificationHTTP(),
+			"slack": schemaEventNotificationSlack(),
 		},
 	}
 }
 
-func newEventNotification(d *schema.ResourceData) (*graylog.EventNotification, error) {
+func getEventNotificationConfig(d *schema.ResourceData) (graylog.EventNotificationConfig, error) {
 	cfgS := d.Get("config").(string)
-	cfg, err := jsoneq.ConvertByte([]byte(cfgS))
+	if cfgS == "" {
+		return getEventNotificationBlockConfig(d)
+	}
+	cfg, err := graylog.UnmarshalEventNotificationConfig([]byte(cfgS))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse the 'config'. 'config' must be a JSON string '%s': %w", cfgS, err)
 	}
+	return cfg, nil
+}
+
+func newEventNotification(d *schema.ResourceData) (*graylog.EventNotification, error) {
+	cfg, err := getEventNotificationConfig(d)
+	if err != nil {
+		return nil, err
+	}
 	return &graylog.EventNotification{
 		ID:          d.Id(),
 		Title:       d.Get("title").(string),
@@ -54,8 +71,7 @@ func newEventNotification(d *schema.ResourceData) (*graylog.EventNotification, e
 	}, nil
 }
 
-func resourceEventNotificationCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceEventNotificationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -71,8 +87,7 @@ func resourceEventNotificationCreate(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceEventNotificationRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceEventNotificationRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -81,12 +96,28 @@ func resourceEventNotificationRead(d *schema.ResourceData, m interface{}) error
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setEventNotification(d, notif)
+}
+
+func setEventNotification(d *schema.ResourceData, notif *graylog.EventNotification) error {
 	if err := setStrToRD(d, "title", notif.Title); err != nil {
 		return err
 	}
 	if err := setStrToRD(d, "description", notif.Description); err != nil {
 		return err
 	}
+	// a block is used only if it is used in the configuration and matches the type,
+	// otherwise the JSON string "config" is used.
+	if _, key := getEventNotificationBlock(d); key != "" {
+		if k, block := flattenEventNotificationConfig(notif.Config); k == key {
+			return d.Set(key, block)
+		}
+	}
+	for _, key := range eventNotificationConfigKeys[1:] {
+		if err := d.Set(key, nil); err != nil {
+			return err
+		}
+	}
 	b, err := json.Marshal(notif.Config)
 	if err != nil {
 		return err
@@ -94,8 +125,7 @@ func resourceEventNotificationRead(d *schema.ResourceData, m interface{}) error
 	return setStrToRD(d, "config", string(b))
 }
 
-func resourceEventNotificationUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceEventNotificationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -108,8 +138,7 @@ func resourceEventNotificationUpdate(d *schema.ResourceData, m interface{}) erro
 	return err
 }
 
-func resourceEventNotificationDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceEventNotificationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
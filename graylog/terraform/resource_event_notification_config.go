@@ -0,0 +1,268 @@
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// eventNotificationConfigKeys are the attributes which represent the event notification's configuration.
+// Only one of them can be set.
+var eventNotificationConfigKeys = []string{"config", "email", "http", "slack"}
+
+func conflictingEventNotificationConfigKeys(key string) []string {
+	keys := make([]string, 0, len(eventNotificationConfigKeys)-1)
+	for _, k := range eventNotificationConfigKeys {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func schemaEventNotificationEmail() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflictingEventNotificationConfigKeys("email"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"subject": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"sender": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: wrapValidateFunc(validateFuncEmailAddress),
+				},
+				"reply_to": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: wrapValidateFunc(validateFuncEmailAddress),
+				},
+				"body_template": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"html_body_template": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"email_recipients": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: wrapValidateFunc(validateFuncEmailAddress),
+					},
+				},
+				"user_recipients": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"time_zone": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func schemaEventNotificationHTTP() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflictingEventNotificationConfigKeys("http"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"url": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateFuncURL("http", "https"),
+				},
+			},
+		},
+	}
+}
+
+func schemaEventNotificationSlack() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflictingEventNotificationConfigKeys("slack"),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"webhook_url": {
+					Type:             schema.TypeString,
+					Required:         true,
+					Sensitive:        true,
+					ValidateDiagFunc: validateFuncURL("https"),
+				},
+				"channel": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"color": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"custom_message": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"backlog_size": {
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+				"user_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"notify_channel": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"link_names": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"icon_url": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateFuncURL("http", "https"),
+				},
+				"icon_emoji": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"time_zone": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func validateFuncEmailAddress(v interface{}, k string) error {
+	s := v.(string)
+	if s == "" {
+		return nil
+	}
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return fmt.Errorf("'%s' must be a valid email address: %w", k, err)
+	}
+	if addr.Name != "" {
+		return fmt.Errorf("'%s' must be an email address without a display name: %s", k, s)
+	}
+	return nil
+}
+
+// getEventNotificationBlock returns the attribute of the block and the block's name.
+// If no block is set, the name is empty.
+func getEventNotificationBlock(d *schema.ResourceData) (map[string]interface{}, string) {
+	for _, key := range eventNotificationConfigKeys[1:] {
+		a := d.Get(key).([]interface{})
+		if len(a) == 0 || a[0] == nil {
+			continue
+		}
+		return a[0].(map[string]interface{}), key
+	}
+	return nil, ""
+}
+
+func getEventNotificationBlockConfig(d *schema.ResourceData) (graylog.EventNotificationConfig, error) {
+	a, key := getEventNotificationBlock(d)
+	switch key {
+	case "email":
+		// sort recipients to make the request deterministic because the order of a set isn't stable
+		emails := getStringArray(a["email_recipients"].(*schema.Set).List())
+		sort.Strings(emails)
+		users := getStringArray(a["user_recipients"].(*schema.Set).List())
+		sort.Strings(users)
+		return &graylog.EventNotificationConfigEmail{
+			Sender:           a["sender"].(string),
+			Subject:          a["subject"].(string),
+			ReplyTo:          a["reply_to"].(string),
+			BodyTemplate:     a["body_template"].(string),
+			HTMLBodyTemplate: a["html_body_template"].(string),
+			EmailRecipients:  emails,
+			UserRecipients:   users,
+			TimeZone:         a["time_zone"].(string),
+		}, nil
+	case "http":
+		return &graylog.EventNotificationConfigHTTP{
+			URL: a["url"].(string),
+		}, nil
+	case "slack":
+		return &graylog.EventNotificationConfigSlack{
+			WebhookURL:    a["webhook_url"].(string),
+			Channel:       a["channel"].(string),
+			Color:         a["color"].(string),
+			CustomMessage: a["custom_message"].(string),
+			BacklogSize:   a["backlog_size"].(int),
+			UserName:      a["user_name"].(string),
+			NotifyChannel: a["notify_channel"].(bool),
+			LinkNames:     a["link_names"].(bool),
+			IconURL:       a["icon_url"].(string),
+			IconEmoji:     a["icon_emoji"].(string),
+			TimeZone:      a["time_zone"].(string),
+		}, nil
+	}
+	return nil, errors.New("one of 'config', 'email', 'http' and 'slack' is required")
+}
+
+// flattenEventNotificationConfig returns the block's name and attribute of the configuration.
+// If the configuration can't be represented as a block, the name is empty.
+func flattenEventNotificationConfig(cfg graylog.EventNotificationConfig) (string, []map[string]interface{}) {
+	switch c := cfg.(type) {
+	case *graylog.EventNotificationConfigEmail:
+		return "email", []map[string]interface{}{
+			{
+				"sender":             c.Sender,
+				"subject":            c.Subject,
+				"reply_to":           c.ReplyTo,
+				"body_template":      c.BodyTemplate,
+				"html_body_template": c.HTMLBodyTemplate,
+				"email_recipients":   c.EmailRecipients,
+				"user_recipients":    c.UserRecipients,
+				"time_zone":          c.TimeZone,
+			},
+		}
+	case *graylog.EventNotificationConfigHTTP:
+		return "http", []map[string]interface{}{
+			{
+				"url": c.URL,
+			},
+		}
+	case *graylog.EventNotificationConfigSlack:
+		return "slack", []map[string]interface{}{
+			{
+				"webhook_url":    c.WebhookURL,
+				"channel":        c.Channel,
+				"color":          c.Color,
+				"custom_message": c.CustomMessage,
+				"backlog_size":   c.BacklogSize,
+				"user_name":      c.UserName,
+				"notify_channel": c.NotifyChannel,
+				"link_names":     c.LinkNames,
+				"icon_url":       c.IconURL,
+				"icon_emoji":     c.IconEmoji,
+				"time_zone":      c.TimeZone,
+			},
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,26 @@
+package terraform
+
+import (
+	"testing"
+)
+
+func TestAccEventNotification(t *testing.T) {
+	setEnv()
+
+	tc := &testCase{
+		t:          t,
+		Name:       "event notification",
+		CreatePath: "/api/events/notifications",
+		GetPath:    "/api/events/notifications/5de5a365a1de18000cdfdf4a",
+
+		CreateReqBodyPath:  "event_notification/create_email_request.json",
+		UpdateReqBodyPath:  "event_notification/update_email_request.json",
+		CreatedDataPath:    "event_notification/email.json",
+		UpdatedDataPath:    "event_notification/update_email.json",
+		CreateRespBodyPath: "event_notification/email.json",
+		UpdateRespBodyPath: "event_notification/update_email.json",
+		CreateTFPath:       "event_notification/email.tf",
+		UpdateTFPath:       "event_notification/update_email.tf",
+	}
+	tc.Test()
+}
@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
@@ -13,15 +13,17 @@ import (
 
 func resourceExtractor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceExtractorCreate,
-		Read:   resourceExtractorRead,
-		Update: resourceExtractorUpdate,
-		Delete: resourceExtractorDelete,
+		CreateContext: wrapCRUD(resourceExtractorCreate),
+		ReadContext:   wrapCRUD(resourceExtractorRead),
+		UpdateContext: wrapCRUD(resourceExtractorUpdate),
+		DeleteContext: wrapCRUD(resourceExtractorDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: genImport("input_id", "extractor_id"),
+			StateContext: genImport("input_id", "extractor_id"),
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"input_id": {
 				Type:     schema.TypeString,
@@ -136,6 +138,10 @@ func resourceExtractor() *schema.Resource {
 							Type:     schema.TypeString,
 							Required: true,
 						},
+						"named_captures_only": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
 					},
 				},
 			},
@@ -228,7 +234,8 @@ func newExtractorConfig(d *schema.ResourceData, t string) interface{} {
 	case "grok":
 		a := d.Get("grok_type_extractor_config").([]interface{})[0].(map[string]interface{})
 		return &graylog.ExtractorTypeGrokConfig{
-			GrokPattern: a["grok_pattern"].(string),
+			GrokPattern:       a["grok_pattern"].(string),
+			NamedCapturesOnly: a["named_captures_only"].(bool),
 		}
 	case "regex":
 		a := d.Get("regex_type_extractor_config").([]interface{})[0].(map[string]interface{})
@@ -290,8 +297,7 @@ func newExtractor(d *schema.ResourceData) (*graylog.Extractor, string, error) {
 	}, d.Get("input_id").(string), nil
 }
 
-func resourceExtractorCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceExtractorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -308,8 +314,7 @@ func resourceExtractorCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceExtractorRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceExtractorRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -415,8 +420,7 @@ func resourceExtractorRead(d *schema.ResourceData, m interface{}) error {
 	return setIntToRD(d, "order", extractor.Order)
 }
 
-func resourceExtractorUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceExtractorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -431,8 +435,7 @@ func resourceExtractorUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceExtractorDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceExtractorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -3,22 +3,24 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourceGrokPattern() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGrokPatternCreate,
-		Read:   resourceGrokPatternRead,
-		Update: resourceGrokPatternUpdate,
-		Delete: resourceGrokPatternDelete,
+		CreateContext: wrapCRUD(resourceGrokPatternCreate),
+		ReadContext:   wrapCRUD(resourceGrokPatternRead),
+		UpdateContext: wrapCRUD(resourceGrokPatternUpdate),
+		DeleteContext: wrapCRUD(resourceGrokPatternDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -40,8 +42,7 @@ func newGrokPattern(d *schema.ResourceData) *graylog.GrokPattern {
 	}
 }
 
-func resourceGrokPatternCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceGrokPatternCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -54,8 +55,7 @@ func resourceGrokPatternCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceGrokPatternRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceGrokPatternRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -64,14 +64,17 @@ func resourceGrokPatternRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setGrokPattern(d, grokPattern)
+}
+
+func setGrokPattern(d *schema.ResourceData, grokPattern *graylog.GrokPattern) error {
 	if err := setStrToRD(d, "name", grokPattern.Name); err != nil {
 		return err
 	}
 	return setStrToRD(d, "pattern", grokPattern.Pattern)
 }
 
-func resourceGrokPatternUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceGrokPatternUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -81,8 +84,7 @@ func resourceGrokPatternUpdate(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceGrokPatternDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceGrokPatternDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -0,0 +1,267 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/grok"
+)
+
+func resourceGrokPatterns() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceGrokPatternsCreate),
+		ReadContext:   wrapCRUD(resourceGrokPatternsRead),
+		UpdateContext: wrapCRUD(resourceGrokPatternsUpdate),
+		DeleteContext: wrapCRUD(resourceGrokPatternsDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGrokPatternsImport,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// the content of a grok pattern file. Each line is "NAME PATTERN".
+			"content": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: schemaDiffSuppressGrokPatterns,
+				ValidateDiagFunc: wrapValidateFunc(validateFuncGrokPatterns),
+			},
+			// if true, all grok patterns which aren't included in the content are removed.
+			"replace_all": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// computed
+			"pattern_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func parseGrokPatterns(content string) ([]graylog.GrokPattern, error) {
+	return grok.ParsePatterns(strings.NewReader(content))
+}
+
+func grokPatternsToMap(patterns []graylog.GrokPattern) map[string]string {
+	m := make(map[string]string, len(patterns))
+	for _, p := range patterns {
+		m[p.Name] = p.Pattern
+	}
+	return m
+}
+
+func validateFuncGrokPatterns(v interface{}, k string) error {
+	_, err := parseGrokPatterns(v.(string))
+	return err
+}
+
+func schemaDiffSuppressGrokPatterns(k, oldV, newV string, d *schema.ResourceData) bool {
+	oldPatterns, err := parseGrokPatterns(oldV)
+	if err != nil {
+		return false
+	}
+	newPatterns, err := parseGrokPatterns(newV)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(grokPatternsToMap(oldPatterns), grokPatternsToMap(newPatterns))
+}
+
+func resourceGrokPatternsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.ImportGrokPatterns(
+		ctx, strings.NewReader(d.Get("content").(string)), d.Get("replace_all").(bool),
+	); err != nil {
+		return err
+	}
+	d.SetId(id.UniqueId())
+	return resourceGrokPatternsRead(ctx, d, m)
+}
+
+func resourceGrokPatternsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	patterns, err := parseGrokPatterns(d.Get("content").(string))
+	if err != nil {
+		return err
+	}
+	current, _, err := cl.GetGrokPatterns(ctx)
+	if err != nil {
+		return err
+	}
+	currentMap := make(map[string]graylog.GrokPattern, len(current))
+	for _, p := range current {
+		currentMap[p.Name] = p
+	}
+	found := []graylog.GrokPattern{}
+	ids := map[string]string{}
+	for _, p := range patterns {
+		if c, ok := currentMap[p.Name]; ok {
+			found = append(found, c)
+			ids[c.Name] = c.ID
+		}
+	}
+	if d.Get("replace_all").(bool) {
+		// the patterns which are added out of band are shown as drift, because they are removed at the next update
+		names := grokPatternsToMap(patterns)
+		extra := []graylog.GrokPattern{}
+		for _, c := range current {
+			if _, ok := names[c.Name]; !ok {
+				extra = append(extra, c)
+				ids[c.Name] = c.ID
+			}
+		}
+		sort.Slice(extra, func(i, j int) bool {
+			return extra[i].Name < extra[j].Name
+		})
+		found = append(found, extra...)
+	}
+	if len(found) == 0 {
+		d.SetId("")
+		return nil
+	}
+	if err := setStrToRD(d, "content", grok.FormatPatterns(found)); err != nil {
+		return err
+	}
+	return setMapStrToStrToRD(d, "pattern_ids", ids)
+}
+
+func resourceGrokPatternsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	o, n := d.GetChange("content")
+	newPatterns, err := parseGrokPatterns(n.(string))
+	if err != nil {
+		return err
+	}
+	if d.Get("replace_all").(bool) {
+		if _, err := cl.UpdateGrokPatterns(ctx, newPatterns, true); err != nil {
+			return err
+		}
+		return resourceGrokPatternsRead(ctx, d, m)
+	}
+	oldPatterns, err := parseGrokPatterns(o.(string))
+	if err != nil {
+		return err
+	}
+	current, _, err := cl.GetGrokPatterns(ctx)
+	if err != nil {
+		return err
+	}
+	currentMap := make(map[string]graylog.GrokPattern, len(current))
+	for _, p := range current {
+		currentMap[p.Name] = p
+	}
+	newMap := grokPatternsToMap(newPatterns)
+	for _, p := range oldPatterns {
+		if _, ok := newMap[p.Name]; ok {
+			continue
+		}
+		if c, ok := currentMap[p.Name]; ok {
+			if _, err := cl.DeleteGrokPattern(ctx, c.ID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, p := range newPatterns {
+		p := p
+		c, ok := currentMap[p.Name]
+		if !ok {
+			if _, err := cl.CreateGrokPattern(ctx, &p); err != nil {
+				return err
+			}
+			continue
+		}
+		if c.Pattern == p.Pattern {
+			continue
+		}
+		p.ID = c.ID
+		if _, err := cl.UpdateGrokPattern(ctx, &p); err != nil {
+			return err
+		}
+	}
+	return resourceGrokPatternsRead(ctx, d, m)
+}
+
+func resourceGrokPatternsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	patterns, err := parseGrokPatterns(d.Get("content").(string))
+	if err != nil {
+		return err
+	}
+	current, _, err := cl.GetGrokPatterns(ctx)
+	if err != nil {
+		return err
+	}
+	names := grokPatternsToMap(patterns)
+	for _, p := range current {
+		if _, ok := names[p.Name]; !ok {
+			continue
+		}
+		if _, err := cl.DeleteGrokPattern(ctx, p.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceGrokPatternsImport imports the grok patterns whose names are given as the comma separated ID.
+func resourceGrokPatternsImport(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) ([]*schema.ResourceData, error) {
+	if d.Id() == "" {
+		return nil, errors.New("format of import argument should be <pattern name>[,<pattern name>...]")
+	}
+	cl, err := newClient(m)
+	if err != nil {
+		return nil, err
+	}
+	current, _, err := cl.GetGrokPatterns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currentMap := make(map[string]graylog.GrokPattern, len(current))
+	for _, p := range current {
+		currentMap[p.Name] = p
+	}
+	patterns := []graylog.GrokPattern{}
+	for _, name := range strings.Split(d.Id(), ",") {
+		name = strings.TrimSpace(name)
+		p, ok := currentMap[name]
+		if !ok {
+			return nil, fmt.Errorf("grok pattern %s isn't found", name)
+		}
+		patterns = append(patterns, p)
+	}
+	if err := setStrToRD(d, "content", grok.FormatPatterns(patterns)); err != nil {
+		return nil, err
+	}
+	d.SetId(id.UniqueId())
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,192 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// fakeGrokPatternServer is a fake API of grok patterns.
+type fakeGrokPatternServer struct {
+	fakeAPI
+	// the key is the pattern name
+	patterns map[string]graylog.GrokPattern
+	count    int
+}
+
+func (s *fakeGrokPatternServer) save(p graylog.GrokPattern) graylog.GrokPattern {
+	if c, ok := s.patterns[p.Name]; ok {
+		p.ID = c.ID
+	} else {
+		s.count++
+		p.ID = fmt.Sprintf("pattern%d", s.count)
+	}
+	s.patterns[p.Name] = p
+	return p
+}
+
+func (s *fakeGrokPatternServer) handle(req *http.Request) (int, interface{}, error) {
+	const patternsPath = "/api/system/grok"
+	switch {
+	case req.URL.Path == patternsPath && req.Method == "GET":
+		patterns := []graylog.GrokPattern{}
+		for _, p := range s.patterns {
+			patterns = append(patterns, p)
+		}
+		sort.Slice(patterns, func(i, j int) bool {
+			return patterns[i].Name < patterns[j].Name
+		})
+		return 200, &graylog.GrokPatternsBody{Patterns: patterns}, nil
+	case req.URL.Path == patternsPath && req.Method == "PUT":
+		body := &graylog.GrokPatternsBody{}
+		if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+			return 0, nil, err
+		}
+		if req.URL.Query().Get("replace") == "true" {
+			s.patterns = map[string]graylog.GrokPattern{}
+		}
+		for _, p := range body.Patterns {
+			s.save(p)
+		}
+		return 204, nil, nil
+	case req.URL.Path == patternsPath && req.Method == "POST":
+		p := graylog.GrokPattern{}
+		if err := json.NewDecoder(req.Body).Decode(&p); err != nil {
+			return 0, nil, err
+		}
+		return 201, s.save(p), nil
+	case strings.HasPrefix(req.URL.Path, patternsPath+"/"):
+		id := strings.TrimPrefix(req.URL.Path, patternsPath+"/")
+		for name, c := range s.patterns {
+			if c.ID != id {
+				continue
+			}
+			switch req.Method {
+			case "PUT":
+				p := graylog.GrokPattern{}
+				if err := json.NewDecoder(req.Body).Decode(&p); err != nil {
+					return 0, nil, err
+				}
+				delete(s.patterns, name)
+				s.patterns[p.Name] = p
+				return 200, p, nil
+			case "DELETE":
+				delete(s.patterns, name)
+				return 204, nil, nil
+			}
+		}
+	}
+	return fakeNotFound()
+}
+
+func (s *fakeGrokPatternServer) checkNames(expected string) resource.TestCheckFunc {
+	return s.check(func() error {
+		names := make([]string, 0, len(s.patterns))
+		for name := range s.patterns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if actual := strings.Join(names, ","); actual != expected {
+			return fmt.Errorf("grok patterns: expected %s, got %s", expected, actual)
+		}
+		return nil
+	})
+}
+
+func TestAccGrokPatterns(t *testing.T) {
+	setEnv()
+	server := &fakeGrokPatternServer{
+		patterns: map[string]graylog.GrokPattern{
+			"OTHER": {ID: "other", Name: "OTHER", Pattern: "other"},
+		},
+	}
+	defer server.use(t, server.handle)()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy:      server.checkNames(""),
+		Steps: []resource.TestStep{
+			{
+				// the patterns which aren't managed are kept
+				Config: `
+resource "graylog_grok_patterns" "test" {
+  content = <<EOS
+# comment
+FOO foo
+
+BAR bar
+EOS
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_grok_patterns.test", "pattern_ids.%", "2"),
+					server.checkNames("BAR,FOO,OTHER"),
+				),
+			},
+			{
+				// all patterns are replaced with the content
+				Config: `
+resource "graylog_grok_patterns" "test" {
+  content     = <<EOS
+FOO foo2
+ZOO zoo
+EOS
+  replace_all = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_grok_patterns.test", "content", "FOO foo2\nZOO zoo\n"),
+					resource.TestCheckResourceAttr("graylog_grok_patterns.test", "pattern_ids.%", "2"),
+					server.checkNames("FOO,ZOO"),
+				),
+			},
+			{
+				// the pattern which is added out of band is removed because of replace_all
+				PreConfig: func() {
+					server.update(func() {
+						server.save(graylog.GrokPattern{Name: "EXTRA", Pattern: "extra"})
+					})
+				},
+				Config: `
+resource "graylog_grok_patterns" "test" {
+  content     = <<EOS
+FOO foo2
+ZOO zoo
+EOS
+  replace_all = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_grok_patterns.test", "pattern_ids.%", "2"),
+					server.checkNames("FOO,ZOO"),
+				),
+			},
+			{
+				ResourceName:  "graylog_grok_patterns.test",
+				ImportState:   true,
+				ImportStateId: "ZOO, FOO",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("the number of the imported resources should be 1: %d", len(states))
+					}
+					attrs := states[0].Attributes
+					if content := attrs["content"]; content != "ZOO zoo\nFOO foo2\n" {
+						return fmt.Errorf("content: %q", content)
+					}
+					if attrs["pattern_ids.FOO"] != server.patterns["FOO"].ID {
+						return fmt.Errorf("pattern_ids: %v", attrs)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
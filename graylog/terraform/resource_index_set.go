@@ -3,7 +3,7 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
@@ -11,15 +11,17 @@ import (
 
 func resourceIndexSet() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceIndexSetCreate,
-		Read:   resourceIndexSetRead,
-		Update: resourceIndexSetUpdate,
-		Delete: resourceIndexSetDelete,
+		CreateContext: wrapCRUD(resourceIndexSetCreate),
+		ReadContext:   wrapCRUD(resourceIndexSetRead),
+		UpdateContext: wrapCRUD(resourceIndexSetUpdate),
+		DeleteContext: wrapCRUD(resourceIndexSetDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"title": {
@@ -169,8 +171,7 @@ func newIndexSet(d *schema.ResourceData) (*graylog.IndexSet, error) {
 	}, nil
 }
 
-func resourceIndexSetCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceIndexSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -186,8 +187,7 @@ func resourceIndexSetCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceIndexSetRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceIndexSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	cfg := m.(*Config)
 	if err != nil {
@@ -200,8 +200,7 @@ func resourceIndexSetRead(d *schema.ResourceData, m interface{}) error {
 	return setIndexSet(d, is, cfg)
 }
 
-func resourceIndexSetUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceIndexSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -217,8 +216,7 @@ func resourceIndexSetUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceIndexSetDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceIndexSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
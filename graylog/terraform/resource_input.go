@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
@@ -30,15 +30,19 @@ func resourceInput() *schema.Resource {
 		}
 	}
 	return &schema.Resource{
-		Create: resourceInputCreate,
-		Read:   resourceInputRead,
-		Update: resourceInputUpdate,
-		Delete: resourceInputDelete,
+		CreateContext: wrapCRUD(resourceInputCreate),
+		ReadContext:   wrapCRUD(resourceInputRead),
+		UpdateContext: wrapCRUD(resourceInputUpdate),
+		DeleteContext: wrapCRUD(resourceInputDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: resourceInputCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			// required
 			"title": {
@@ -119,8 +123,7 @@ func newInput(d *schema.ResourceData) (*graylog.Input, error) {
 	return input, nil
 }
 
-func resourceInputCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceInputCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -137,8 +140,7 @@ func resourceInputCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceInputRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceInputRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -147,6 +149,10 @@ func resourceInputRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setInput(d, input)
+}
+
+func setInput(d *schema.ResourceData, input *graylog.Input) error {
 	if input.Attrs != nil {
 		b, err := json.Marshal(input.Attrs)
 		if err != nil {
@@ -181,8 +187,7 @@ func resourceInputRead(d *schema.ResourceData, m interface{}) error {
 	return setStrToRD(d, "created_at", input.CreatedAt)
 }
 
-func resourceInputUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceInputUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -197,8 +202,7 @@ func resourceInputUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceInputDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceInputDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -0,0 +1,137 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// tlsFields are attributes which require "tls_enable".
+var tlsFields = []string{"tls_cert_file", "tls_key_file", "tls_key_password", "tls_client_auth", "tls_client_auth_cert_file"}
+
+// resourceInputCustomizeDiff checks the attributes against the input type before any API call.
+func resourceInputCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+	attrs, ok := configuredInputAttrs(d.GetRawConfig())
+	if !ok {
+		return nil
+	}
+	return validateInputAttrs(d.Get("type").(string), attrs)
+}
+
+// configuredInputAttrs returns the attributes which are set in the configuration.
+// The value of an attribute whose value is unknown is nil.
+// If the attributes block is unknown, ok is false.
+func configuredInputAttrs(config cty.Value) (attrs map[string]interface{}, ok bool) {
+	if config.IsNull() || !config.IsKnown() {
+		return nil, false
+	}
+	blocks := config.GetAttr("attributes")
+	if blocks.IsNull() || !blocks.IsKnown() || blocks.LengthInt() == 0 {
+		return nil, false
+	}
+	block := blocks.Index(cty.NumberIntVal(0))
+	if block.IsNull() || !block.IsKnown() {
+		return nil, false
+	}
+	attrs = map[string]interface{}{}
+	for k, v := range block.AsValueMap() {
+		if v.IsNull() {
+			continue
+		}
+		if !v.IsKnown() {
+			attrs[k] = nil
+			continue
+		}
+		switch v.Type() {
+		case cty.String:
+			attrs[k] = v.AsString()
+		case cty.Number:
+			i, _ := v.AsBigFloat().Int64()
+			attrs[k] = int(i)
+		case cty.Bool:
+			attrs[k] = v.True()
+		}
+	}
+	return attrs, true
+}
+
+// validateInputAttrs checks that the attributes are supported by the input type,
+// the required attributes are set, the ports are valid and the TLS attributes are consistent.
+// Attributes of input types which aren't registered such as plugins' inputs aren't checked.
+func validateInputAttrs(inputType string, attrs map[string]interface{}) error {
+	fields, ok := graylog.GetInputAttrsFields(inputType)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(attrs))
+	for k := range attrs {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("'attributes.0.%s' isn't supported by the input type '%s'", name, inputType)
+		}
+	}
+
+	required := []string{}
+	for name, f := range fields {
+		if f.Required {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+	for _, name := range required {
+		if _, ok := attrs[name]; !ok {
+			return fmt.Errorf("'attributes.0.%s' is required for the input type '%s'", name, inputType)
+		}
+	}
+
+	for _, name := range names {
+		if name != "port" && !strings.HasSuffix(name, "_port") {
+			continue
+		}
+		if p, ok := attrs[name].(int); ok && (p < 1 || p > 65535) {
+			return fmt.Errorf("'attributes.0.%s' must be between 1 and 65535: %d", name, p)
+		}
+	}
+	return validateInputTLSAttrs(attrs)
+}
+
+func validateInputTLSAttrs(attrs map[string]interface{}) error {
+	_, cert := attrs["tls_cert_file"]
+	_, key := attrs["tls_key_file"]
+	if cert != key {
+		return errors.New("'attributes.0.tls_cert_file' and 'attributes.0.tls_key_file' must be set together")
+	}
+	if _, ok := attrs["tls_key_password"]; ok && !key {
+		return errors.New("'attributes.0.tls_key_password' requires 'attributes.0.tls_key_file'")
+	}
+	if v, ok := attrs["tls_enable"]; !ok || v == false {
+		for _, name := range tlsFields {
+			if _, ok := attrs[name]; ok {
+				return fmt.Errorf("'attributes.0.%s' requires 'attributes.0.tls_enable' to be true", name)
+			}
+		}
+	}
+	clientAuth, ok := attrs["tls_client_auth"]
+	switch clientAuth {
+	case nil, "disabled", "optional", "required":
+	default:
+		return fmt.Errorf("'attributes.0.tls_client_auth' must be one of disabled, optional and required: %v", clientAuth)
+	}
+	if _, certFile := attrs["tls_client_auth_cert_file"]; certFile && (!ok || clientAuth == "disabled") {
+		return errors.New("'attributes.0.tls_client_auth_cert_file' requires 'attributes.0.tls_client_auth' to be optional or required")
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package terraform
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/flute"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestValidateInputAttrs(t *testing.T) {
+	data := []struct {
+		title     string
+		inputType string
+		attrs     map[string]interface{}
+		isErr     bool
+	}{
+		{
+			title:     "valid",
+			inputType: graylog.InputTypeGELFUDP,
+			attrs:     map[string]interface{}{"bind_address": "0.0.0.0", "port": 12201, "recv_buffer_size": 262144},
+		},
+		{
+			title:     "required attribute is missing",
+			inputType: graylog.InputTypeGELFUDP,
+			attrs:     map[string]interface{}{"port": 12201, "recv_buffer_size": 262144},
+			isErr:     true,
+		},
+		{
+			title:     "unknown value of a required attribute",
+			inputType: graylog.InputTypeGELFUDP,
+			attrs:     map[string]interface{}{"bind_address": nil, "port": nil, "recv_buffer_size": 262144},
+		},
+		{
+			title:     "attribute isn't supported by the type",
+			inputType: graylog.InputTypeAWSCloudTrail,
+			attrs:     map[string]interface{}{"port": 12201},
+			isErr:     true,
+		},
+		{
+			title:     "invalid port",
+			inputType: graylog.InputTypeGELFUDP,
+			attrs:     map[string]interface{}{"bind_address": "0.0.0.0", "port": 70000, "recv_buffer_size": 262144},
+			isErr:     true,
+		},
+		{
+			title:     "input types which aren't registered aren't checked",
+			inputType: "org.example.PluginInput",
+			attrs:     map[string]interface{}{"port": 0},
+		},
+		{
+			title:     "TLS",
+			inputType: graylog.InputTypeGELFTCP,
+			attrs: map[string]interface{}{
+				"bind_address": "0.0.0.0", "port": 12201, "recv_buffer_size": 1048576,
+				"tls_enable": true, "tls_cert_file": "/etc/graylog/cert.pem", "tls_key_file": "/etc/graylog/key.pem",
+				"tls_client_auth": "required", "tls_client_auth_cert_file": "/etc/graylog/ca.pem",
+			},
+		},
+		{
+			title:     "TLS key file without cert file",
+			inputType: graylog.InputTypeGELFTCP,
+			attrs: map[string]interface{}{
+				"bind_address": "0.0.0.0", "port": 12201, "recv_buffer_size": 1048576,
+				"tls_enable": true, "tls_key_file": "/etc/graylog/key.pem",
+			},
+			isErr: true,
+		},
+		{
+			title:     "TLS files without tls_enable",
+			inputType: graylog.InputTypeGELFTCP,
+			attrs: map[string]interface{}{
+				"bind_address": "0.0.0.0", "port": 12201, "recv_buffer_size": 1048576,
+				"tls_cert_file": "/etc/graylog/cert.pem", "tls_key_file": "/etc/graylog/key.pem",
+			},
+			isErr: true,
+		},
+		{
+			title:     "client auth cert file with disabled client auth",
+			inputType: graylog.InputTypeGELFTCP,
+			attrs: map[string]interface{}{
+				"bind_address": "0.0.0.0", "port": 12201, "recv_buffer_size": 1048576,
+				"tls_enable": true, "tls_client_auth": "disabled", "tls_client_auth_cert_file": "/etc/graylog/ca.pem",
+			},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			err := validateInputAttrs(d.inputType, d.attrs)
+			if d.isErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestConfiguredInputAttrs(t *testing.T) {
+	attrs, ok := configuredInputAttrs(cty.ObjectVal(map[string]cty.Value{
+		"attributes": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"bind_address":     cty.StringVal("0.0.0.0"),
+			"port":             cty.NumberIntVal(12201),
+			"tls_enable":       cty.True,
+			"override_source":  cty.NullVal(cty.String),
+			"recv_buffer_size": cty.UnknownVal(cty.Number),
+		})}),
+	}))
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{
+		"bind_address": "0.0.0.0", "port": 12201, "tls_enable": true, "recv_buffer_size": nil,
+	}, attrs)
+}
+
+func TestAccInput_invalidAttributes(t *testing.T) {
+	setEnv()
+	defaultTransport := http.DefaultClient.Transport
+	defer func() {
+		http.DefaultClient.Transport = defaultTransport
+	}()
+	// no API is called
+	http.DefaultClient.Transport = &flute.Transport{T: t}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				PlanOnly: true,
+				Config: `
+resource "graylog_input" "gelf_udp" {
+  title = "gelf udp"
+  type  = "org.graylog2.inputs.gelf.udp.GELFUDPInput"
+  attributes {
+    port             = 12201
+    recv_buffer_size = 262144
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`'attributes.0.bind_address' is required`),
+			},
+		},
+	})
+}
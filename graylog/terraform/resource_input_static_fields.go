@@ -3,20 +3,22 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceInputStaticFields() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceInputStaticFieldsCreate,
-		Read:   resourceInputStaticFieldsRead,
-		Update: resourceInputStaticFieldsUpdate,
-		Delete: resourceInputStaticFieldsDelete,
+		CreateContext: wrapCRUD(resourceInputStaticFieldsCreate),
+		ReadContext:   wrapCRUD(resourceInputStaticFieldsRead),
+		UpdateContext: wrapCRUD(resourceInputStaticFieldsUpdate),
+		DeleteContext: wrapCRUD(resourceInputStaticFieldsDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"input_id": {
@@ -45,8 +47,7 @@ func newInputStaticFields(d *schema.ResourceData) (string, map[string]string, er
 	return d.Get("input_id").(string), fields, nil
 }
 
-func resourceInputStaticFieldsCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceInputStaticFieldsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -90,8 +91,7 @@ func resourceInputStaticFieldsCreate(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceInputStaticFieldsRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceInputStaticFieldsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -103,8 +103,7 @@ func resourceInputStaticFieldsRead(d *schema.ResourceData, m interface{}) error
 	return d.Set("fields", input.StaticFields)
 }
 
-func resourceInputStaticFieldsUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceInputStaticFieldsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -150,8 +149,7 @@ func resourceInputStaticFieldsUpdate(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceInputStaticFieldsDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceInputStaticFieldsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -5,8 +5,8 @@ package terraform
 // 	"os"
 // 	"testing"
 //
-// 	"github.com/hashicorp/terraform/helper/resource"
-// 	"github.com/hashicorp/terraform/terraform"
+// 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+// 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 //
 // 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
 // )
@@ -0,0 +1,90 @@
+package terraform
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	ldapGroupRoleMappingID = "ldap_group_role_mapping_id"
+)
+
+// resourceLDAPGroupRoleMapping manages the whole mapping from LDAP groups to Graylog roles.
+func resourceLDAPGroupRoleMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceLDAPGroupRoleMappingCreate),
+		ReadContext:   wrapCRUD(resourceLDAPGroupRoleMappingRead),
+		UpdateContext: wrapCRUD(resourceLDAPGroupRoleMappingUpdate),
+		DeleteContext: wrapCRUD(resourceLDAPGroupRoleMappingDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			// the key is the LDAP group and the value is the role name
+			"mapping": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func newLDAPGroupRoleMapping(d *schema.ResourceData) map[string]string {
+	raw := d.Get("mapping").(map[string]interface{})
+	mapping := make(map[string]string, len(raw))
+	for k, v := range raw {
+		mapping[k] = v.(string)
+	}
+	return mapping
+}
+
+func resourceLDAPGroupRoleMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.UpdateLDAPGroupRoleMapping(ctx, newLDAPGroupRoleMapping(d)); err != nil {
+		return err
+	}
+	d.SetId(ldapGroupRoleMappingID)
+	return resourceLDAPGroupRoleMappingRead(ctx, d, m)
+}
+
+func resourceLDAPGroupRoleMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	mapping, _, err := cl.GetLDAPGroupRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	return setMapStrToStrToRD(d, "mapping", mapping)
+}
+
+func resourceLDAPGroupRoleMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.UpdateLDAPGroupRoleMapping(ctx, newLDAPGroupRoleMapping(d)); err != nil {
+		return err
+	}
+	return resourceLDAPGroupRoleMappingRead(ctx, d, m)
+}
+
+func resourceLDAPGroupRoleMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	_, err = cl.UpdateLDAPGroupRoleMapping(ctx, map[string]string{})
+	return err
+}
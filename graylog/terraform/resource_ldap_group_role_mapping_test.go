@@ -0,0 +1,135 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/suzuki-shunsuke/flute/flute"
+)
+
+func TestAccLDAPGroupRoleMapping(t *testing.T) {
+	setEnv()
+	var mutex sync.Mutex
+	mapping := map[string]string{}
+
+	defaultTransport := http.DefaultClient.Transport
+	defer func() {
+		http.DefaultClient.Transport = defaultTransport
+	}()
+	http.DefaultClient.Transport = &flute.Transport{
+		T: t,
+		Services: []flute.Service{
+			{
+				Endpoint: "http://example.com",
+				Routes: []flute.Route{
+					{
+						Name: "Get the LDAP group role mapping",
+						Matcher: &flute.Matcher{
+							Method: "GET",
+							Path:   "/api/system/ldap/settings/groups",
+						},
+						Response: &flute.Response{
+							Response: func(req *http.Request) (*http.Response, error) {
+								mutex.Lock()
+								defer mutex.Unlock()
+								b, err := json.Marshal(mapping)
+								if err != nil {
+									return nil, err
+								}
+								return &http.Response{
+									StatusCode: 200,
+									Body:       ioutil.NopCloser(strings.NewReader(string(b))),
+								}, nil
+							},
+						},
+					},
+					{
+						Name: "Update the LDAP group role mapping",
+						Matcher: &flute.Matcher{
+							Method: "PUT",
+							Path:   "/api/system/ldap/settings/groups",
+						},
+						Tester: &flute.Tester{
+							PartOfHeader: getTestHeader(),
+						},
+						Response: &flute.Response{
+							Response: func(req *http.Request) (*http.Response, error) {
+								mutex.Lock()
+								defer mutex.Unlock()
+								m := map[string]string{}
+								if err := json.NewDecoder(req.Body).Decode(&m); err != nil {
+									return nil, err
+								}
+								mapping = m
+								return &http.Response{
+									StatusCode: 204,
+									Body:       ioutil.NopCloser(strings.NewReader("")),
+								}, nil
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	checkMapping := func(expected string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if actual := fmt.Sprint(mapping); actual != expected {
+				return fmt.Errorf("mapping: expected %s, got %s", expected, actual)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy:      checkMapping("map[]"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "graylog_ldap_group_role_mapping" "test" {
+  mapping = {
+    admins = "Admin"
+    devs   = "Reader"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_ldap_group_role_mapping.test", "mapping.admins", "Admin"),
+					checkMapping("map[admins:Admin devs:Reader]"),
+				),
+			},
+			{
+				ResourceName:      "graylog_ldap_group_role_mapping.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the group which is added out of band is removed
+				PreConfig: func() {
+					mutex.Lock()
+					defer mutex.Unlock()
+					mapping["ops"] = "Admin"
+				},
+				Config: `
+resource "graylog_ldap_group_role_mapping" "test" {
+  mapping = {
+    admins = "Admin"
+  }
+}
+`,
+				Check: checkMapping("map[admins:Admin]"),
+			},
+		},
+	})
+}
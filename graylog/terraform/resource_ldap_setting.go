@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-set/v6"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
@@ -16,15 +16,17 @@ const (
 
 func resourceLDAPSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceLDAPSettingCreate,
-		Read:   resourceLDAPSettingRead,
-		Update: resourceLDAPSettingUpdate,
-		Delete: resourceLDAPSettingDelete,
+		CreateContext: wrapCRUD(resourceLDAPSettingCreate),
+		ReadContext:   wrapCRUD(resourceLDAPSettingRead),
+		UpdateContext: wrapCRUD(resourceLDAPSettingUpdate),
+		DeleteContext: wrapCRUD(resourceLDAPSettingDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// required
 			"system_username": {
@@ -131,8 +133,7 @@ func newLDAPSetting(d *schema.ResourceData) (*graylog.LDAPSetting, error) {
 	return setting, nil
 }
 
-func resourceLDAPSettingCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceLDAPSettingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -149,8 +150,7 @@ func resourceLDAPSettingCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceLDAPSettingRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceLDAPSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -208,8 +208,7 @@ func resourceLDAPSettingRead(d *schema.ResourceData, m interface{}) error {
 	return setMapStrToStrToRD(d, "group_mapping", ls.GroupMapping)
 }
 
-func resourceLDAPSettingUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceLDAPSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -222,8 +221,7 @@ func resourceLDAPSettingUpdate(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceLDAPSettingDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceLDAPSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
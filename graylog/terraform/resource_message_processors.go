@@ -0,0 +1,193 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+const (
+	messageProcessorsID = "message_processors_id"
+)
+
+// resourceMessageProcessors manages the order of message processors and the disabled processors.
+// The class names are validated against the processors which are available on the server.
+func resourceMessageProcessors() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceMessageProcessorsCreate),
+		ReadContext:   wrapCRUD(resourceMessageProcessorsRead),
+		UpdateContext: wrapCRUD(resourceMessageProcessorsUpdate),
+		DeleteContext: wrapCRUD(resourceMessageProcessorsDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceMessageProcessorsCustomizeDiff,
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			// the class names of all available processors in order.
+			// Graylog appends the processors which aren't included,
+			// so the order must include all processors to prevent the perpetual diff.
+			"processor_order": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// Optional
+			"disabled_processors": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// validateMessageProcessors checks the processors are available and the order includes all available processors.
+func validateMessageProcessors(available *graylog.MessageProcessors, order, disabled []string) error {
+	classNames := available.ClassNames()
+	availableSet := make(map[string]struct{}, len(classNames))
+	for _, className := range classNames {
+		availableSet[className] = struct{}{}
+	}
+	unknownErr := func(className string) error {
+		return fmt.Errorf(
+			"unknown message processor %s. Available processors: %s",
+			className, strings.Join(classNames, ", "))
+	}
+
+	orderSet := make(map[string]struct{}, len(order))
+	for _, className := range order {
+		if _, ok := availableSet[className]; !ok {
+			return &attrError{key: "processor_order", err: unknownErr(className)}
+		}
+		if _, ok := orderSet[className]; ok {
+			return &attrError{key: "processor_order", err: fmt.Errorf("the message processor %s is duplicated", className)}
+		}
+		orderSet[className] = struct{}{}
+	}
+	missing := []string{}
+	for _, className := range classNames {
+		if _, ok := orderSet[className]; !ok {
+			missing = append(missing, className)
+		}
+	}
+	if len(missing) != 0 {
+		return &attrError{key: "processor_order", err: fmt.Errorf(
+			"all available message processors must be included. Missing processors: %s",
+			strings.Join(missing, ", "))}
+	}
+
+	for _, className := range disabled {
+		if _, ok := availableSet[className]; !ok {
+			return &attrError{key: "disabled_processors", err: unknownErr(className)}
+		}
+	}
+	return nil
+}
+
+// resourceMessageProcessorsCustomizeDiff validates the class names against the server at plan time.
+func resourceMessageProcessorsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("processor_order") || !d.NewValueKnown("disabled_processors") {
+		// the processors can't be checked until they are known
+		return nil
+	}
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	available, _, err := cl.GetMessageProcessors(ctx)
+	if err != nil {
+		return err
+	}
+	return validateMessageProcessors(
+		available,
+		getStringArray(d.Get("processor_order").([]interface{})),
+		getStringArray(d.Get("disabled_processors").(*schema.Set).List()))
+}
+
+// updateMessageProcessors sends the processors with the names which the server returns,
+// after validating the class names again because the available processors may be changed after the plan.
+func updateMessageProcessors(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	available, _, err := cl.GetMessageProcessors(ctx)
+	if err != nil {
+		return err
+	}
+	order := getStringArray(d.Get("processor_order").([]interface{}))
+	disabled := getStringArray(d.Get("disabled_processors").(*schema.Set).List())
+	if err := validateMessageProcessors(available, order, disabled); err != nil {
+		return err
+	}
+	names := make(map[string]string, len(available.ProcessorOrder))
+	for _, processor := range available.ProcessorOrder {
+		names[processor.ClassName] = processor.Name
+	}
+	processors := &graylog.MessageProcessors{
+		ProcessorOrder:     make([]graylog.MessageProcessor, len(order)),
+		DisabledProcessors: disabled,
+	}
+	for i, className := range order {
+		processors.ProcessorOrder[i] = graylog.MessageProcessor{Name: names[className], ClassName: className}
+	}
+	_, err = cl.UpdateMessageProcessors(ctx, processors)
+	return err
+}
+
+func resourceMessageProcessorsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateMessageProcessors(ctx, d, m); err != nil {
+		return err
+	}
+	d.SetId(messageProcessorsID)
+	return resourceMessageProcessorsRead(ctx, d, m)
+}
+
+func resourceMessageProcessorsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	processors, ei, err := cl.GetMessageProcessors(ctx)
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	if err := setStrListToRD(d, "processor_order", processors.ClassNames()); err != nil {
+		return err
+	}
+	return setStrListToRD(d, "disabled_processors", processors.DisabledProcessors)
+}
+
+func resourceMessageProcessorsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateMessageProcessors(ctx, d, m); err != nil {
+		return err
+	}
+	return resourceMessageProcessorsRead(ctx, d, m)
+}
+
+// resourceMessageProcessorsDelete restores the default order and enables all processors
+// by removing the cluster configuration of message processors.
+func resourceMessageProcessorsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	if ei, err := cl.DeleteClusterConfig(ctx, graylog.ClusterConfigClassMessageProcessors); err != nil {
+		if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+			return err
+		}
+	}
+	return nil
+}
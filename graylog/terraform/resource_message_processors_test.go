@@ -0,0 +1,157 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+const (
+	testMessageFilterChainProcessor = "org.graylog2.messageprocessors.MessageFilterChainProcessor"
+	testPipelineInterpreter         = "org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter"
+	testGeoIPProcessor              = "org.graylog.plugins.map.geoip.processor.GeoIpProcessor"
+)
+
+// fakeMessageProcessorsServer is a fake API of message processors.
+// Like Graylog, the processors which aren't included in the updated order are appended.
+type fakeMessageProcessorsServer struct {
+	fakeAPI
+	processors graylog.MessageProcessors
+}
+
+func newFakeMessageProcessorsServer() *fakeMessageProcessorsServer {
+	s := &fakeMessageProcessorsServer{}
+	s.reset()
+	return s
+}
+
+func (s *fakeMessageProcessorsServer) reset() {
+	s.processors = graylog.MessageProcessors{
+		ProcessorOrder: []graylog.MessageProcessor{
+			{Name: "Message Filter Chain", ClassName: testMessageFilterChainProcessor},
+			{Name: "Pipeline Processor", ClassName: testPipelineInterpreter},
+			{Name: "GeoIP Resolver", ClassName: testGeoIPProcessor},
+		},
+		DisabledProcessors: []string{},
+	}
+}
+
+func (s *fakeMessageProcessorsServer) handle(req *http.Request) (int, interface{}, error) {
+	switch {
+	case req.Method == "GET" && req.URL.Path == "/api/system/messageprocessors/config":
+		return 200, s.processors, nil
+	case req.Method == "PUT" && req.URL.Path == "/api/system/messageprocessors/config":
+		processors := graylog.MessageProcessors{}
+		if err := json.NewDecoder(req.Body).Decode(&processors); err != nil {
+			return 0, nil, err
+		}
+		s.updateProcessors(processors)
+		return 200, s.processors, nil
+	case req.Method == "DELETE" && req.URL.Path == "/api/system/cluster_config/"+graylog.ClusterConfigClassMessageProcessors:
+		s.reset()
+		return 204, nil, nil
+	}
+	return fakeNotFound()
+}
+
+// updateProcessors appends the processors which aren't included in the order like Graylog.
+func (s *fakeMessageProcessorsServer) updateProcessors(processors graylog.MessageProcessors) {
+	included := map[string]struct{}{}
+	for _, processor := range processors.ProcessorOrder {
+		included[processor.ClassName] = struct{}{}
+	}
+	for _, processor := range s.processors.ProcessorOrder {
+		if _, ok := included[processor.ClassName]; !ok {
+			processors.ProcessorOrder = append(processors.ProcessorOrder, processor)
+		}
+	}
+	s.processors = processors
+}
+
+func (s *fakeMessageProcessorsServer) checkProcessors(order, disabled []string) resource.TestCheckFunc {
+	return s.check(func() error {
+		if actual := s.processors.ClassNames(); fmt.Sprint(actual) != fmt.Sprint(order) {
+			return fmt.Errorf("processor_order: expected %v, got %v", order, actual)
+		}
+		if fmt.Sprint(s.processors.DisabledProcessors) != fmt.Sprint(disabled) {
+			return fmt.Errorf("disabled_processors: expected %v, got %v", disabled, s.processors.DisabledProcessors)
+		}
+		return nil
+	})
+}
+
+func TestAccMessageProcessors(t *testing.T) {
+	setEnv()
+	server := newFakeMessageProcessorsServer()
+
+	defer server.use(t, server.handle)()
+
+	genConfig := func(order, disabled []string) string {
+		return fmt.Sprintf(`
+resource "graylog_message_processors" "test" {
+  processor_order     = %s
+  disabled_processors = %s
+}
+`, toHCLStrList(order), toHCLStrList(disabled))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy:      server.checkProcessors([]string{testMessageFilterChainProcessor, testPipelineInterpreter, testGeoIPProcessor}, []string{}),
+		Steps: []resource.TestStep{
+			{
+				PlanOnly: true,
+				Config: genConfig(
+					[]string{testPipelineInterpreter, testMessageFilterChainProcessor, "org.example.FooProcessor"}, []string{}),
+				ExpectError: regexp.MustCompile(`unknown message processor org.example.FooProcessor`),
+			},
+			{
+				PlanOnly:    true,
+				Config:      genConfig([]string{testPipelineInterpreter, testMessageFilterChainProcessor}, []string{}),
+				ExpectError: regexp.MustCompile(`Missing processors: ` + regexp.QuoteMeta(testGeoIPProcessor)),
+			},
+			{
+				PlanOnly: true,
+				Config: genConfig(
+					[]string{testPipelineInterpreter, testMessageFilterChainProcessor, testGeoIPProcessor},
+					[]string{"org.example.FooProcessor"}),
+				ExpectError: regexp.MustCompile(`'disabled_processors': unknown message processor org.example.FooProcessor`),
+			},
+			{
+				Config: genConfig(
+					[]string{testPipelineInterpreter, testMessageFilterChainProcessor, testGeoIPProcessor},
+					[]string{testGeoIPProcessor}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_message_processors.test", "processor_order.0", testPipelineInterpreter),
+					resource.TestCheckResourceAttr("graylog_message_processors.test", "disabled_processors.#", "1"),
+					server.checkProcessors(
+						[]string{testPipelineInterpreter, testMessageFilterChainProcessor, testGeoIPProcessor},
+						[]string{testGeoIPProcessor}),
+				),
+			},
+			{
+				Config: genConfig(
+					[]string{testMessageFilterChainProcessor, testPipelineInterpreter, testGeoIPProcessor}, []string{}),
+				Check: server.checkProcessors(
+					[]string{testMessageFilterChainProcessor, testPipelineInterpreter, testGeoIPProcessor}, []string{}),
+			},
+			{
+				ResourceName:      "graylog_message_processors.test",
+				ImportState:       true,
+				ImportStateId:     messageProcessorsID,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func toHCLStrList(list []string) string {
+	b, _ := json.Marshal(list)
+	return string(b)
+}
@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
@@ -13,15 +13,17 @@ import (
 
 func resourceOutput() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOutputCreate,
-		Read:   resourceOutputRead,
-		Update: resourceOutputUpdate,
-		Delete: resourceOutputDelete,
+		CreateContext: wrapCRUD(resourceOutputCreate),
+		ReadContext:   wrapCRUD(resourceOutputRead),
+		UpdateContext: wrapCRUD(resourceOutputUpdate),
+		DeleteContext: wrapCRUD(resourceOutputDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"title": {
 				Type:     schema.TypeString,
@@ -54,8 +56,7 @@ func newOutput(d *schema.ResourceData) (*graylog.Output, error) {
 	}, nil
 }
 
-func resourceOutputCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceOutputCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -71,8 +72,7 @@ func resourceOutputCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceOutputRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceOutputRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -81,6 +81,10 @@ func resourceOutputRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setOutput(d, output)
+}
+
+func setOutput(d *schema.ResourceData, output *graylog.Output) error {
 	if err := setStrToRD(d, "title", output.Title); err != nil {
 		return err
 	}
@@ -94,8 +98,7 @@ func resourceOutputRead(d *schema.ResourceData, m interface{}) error {
 	return setStrToRD(d, "configuration", string(b))
 }
 
-func resourceOutputUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceOutputUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -108,8 +111,7 @@ func resourceOutputUpdate(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceOutputDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceOutputDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -4,22 +4,24 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourcePipeline() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePipelineCreate,
-		Read:   resourcePipelineRead,
-		Update: resourcePipelineUpdate,
-		Delete: resourcePipelineDelete,
+		CreateContext: wrapCRUD(resourcePipelineCreate),
+		ReadContext:   wrapCRUD(resourcePipelineRead),
+		UpdateContext: wrapCRUD(resourcePipelineUpdate),
+		DeleteContext: wrapCRUD(resourcePipelineDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"source": {
@@ -47,8 +49,7 @@ func newPipeline(d *schema.ResourceData) *graylog.Pipeline {
 	}
 }
 
-func resourcePipelineCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -64,8 +65,7 @@ func resourcePipelineCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourcePipelineRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -74,14 +74,17 @@ func resourcePipelineRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setPipeline(d, pipe)
+}
+
+func setPipeline(d *schema.ResourceData, pipe *graylog.Pipeline) error {
 	if err := setStrToRD(d, "source", pipe.Source); err != nil {
 		return err
 	}
 	return setStrToRD(d, "description", pipe.Description)
 }
 
-func resourcePipelineUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -91,8 +94,7 @@ func resourcePipelineUpdate(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourcePipelineDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
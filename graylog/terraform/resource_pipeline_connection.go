@@ -3,22 +3,24 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourcePipelineConnection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePipelineConnectionCreate,
-		Read:   resourcePipelineConnectionRead,
-		Update: resourcePipelineConnectionUpdate,
-		Delete: resourcePipelineConnectionDelete,
+		CreateContext: wrapCRUD(resourcePipelineConnectionCreate),
+		ReadContext:   wrapCRUD(resourcePipelineConnectionRead),
+		UpdateContext: wrapCRUD(resourcePipelineConnectionUpdate),
+		DeleteContext: wrapCRUD(resourcePipelineConnectionDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"stream_id": {
@@ -42,8 +44,7 @@ func newPipelineConnection(d *schema.ResourceData) *graylog.PipelineConnection {
 	}
 }
 
-func resourcePipelineConnectionCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -56,8 +57,7 @@ func resourcePipelineConnectionCreate(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourcePipelineConnectionRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -77,8 +77,7 @@ func resourcePipelineConnectionRead(d *schema.ResourceData, m interface{}) error
 	return setStrListToRD(d, "pipeline_ids", pipelines)
 }
 
-func resourcePipelineConnectionUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -90,8 +89,7 @@ func resourcePipelineConnectionUpdate(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourcePipelineConnectionDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -4,22 +4,24 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourcePipelineRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePipelineRuleCreate,
-		Read:   resourcePipelineRuleRead,
-		Update: resourcePipelineRuleUpdate,
-		Delete: resourcePipelineRuleDelete,
+		CreateContext: wrapCRUD(resourcePipelineRuleCreate),
+		ReadContext:   wrapCRUD(resourcePipelineRuleRead),
+		UpdateContext: wrapCRUD(resourcePipelineRuleUpdate),
+		DeleteContext: wrapCRUD(resourcePipelineRuleDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"source": {
@@ -46,8 +48,7 @@ func newPipelineRule(d *schema.ResourceData) *graylog.PipelineRule {
 	}
 }
 
-func resourcePipelineRuleCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -63,8 +64,7 @@ func resourcePipelineRuleCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourcePipelineRuleRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -73,14 +73,17 @@ func resourcePipelineRuleRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setPipelineRule(d, rule)
+}
+
+func setPipelineRule(d *schema.ResourceData, rule *graylog.PipelineRule) error {
 	if err := setStrToRD(d, "source", rule.Source); err != nil {
 		return err
 	}
 	return setStrToRD(d, "description", rule.Description)
 }
 
-func resourcePipelineRuleUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -90,8 +93,7 @@ func resourcePipelineRuleUpdate(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourcePipelineRuleDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourcePipelineRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -3,7 +3,7 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-set/v6"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
@@ -11,15 +11,17 @@ import (
 
 func resourceRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRoleCreate,
-		Read:   resourceRoleRead,
-		Update: resourceRoleUpdate,
-		Delete: resourceRoleDelete,
+		CreateContext: wrapCRUD(resourceRoleCreate),
+		ReadContext:   wrapCRUD(resourceRoleRead),
+		UpdateContext: wrapCRUD(resourceRoleUpdate),
+		DeleteContext: wrapCRUD(resourceRoleDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -52,8 +54,7 @@ func newRole(d *schema.ResourceData) *graylog.Role {
 	}
 }
 
-func resourceRoleCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -66,8 +67,7 @@ func resourceRoleCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceRoleRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -76,6 +76,10 @@ func resourceRoleRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setRole(d, role)
+}
+
+func setRole(d *schema.ResourceData, role *graylog.Role) error {
 	if err := setStrToRD(d, "name", role.Name); err != nil {
 		return err
 	}
@@ -88,8 +92,7 @@ func resourceRoleRead(d *schema.ResourceData, m interface{}) error {
 	return setBoolToRD(d, "read_only", role.ReadOnly)
 }
 
-func resourceRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	o, n := d.GetChange("name")
 	oldName := o.(string)
 	newName := n.(string)
@@ -103,8 +106,7 @@ func resourceRoleUpdate(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceRoleDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
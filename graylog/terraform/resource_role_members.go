@@ -0,0 +1,126 @@
+package terraform
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/suzuki-shunsuke/go-set/v6"
+)
+
+// resourceRoleMembers manages all members of a role.
+// Users which aren't included in usernames are removed from the role.
+func resourceRoleMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceRoleMembersCreate),
+		ReadContext:   wrapCRUD(resourceRoleMembersRead),
+		UpdateContext: wrapCRUD(resourceRoleMembersUpdate),
+		DeleteContext: wrapCRUD(resourceRoleMembersDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"role_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"usernames": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// updateRoleMembers adds and removes users so that the role's members become usernames.
+func updateRoleMembers(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	roleName := d.Get("role_name").(string)
+	users, _, err := cl.GetRoleMembers(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	members := make([]string, len(users))
+	for i, user := range users {
+		members[i] = user.Username
+	}
+	current := set.NewStrSet(members...)
+	usernames := set.NewStrSet(getStringArray(d.Get("usernames").(*schema.Set).List())...)
+	for _, username := range current.ToList() {
+		if usernames.Has(username) {
+			continue
+		}
+		if _, err := cl.RemoveUserFromRole(ctx, username, roleName); err != nil {
+			return err
+		}
+	}
+	for _, username := range usernames.ToList() {
+		if current.Has(username) {
+			continue
+		}
+		if _, err := cl.AddUserToRole(ctx, username, roleName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceRoleMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateRoleMembers(ctx, d, m); err != nil {
+		return err
+	}
+	d.SetId(d.Get("role_name").(string))
+	return resourceRoleMembersRead(ctx, d, m)
+}
+
+func resourceRoleMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	users, ei, err := cl.GetRoleMembers(ctx, d.Id())
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	usernames := make([]string, len(users))
+	for i, user := range users {
+		usernames[i] = user.Username
+	}
+	if err := setStrToRD(d, "role_name", d.Id()); err != nil {
+		return err
+	}
+	return setStrListToRD(d, "usernames", usernames)
+}
+
+func resourceRoleMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateRoleMembers(ctx, d, m); err != nil {
+		return err
+	}
+	return resourceRoleMembersRead(ctx, d, m)
+}
+
+func resourceRoleMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	// only the members managed by the resource are removed
+	roleName := d.Get("role_name").(string)
+	for _, username := range getStringArray(d.Get("usernames").(*schema.Set).List()) {
+		if ei, err := cl.RemoveUserFromRole(ctx, username, roleName); err != nil {
+			if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+				return err
+			}
+		}
+	}
+	return nil
+}
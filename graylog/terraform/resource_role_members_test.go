@@ -0,0 +1,188 @@
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/go-set/v6"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+// fakeRoleMemberServer is a fake API of users and role members.
+type fakeRoleMemberServer struct {
+	fakeAPI
+	// the key is the username and the value is the user's roles
+	users map[string]set.StrSet
+	roles set.StrSet
+}
+
+func newFakeRoleMemberServer() *fakeRoleMemberServer {
+	return &fakeRoleMemberServer{
+		users: map[string]set.StrSet{
+			"admin": set.NewStrSet("Admin"),
+			"foo":   set.NewStrSet("Reader"),
+			"bar":   set.NewStrSet("Reader"),
+		},
+		roles: set.NewStrSet("Admin", "Reader"),
+	}
+}
+
+func (s *fakeRoleMemberServer) handle(req *http.Request) (int, interface{}, error) {
+	// /api/roles/<role>/members[/<user>] or /api/users/<user>
+	paths := strings.Split(strings.TrimPrefix(req.URL.Path, "/api/"), "/")
+	switch {
+	case len(paths) == 2 && paths[0] == "users" && req.Method == "GET":
+		if roles, ok := s.users[paths[1]]; ok {
+			return 200, graylog.User{Username: paths[1], Roles: roles}, nil
+		}
+	case len(paths) >= 3 && paths[0] == "roles" && paths[2] == "members":
+		role := paths[1]
+		if !s.roles.Has(role) {
+			break
+		}
+		if len(paths) == 3 && req.Method == "GET" {
+			users := []graylog.User{}
+			for username, roles := range s.users {
+				if roles.Has(role) {
+					users = append(users, graylog.User{Username: username, Roles: roles})
+				}
+			}
+			return 200, &graylog.UsersBody{Users: users}, nil
+		}
+		roles, ok := s.users[paths[3]]
+		if len(paths) != 4 || !ok {
+			break
+		}
+		switch req.Method {
+		case "PUT":
+			roles[role] = struct{}{}
+		case "DELETE":
+			roles.Remove(role)
+		}
+		return 204, nil, nil
+	}
+	return fakeNotFound()
+}
+
+func (s *fakeRoleMemberServer) checkRoles(username string, roles ...string) resource.TestCheckFunc {
+	return s.check(func() error {
+		actual := s.users[username].ToList()
+		sort.Strings(actual)
+		if fmt.Sprint(actual) != fmt.Sprint(roles) {
+			return fmt.Errorf("the roles of the user %s: expected %v, got %v", username, roles, actual)
+		}
+		return nil
+	})
+}
+
+func TestAccRoleMembers(t *testing.T) {
+	setEnv()
+	server := newFakeRoleMemberServer()
+	defer server.use(t, server.handle)()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			server.checkRoles("admin"),
+			server.checkRoles("foo", "Reader"),
+			server.checkRoles("bar", "Reader"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "graylog_role_members" "test" {
+  role_name = "Admin"
+  usernames = ["admin", "foo"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_role_members.test", "id", "Admin"),
+					resource.TestCheckResourceAttr("graylog_role_members.test", "usernames.#", "2"),
+					server.checkRoles("foo", "Admin", "Reader"),
+				),
+			},
+			{
+				ResourceName:      "graylog_role_members.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the member which is added out of band is removed
+				PreConfig: func() {
+					server.update(func() {
+						server.users["bar"]["Admin"] = struct{}{}
+					})
+				},
+				Config: `
+resource "graylog_role_members" "test" {
+  role_name = "Admin"
+  usernames = ["admin", "foo"]
+}
+`,
+				Check: server.checkRoles("bar", "Reader"),
+			},
+			{
+				Config: `
+resource "graylog_role_members" "test" {
+  role_name = "Admin"
+  usernames = ["admin"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_role_members.test", "usernames.#", "1"),
+					server.checkRoles("foo", "Reader"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserRole(t *testing.T) {
+	setEnv()
+	server := newFakeRoleMemberServer()
+	defer server.use(t, server.handle)()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy:      server.checkRoles("foo", "Reader"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "graylog_user_role" "test" {
+  username  = "foo"
+  role_name = "Admin"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_user_role.test", "id", "foo/Admin"),
+					server.checkRoles("foo", "Admin", "Reader"),
+				),
+			},
+			{
+				ResourceName:      "graylog_user_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the binding which is removed out of band is created again
+				PreConfig: func() {
+					server.update(func() {
+						server.users["foo"].Remove("Admin")
+					})
+				},
+				Config: `
+resource "graylog_user_role" "test" {
+  username  = "foo"
+  role_name = "Admin"
+}
+`,
+				Check: server.checkRoles("foo", "Admin", "Reader"),
+			},
+		},
+	})
+}
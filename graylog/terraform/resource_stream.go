@@ -3,21 +3,25 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourceStream() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStreamCreate,
-		Read:   resourceStreamRead,
-		Update: resourceStreamUpdate,
-		Delete: resourceStreamDelete,
+		CreateContext: wrapCRUD(resourceStreamCreate),
+		ReadContext:   wrapCRUD(resourceStreamRead),
+		UpdateContext: wrapCRUD(resourceStreamUpdate),
+		DeleteContext: wrapCRUD(resourceStreamDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceStreamCustomizeDiff,
+
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"title": {
@@ -31,7 +35,63 @@ func resourceStream() *schema.Resource {
 			},
 
 			// Optional
-			// rules
+			// The rules, the outputs and the alert receivers are managed authoritatively only if they are set,
+			// so that they can be managed by other resources such as graylog_stream_rule.
+			"rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"type": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"inverted": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"output_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"alert_receivers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"emails": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"users": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -52,7 +112,6 @@ func resourceStream() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
-			// outputs
 			"created_at": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -67,7 +126,6 @@ func resourceStream() *schema.Resource {
 				Optional: true,
 			},
 			// alert_conditions
-			// alert_receivers
 		},
 	}
 }
@@ -84,8 +142,42 @@ func newStream(d *schema.ResourceData) (*graylog.Stream, error) {
 	}, nil
 }
 
-func resourceStreamCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func newStreamRules(d *schema.ResourceData) []graylog.StreamRule {
+	list := d.Get("rule").(*schema.Set).List()
+	rules := make([]graylog.StreamRule, len(list))
+	for i, v := range list {
+		a := v.(map[string]interface{})
+		rules[i] = graylog.StreamRule{
+			Field:       a["field"].(string),
+			Value:       a["value"].(string),
+			Description: a["description"].(string),
+			Type:        a["type"].(int),
+			Inverted:    a["inverted"].(bool),
+		}
+	}
+	return rules
+}
+
+func newAlertReceivers(d *schema.ResourceData) *graylog.AlertReceivers {
+	return alertReceiversFromList(d.Get("alert_receivers").([]interface{}))
+}
+
+func alertReceiversFromList(list []interface{}) *graylog.AlertReceivers {
+	receivers := &graylog.AlertReceivers{Emails: []string{}, Users: []string{}}
+	if len(list) == 0 || list[0] == nil {
+		return receivers
+	}
+	a := list[0].(map[string]interface{})
+	receivers.Emails = getStringArray(a["emails"].(*schema.Set).List())
+	receivers.Users = getStringArray(a["users"].(*schema.Set).List())
+	return receivers
+}
+
+func resourceStreamCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return claimStream(m, d.Id(), d.GetRawConfig())
+}
+
+func resourceStreamCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -99,6 +191,22 @@ func resourceStreamCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	d.SetId(stream.ID)
+	recordNewStream(m, stream.ID, d.GetRawConfig())
+	if _, ok := d.GetOk("rule"); ok {
+		if err := reconcileStreamRules(ctx, cl, stream.ID, newStreamRules(d)); err != nil {
+			return err
+		}
+	}
+	if v, ok := d.GetOk("output_ids"); ok {
+		if _, err := cl.CreateStreamOutputs(ctx, stream.ID, getStringArray(v.(*schema.Set).List())); err != nil {
+			return err
+		}
+	}
+	if _, ok := d.GetOk("alert_receivers"); ok {
+		if err := updateStreamAlertReceivers(ctx, cl, stream.ID, &graylog.AlertReceivers{}, newAlertReceivers(d)); err != nil {
+			return err
+		}
+	}
 	// resume if needed
 	disabled := d.Get("disabled").(bool)
 	if !disabled {
@@ -109,8 +217,7 @@ func resourceStreamCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceStreamRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -119,14 +226,43 @@ func resourceStreamRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
-	return setStream(d, stream, m.(*Config))
+	if err := setStream(d, stream, m.(*Config)); err != nil {
+		return err
+	}
+
+	rules := make([]map[string]interface{}, len(stream.Rules))
+	for i, rule := range stream.Rules {
+		rules[i] = map[string]interface{}{
+			"field":       rule.Field,
+			"value":       rule.Value,
+			"description": rule.Description,
+			"type":        rule.Type,
+			"inverted":    rule.Inverted,
+		}
+	}
+	if err := setToRD(d, "rule", rules); err != nil {
+		return err
+	}
+	outputIDs := make([]string, len(stream.Outputs))
+	for i, output := range stream.Outputs {
+		outputIDs[i] = output.ID
+	}
+	if err := setStrListToRD(d, "output_ids", outputIDs); err != nil {
+		return err
+	}
+	receivers := stream.AlertReceivers
+	if receivers == nil {
+		receivers = &graylog.AlertReceivers{}
+	}
+	return setToRD(d, "alert_receivers", []map[string]interface{}{{
+		"emails": receivers.Emails,
+		"users":  receivers.Users,
+	}})
 
-	// alert_receivers
 	// alert_conditions
 }
 
-func resourceStreamUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -135,9 +271,30 @@ func resourceStreamUpdate(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
+	if err := claimStream(m, stream.ID, d.GetRawConfig()); err != nil {
+		return err
+	}
 	if _, err := cl.UpdateStream(ctx, stream); err != nil {
 		return err
 	}
+	if d.HasChange("rule") {
+		if err := reconcileStreamRules(ctx, cl, stream.ID, newStreamRules(d)); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("output_ids") {
+		if err := updateStreamOutputs(ctx, cl, d); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("alert_receivers") {
+		// the state has the alert receivers on the server, which are read before the update
+		oldV, _ := d.GetChange("alert_receivers")
+		if err := updateStreamAlertReceivers(
+			ctx, cl, stream.ID, alertReceiversFromList(oldV.([]interface{})), newAlertReceivers(d)); err != nil {
+			return err
+		}
+	}
 	if !d.HasChange("disabled") {
 		return nil
 	}
@@ -153,8 +310,7 @@ func resourceStreamUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceStreamDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -0,0 +1,126 @@
+package terraform
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/suzuki-shunsuke/go-set/v6"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+type streamRuleKey struct {
+	field       string
+	value       string
+	description string
+	ruleType    int
+	inverted    bool
+}
+
+func newStreamRuleKey(rule *graylog.StreamRule) streamRuleKey {
+	return streamRuleKey{
+		field:       rule.Field,
+		value:       rule.Value,
+		description: rule.Description,
+		ruleType:    rule.Type,
+		inverted:    rule.Inverted,
+	}
+}
+
+// reconcileStreamRules makes the rules of the stream on the server equal to the given rules.
+// Rules don't have the ids in the configuration, so rules are compared by all the fields.
+// Rules on the server which aren't in the given rules are deleted even if they were created out of band.
+func reconcileStreamRules(
+	ctx context.Context, cl *client.Client, streamID string, rules []graylog.StreamRule,
+) error {
+	current, _, _, err := cl.GetStreamRules(ctx, streamID)
+	if err != nil {
+		return err
+	}
+	desired := make(map[streamRuleKey]bool, len(rules))
+	for i := range rules {
+		desired[newStreamRuleKey(&rules[i])] = false
+	}
+	for i := range current {
+		key := newStreamRuleKey(&current[i])
+		if kept, ok := desired[key]; ok && !kept {
+			desired[key] = true
+			continue
+		}
+		if _, err := cl.DeleteStreamRule(ctx, streamID, current[i].ID); err != nil {
+			return err
+		}
+	}
+	for i := range rules {
+		rule := rules[i]
+		if desired[newStreamRuleKey(&rule)] {
+			continue
+		}
+		rule.StreamID = streamID
+		if _, err := cl.CreateStreamRule(ctx, &rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateStreamOutputs(ctx context.Context, cl *client.Client, d *schema.ResourceData) error {
+	oldV, newV := d.GetChange("output_ids")
+	oldVSet := set.NewStrSet(getStringArray(oldV.(*schema.Set).List())...)
+	newVSet := set.NewStrSet(getStringArray(newV.(*schema.Set).List())...)
+	for k := range oldVSet.ToMap(false) {
+		if newVSet.Has(k) {
+			continue
+		}
+		if _, err := cl.DeleteStreamOutput(ctx, d.Id(), k); err != nil {
+			return err
+		}
+	}
+	added := []string{}
+	for k := range newVSet.ToMap(false) {
+		if !oldVSet.Has(k) {
+			added = append(added, k)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	_, err := cl.CreateStreamOutputs(ctx, d.Id(), added)
+	return err
+}
+
+func updateStreamAlertReceivers(
+	ctx context.Context, cl *client.Client, streamID string, oldV, newV *graylog.AlertReceivers,
+) error {
+	if err := updateStreamAlertReceiversOfType(
+		ctx, cl, streamID, graylog.AlertReceiverTypeEmails, oldV.Emails, newV.Emails); err != nil {
+		return err
+	}
+	return updateStreamAlertReceiversOfType(
+		ctx, cl, streamID, graylog.AlertReceiverTypeUsers, oldV.Users, newV.Users)
+}
+
+func updateStreamAlertReceiversOfType(
+	ctx context.Context, cl *client.Client, streamID, receiverType string, oldV, newV []string,
+) error {
+	oldVSet := set.NewStrSet(oldV...)
+	newVSet := set.NewStrSet(newV...)
+	for _, entity := range oldV {
+		if newVSet.Has(entity) {
+			continue
+		}
+		if _, err := cl.DeleteStreamAlertReceiver(ctx, streamID, receiverType, entity); err != nil {
+			return err
+		}
+	}
+	for _, entity := range newV {
+		if oldVSet.Has(entity) {
+			continue
+		}
+		if _, err := cl.AddStreamAlertReceiver(ctx, streamID, receiverType, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
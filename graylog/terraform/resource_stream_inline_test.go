@@ -0,0 +1,282 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestStreamOwners_claim(t *testing.T) {
+	owners := &streamOwners{}
+	require.Nil(t, owners.claim("", streamAttrRules, "graylog_stream"))
+	require.Nil(t, owners.claim("a", streamAttrRules, "graylog_stream_rule"))
+	require.Nil(t, owners.claim("a", streamAttrRules, "graylog_stream_rule"))
+	require.Nil(t, owners.claim("a", streamAttrOutputs, "graylog_stream"))
+	require.Nil(t, owners.claim("b", streamAttrRules, "graylog_stream"))
+	require.NotNil(t, owners.claim("a", streamAttrRules, "graylog_stream"))
+	require.NotNil(t, owners.claim("a", streamAttrOutputs, "graylog_stream_output"))
+	// a new stream
+	owners.record("c", streamAttrRules, "graylog_stream")
+	require.NotNil(t, owners.claim("c", streamAttrRules, "graylog_stream_rule"))
+}
+
+func TestInlineStreamAttrs(t *testing.T) {
+	ruleType := cty.Object(map[string]cty.Type{"field": cty.String})
+	data := []struct {
+		title  string
+		config cty.Value
+		exp    []string
+	}{
+		{
+			title: "not set",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"rule":       cty.SetValEmpty(ruleType),
+				"output_ids": cty.NullVal(cty.Set(cty.String)),
+			}),
+			exp: []string{},
+		},
+		{
+			title: "set",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"rule": cty.SetVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"field": cty.StringVal("level")}),
+				}),
+				"output_ids": cty.UnknownVal(cty.Set(cty.String)),
+			}),
+			exp: []string{"rule", "output_ids"},
+		},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			require.Equal(t, d.exp, inlineStreamAttrs(d.config))
+		})
+	}
+}
+
+// fakeStreamServer is a fake Graylog API of a stream, its rules, outputs and alert receivers.
+type fakeStreamServer struct {
+	fakeAPI
+	stream    *graylog.Stream
+	ruleCount int
+}
+
+func (s *fakeStreamServer) addRule(rule graylog.StreamRule) {
+	s.ruleCount++
+	rule.ID = fmt.Sprintf("rule%d", s.ruleCount)
+	rule.StreamID = s.stream.ID
+	s.stream.Rules = append(s.stream.Rules, rule)
+}
+
+func (s *fakeStreamServer) handle(req *http.Request) (int, interface{}, error) {
+	base := "/api/streams/" + s.stream.ID
+	path := req.URL.Path
+	switch {
+	case req.Method == "POST" && path == "/api/streams":
+		return 201, map[string]string{"stream_id": s.stream.ID}, nil
+	case req.Method == "POST" && (path == base+"/resume" || path == base+"/pause"):
+		return 204, nil, nil
+	case req.Method == "GET" && path == base:
+		return 200, s.stream, nil
+	case req.Method == "PUT" && path == base:
+		return 200, s.stream, nil
+	case req.Method == "DELETE" && path == base:
+		return 204, nil, nil
+	case req.Method == "GET" && path == base+"/rules":
+		return 200, &graylog.StreamRulesBody{Total: len(s.stream.Rules), StreamRules: s.stream.Rules}, nil
+	case req.Method == "POST" && path == base+"/rules":
+		rule := graylog.StreamRule{}
+		if err := json.NewDecoder(req.Body).Decode(&rule); err != nil {
+			return 0, nil, err
+		}
+		s.addRule(rule)
+		return 201, map[string]string{"streamrule_id": s.stream.Rules[len(s.stream.Rules)-1].ID}, nil
+	case req.Method == "DELETE" && strings.HasPrefix(path, base+"/rules/"):
+		id := strings.TrimPrefix(path, base+"/rules/")
+		rules := []graylog.StreamRule{}
+		for _, rule := range s.stream.Rules {
+			if rule.ID != id {
+				rules = append(rules, rule)
+			}
+		}
+		s.stream.Rules = rules
+		return 204, nil, nil
+	case req.Method == "POST" && path == base+"/outputs":
+		body := map[string][]string{}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return 0, nil, err
+		}
+		for _, id := range body["outputs"] {
+			s.stream.Outputs = append(s.stream.Outputs, graylog.Output{ID: id})
+		}
+		return 201, nil, nil
+	case req.Method == "DELETE" && strings.HasPrefix(path, base+"/outputs/"):
+		id := strings.TrimPrefix(path, base+"/outputs/")
+		outputs := []graylog.Output{}
+		for _, output := range s.stream.Outputs {
+			if output.ID != id {
+				outputs = append(outputs, output)
+			}
+		}
+		s.stream.Outputs = outputs
+		return 204, nil, nil
+	case path == base+"/alerts/receivers":
+		entity := req.URL.Query().Get("entity")
+		receivers := &s.stream.AlertReceivers.Emails
+		if req.URL.Query().Get("type") == graylog.AlertReceiverTypeUsers {
+			receivers = &s.stream.AlertReceivers.Users
+		}
+		if req.Method == "POST" {
+			*receivers = append(*receivers, entity)
+			return 201, nil, nil
+		}
+		a := []string{}
+		for _, e := range *receivers {
+			if e != entity {
+				a = append(a, e)
+			}
+		}
+		*receivers = a
+		return 204, nil, nil
+	}
+	return 404, map[string]string{"message": "not found: " + req.Method + " " + path}, nil
+}
+
+func (s *fakeStreamServer) checkStream(rules []string, outputIDs []string, emails, users []string) resource.TestCheckFunc {
+	return s.check(func() error {
+		actualRules := make([]string, len(s.stream.Rules))
+		for i, rule := range s.stream.Rules {
+			actualRules[i] = rule.Field + "=" + rule.Value
+		}
+		actualOutputIDs := make([]string, len(s.stream.Outputs))
+		for i, output := range s.stream.Outputs {
+			actualOutputIDs[i] = output.ID
+		}
+		for _, a := range [][]string{actualRules, actualOutputIDs, rules, outputIDs} {
+			sort.Strings(a)
+		}
+		if fmt.Sprint(actualRules) != fmt.Sprint(rules) {
+			return fmt.Errorf("rules: expected %v, got %v", rules, actualRules)
+		}
+		if fmt.Sprint(actualOutputIDs) != fmt.Sprint(outputIDs) {
+			return fmt.Errorf("outputs: expected %v, got %v", outputIDs, actualOutputIDs)
+		}
+		receivers := s.stream.AlertReceivers
+		if fmt.Sprint(receivers.Emails) != fmt.Sprint(emails) || fmt.Sprint(receivers.Users) != fmt.Sprint(users) {
+			return fmt.Errorf("alert receivers: expected %v %v, got %v %v", emails, users, receivers.Emails, receivers.Users)
+		}
+		return nil
+	})
+}
+
+func TestAccStream_inline(t *testing.T) {
+	setEnv()
+	server := &fakeStreamServer{
+		stream: &graylog.Stream{
+			ID:             "000000000000000000000003",
+			Title:          "test",
+			IndexSetID:     "5d84bfbe2ab79c000d35d4a9",
+			MatchingType:   "AND",
+			Rules:          []graylog.StreamRule{},
+			Outputs:        []graylog.Output{},
+			AlertReceivers: &graylog.AlertReceivers{Emails: []string{}, Users: []string{}},
+		},
+	}
+
+	defer server.use(t, server.handle)()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "graylog_stream" "test" {
+  title         = "test"
+  index_set_id  = "5d84bfbe2ab79c000d35d4a9"
+  matching_type = "AND"
+  output_ids    = ["out1"]
+
+  rule {
+    field = "level"
+    value = "error"
+    type  = 1
+  }
+
+  alert_receivers {
+    emails = ["foo@example.com"]
+  }
+}
+`,
+				Check: server.checkStream([]string{"level=error"}, []string{"out1"}, []string{"foo@example.com"}, []string{}),
+			},
+			{
+				// rules which aren't in the configuration are removed
+				PreConfig: func() {
+					server.update(func() {
+						server.addRule(graylog.StreamRule{Field: "source", Value: "out-of-band", Type: 1})
+					})
+				},
+				Config: `
+resource "graylog_stream" "test" {
+  title         = "test"
+  index_set_id  = "5d84bfbe2ab79c000d35d4a9"
+  matching_type = "AND"
+  output_ids    = ["out2"]
+
+  rule {
+    field = "level"
+    value = "error"
+    type  = 1
+  }
+
+  rule {
+    field = "facility"
+    value = "app"
+    type  = 1
+  }
+
+  alert_receivers {
+    users = ["admin"]
+  }
+}
+`,
+				Check: server.checkStream(
+					[]string{"level=error", "facility=app"}, []string{"out2"}, []string{}, []string{"admin"}),
+			},
+			{
+				PlanOnly: true,
+				Config: `
+resource "graylog_stream" "test" {
+  title         = "test"
+  index_set_id  = "5d84bfbe2ab79c000d35d4a9"
+  matching_type = "AND"
+
+  rule {
+    field = "level"
+    value = "error"
+    type  = 1
+  }
+}
+
+resource "graylog_stream_rule" "test" {
+  stream_id = graylog_stream.test.id
+  field     = "source"
+  value     = "app"
+  type      = 1
+}
+`,
+				ExpectError: regexp.MustCompile(`the rules of the stream 000000000000000000000003 are managed by both graylog_stream and graylog_stream_rule`),
+			},
+		},
+	})
+}
@@ -3,21 +3,25 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-set/v6"
 )
 
 func resourceStreamOutput() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStreamOutputCreate,
-		Read:   resourceStreamOutputRead,
-		Update: resourceStreamOutputUpdate,
-		Delete: resourceStreamOutputDelete,
+		CreateContext: wrapCRUD(resourceStreamOutputCreate),
+		ReadContext:   wrapCRUD(resourceStreamOutputRead),
+		UpdateContext: wrapCRUD(resourceStreamOutputUpdate),
+		DeleteContext: wrapCRUD(resourceStreamOutputDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceStreamOutputCustomizeDiff,
+
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"stream_id": {
@@ -38,8 +42,14 @@ func newStreamOutput(d *schema.ResourceData) (string, []string) {
 	return d.Get("stream_id").(string), getStringArray(d.Get("output_ids").(*schema.Set).List())
 }
 
-func resourceStreamOutputCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamOutputCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return m.(*Config).streamOwners.claim(d.Get("stream_id").(string), streamAttrOutputs, "graylog_stream_output")
+}
+
+func resourceStreamOutputCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := m.(*Config).streamOwners.claim(d.Get("stream_id").(string), streamAttrOutputs, "graylog_stream_output"); err != nil {
+		return err
+	}
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -52,8 +62,7 @@ func resourceStreamOutputCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceStreamOutputRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamOutputRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -76,8 +85,7 @@ func resourceStreamOutputRead(d *schema.ResourceData, m interface{}) error {
 	return setStrListToRD(d, "output_ids", outputIDs)
 }
 
-func resourceStreamOutputUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamOutputUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -105,8 +113,7 @@ func resourceStreamOutputUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceStreamOutputDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamOutputDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -3,22 +3,26 @@ package terraform
 import (
 	"context"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
 func resourceStreamRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStreamRuleCreate,
-		Read:   resourceStreamRuleRead,
-		Update: resourceStreamRuleUpdate,
-		Delete: resourceStreamRuleDelete,
+		CreateContext: wrapCRUD(resourceStreamRuleCreate),
+		ReadContext:   wrapCRUD(resourceStreamRuleRead),
+		UpdateContext: wrapCRUD(resourceStreamRuleUpdate),
+		DeleteContext: wrapCRUD(resourceStreamRuleDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: genImport("stream_id", "stream_rule_id"),
+			StateContext: genImport("stream_id", "stream_rule_id"),
 		},
 
+		CustomizeDiff: resourceStreamRuleCustomizeDiff,
+
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"field": {
@@ -64,8 +68,14 @@ func newStreamRule(d *schema.ResourceData) (*graylog.StreamRule, error) {
 	}, nil
 }
 
-func resourceStreamRuleCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return m.(*Config).streamOwners.claim(d.Get("stream_id").(string), streamAttrRules, "graylog_stream_rule")
+}
+
+func resourceStreamRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := m.(*Config).streamOwners.claim(d.Get("stream_id").(string), streamAttrRules, "graylog_stream_rule"); err != nil {
+		return err
+	}
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -82,8 +92,7 @@ func resourceStreamRuleCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceStreamRuleRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -110,8 +119,7 @@ func resourceStreamRuleRead(d *schema.ResourceData, m interface{}) error {
 	return setBoolToRD(d, "inverted", rule.Inverted)
 }
 
-func resourceStreamRuleUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -126,8 +134,7 @@ func resourceStreamRuleUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceStreamRuleDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceStreamRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/suzuki-shunsuke/go-set/v6"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
@@ -12,15 +12,17 @@ import (
 
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserCreate,
-		Read:   resourceUserRead,
-		Update: resourceUserUpdate,
-		Delete: resourceUserDelete,
+		CreateContext: wrapCRUD(resourceUserCreate),
+		ReadContext:   wrapCRUD(resourceUserRead),
+		UpdateContext: wrapCRUD(resourceUserUpdate),
+		DeleteContext: wrapCRUD(resourceUserDelete),
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			// Required
 			"username": {
@@ -119,8 +121,7 @@ func newUser(d *schema.ResourceData) *graylog.User {
 	}
 }
 
-func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -136,8 +137,7 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 	return setStrToRD(d, "user_id", user.ID)
 }
 
-func resourceUserRead(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -146,6 +146,10 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return handleGetResourceError(d, ei, err)
 	}
+	return setUser(d, user)
+}
+
+func setUser(d *schema.ResourceData, user *graylog.User) error {
 	if err := setStrToRD(d, "username", user.Username); err != nil {
 		return err
 	}
@@ -185,8 +189,7 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	return setStrToRD(d, "last_activity", user.LastActivity)
 }
 
-func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -196,8 +199,7 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 	return err
 }
 
-func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
-	ctx := context.Background()
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	cl, err := newClient(m)
 	if err != nil {
 		return err
@@ -0,0 +1,103 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceUserRole binds a role to a user.
+// Unlike graylog_role_members, the other members of the role aren't changed.
+func resourceUserRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceUserRoleCreate),
+		ReadContext:   wrapCRUD(resourceUserRoleRead),
+		DeleteContext: wrapCRUD(resourceUserRoleDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceUserRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	username := d.Get("username").(string)
+	roleName := d.Get("role_name").(string)
+	if _, err := cl.AddUserToRole(ctx, username, roleName); err != nil {
+		return err
+	}
+	d.SetId(username + "/" + roleName)
+	return resourceUserRoleRead(ctx, d, m)
+}
+
+// parseUserRoleID parses the ID "<username>/<role name>".
+func parseUserRoleID(id string) (string, string, error) {
+	a := strings.SplitN(id, "/", 2)
+	if len(a) != 2 || a[0] == "" || a[1] == "" {
+		return "", "", errors.New("format of ID should be <username>/<role name>: " + id)
+	}
+	return a[0], a[1], nil
+}
+
+func resourceUserRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	username, roleName, err := parseUserRoleID(d.Id())
+	if err != nil {
+		return err
+	}
+	user, ei, err := cl.GetUser(ctx, username)
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	if !user.Roles.Has(roleName) {
+		// the role is removed from the user out of band
+		d.SetId("")
+		return nil
+	}
+	if err := setStrToRD(d, "username", username); err != nil {
+		return err
+	}
+	return setStrToRD(d, "role_name", roleName)
+}
+
+func resourceUserRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	username, roleName, err := parseUserRoleID(d.Id())
+	if err != nil {
+		return err
+	}
+	if ei, err := cl.RemoveUserFromRole(ctx, username, roleName); err != nil {
+		if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+			return err
+		}
+	}
+	return nil
+}
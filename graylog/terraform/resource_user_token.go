@@ -0,0 +1,275 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+const defaultUserTokenFilePermission = "0600"
+
+func resourceUserToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: wrapCRUD(resourceUserTokenCreate),
+		ReadContext:   wrapCRUD(resourceUserTokenRead),
+		UpdateContext: wrapCRUD(resourceUserTokenUpdate),
+		DeleteContext: wrapCRUD(resourceUserTokenDelete),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: genImport("username", "token_id"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Optional
+			// The token is rotated when rotation_trigger or keepers are changed.
+			"rotation_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"local_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"file_permission": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          defaultUserTokenFilePermission,
+				ValidateDiagFunc: wrapValidateFunc(validateFuncFilePermission),
+			},
+
+			// Computed
+			"token_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"last_access": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// validateFuncFilePermission checks the value is an octal file permission which doesn't allow other users to access the file.
+func validateFuncFilePermission(v interface{}, k string) error {
+	perm, err := strconv.ParseUint(v.(string), 8, 32)
+	if err != nil || perm > 0777 {
+		return fmt.Errorf("'%s' must be an octal file permission such as \"0600\": %s", k, v)
+	}
+	if perm&0007 != 0 {
+		return fmt.Errorf("'%s' must not allow other users to access the token file: %s", k, v)
+	}
+	return nil
+}
+
+func getFilePermission(d *schema.ResourceData) os.FileMode {
+	// the permission is validated
+	perm, _ := strconv.ParseUint(d.Get("file_permission").(string), 8, 32)
+	return os.FileMode(perm)
+}
+
+// writeUserTokenFile writes the token to the file.
+// The token is written to a temporary file which only the owner can access and the file is renamed,
+// so that the token isn't written to the existing file whose permission may allow other users to read it.
+func writeUserTokenFile(path, token string, perm os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".")
+	if err != nil {
+		return fmt.Errorf("failed to create a temporary file to write the token: %w", err)
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+	if _, err := f.WriteString(token); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write the token to the file %s: %w", tmp, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write the token to the file %s: %w", tmp, err)
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		return fmt.Errorf("failed to change the permission of the file %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write the token to the file %s: %w", path, err)
+	}
+	return nil
+}
+
+// hasUserTokenFile returns true if the file has the token.
+func hasUserTokenFile(path, token string) bool {
+	b, err := ioutil.ReadFile(path)
+	return err == nil && string(b) == token
+}
+
+// removeUserTokenFile removes the file only if the file has the token,
+// so that the file which is changed by others isn't removed.
+func removeUserTokenFile(path, token string) error {
+	if path == "" || token == "" || !hasUserTokenFile(path, token) {
+		return nil
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove the token file %s: %w", path, err)
+	}
+	return nil
+}
+
+func resourceUserTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	token, _, err := cl.CreateUserToken(ctx, d.Get("username").(string), d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+	if token.Token == "" {
+		return errors.New("the created token is empty")
+	}
+	// Graylog 3.0 doesn't return the token id
+	id := token.ID
+	if id == "" {
+		id = token.Name
+	}
+	d.SetId(id)
+	if err := setStrToRD(d, "token", token.Token); err != nil {
+		return err
+	}
+	if path := d.Get("local_file").(string); path != "" {
+		if err := writeUserTokenFile(path, token.Token, getFilePermission(d)); err != nil {
+			return err
+		}
+	}
+	return resourceUserTokenRead(ctx, d, m)
+}
+
+// findUserToken returns the token whose id is the resource id.
+// If the token id isn't supported, the token is found by the name and the token.
+func findUserToken(tokens []graylog.UserToken, d *schema.ResourceData) *graylog.UserToken {
+	for i, token := range tokens {
+		if token.ID != "" {
+			if token.ID == d.Id() {
+				return &tokens[i]
+			}
+			continue
+		}
+		if token.Name == d.Id() && token.Token == d.Get("token").(string) {
+			return &tokens[i]
+		}
+	}
+	return nil
+}
+
+func resourceUserTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	tokens, ei, err := cl.GetUserTokens(ctx, d.Get("username").(string))
+	if err != nil {
+		return handleGetResourceError(d, ei, err)
+	}
+	token := findUserToken(tokens, d)
+	if token == nil {
+		// the token is deleted out of band
+		d.SetId("")
+		return nil
+	}
+	// token_id is empty if the token id isn't supported
+	if err := setStrToRD(d, "token_id", token.ID); err != nil {
+		return err
+	}
+	if err := setStrToRD(d, "name", token.Name); err != nil {
+		return err
+	}
+	// the list API doesn't return the token since Graylog 4.0
+	if token.Token != "" {
+		if err := setStrToRD(d, "token", token.Token); err != nil {
+			return err
+		}
+	}
+	if err := setStrToRD(d, "last_access", token.LastAccess); err != nil {
+		return err
+	}
+	// if the token file is removed or changed, it is written again at the update
+	if path := d.Get("local_file").(string); path != "" && !hasUserTokenFile(path, d.Get("token").(string)) {
+		return setStrToRD(d, "local_file", "")
+	}
+	return nil
+}
+
+func resourceUserTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	// the other attributes are ForceNew
+	token := d.Get("token").(string)
+	if d.HasChange("local_file") {
+		oldV, _ := d.GetChange("local_file")
+		if err := removeUserTokenFile(oldV.(string), token); err != nil {
+			return err
+		}
+	}
+	if path := d.Get("local_file").(string); path != "" {
+		if token == "" {
+			return errors.New("the token can't be written to the file because the token isn't known. Recreate the token")
+		}
+		if err := writeUserTokenFile(path, token, getFilePermission(d)); err != nil {
+			return err
+		}
+	}
+	return resourceUserTokenRead(ctx, d, m)
+}
+
+func resourceUserTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := newClient(m)
+	if err != nil {
+		return err
+	}
+	username := d.Get("username").(string)
+	token := d.Get("token").(string)
+	if d.Get("token_id").(string) == "" {
+		// Graylog 3.0 doesn't support the token id, so the token is deleted by the token itself.
+		// 404 isn't ignored because the token isn't deleted
+		if token == "" {
+			return errors.New("the token can't be deleted because the token isn't known")
+		}
+		if _, err := cl.DeleteUserToken(ctx, username, token); err != nil {
+			return err
+		}
+	} else if ei, err := cl.DeleteUserToken(ctx, username, d.Id()); err != nil {
+		if ei == nil || ei.Response == nil || ei.Response.StatusCode != 404 {
+			return err
+		}
+	}
+	return removeUserTokenFile(d.Get("local_file").(string), token)
+}
@@ -0,0 +1,251 @@
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
+)
+
+func TestValidateFuncFilePermission(t *testing.T) {
+	require.Nil(t, validateFuncFilePermission("0600", "file_permission"))
+	require.Nil(t, validateFuncFilePermission("0640", "file_permission"))
+	require.NotNil(t, validateFuncFilePermission("0644", "file_permission"))
+	require.NotNil(t, validateFuncFilePermission("rw-------", "file_permission"))
+	require.NotNil(t, validateFuncFilePermission("01000", "file_permission"))
+}
+
+func TestWriteUserTokenFile(t *testing.T) {
+	// the token isn't written to the existing file whose permission allows other users to read it
+	path := filepath.Join(t.TempDir(), "token")
+	require.Nil(t, ioutil.WriteFile(path, []byte("old"), 0644))
+	require.Nil(t, writeUserTokenFile(path, "secret", 0600))
+	fi, err := os.Stat(path)
+	require.Nil(t, err)
+	require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	require.True(t, hasUserTokenFile(path, "secret"))
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	require.Nil(t, err)
+	require.Len(t, files, 1, "the temporary file should be removed")
+}
+
+// fakeUserTokenServer is a fake Graylog 4 API of user tokens, whose list API doesn't return tokens.
+// If legacy is true, it is a fake Graylog 3.0 API, which doesn't support the token id.
+type fakeUserTokenServer struct {
+	fakeAPI
+	tokens []graylog.UserToken
+	count  int
+	legacy bool
+}
+
+func (s *fakeUserTokenServer) handle(req *http.Request) (int, interface{}, error) {
+	base := "/api/users/foo/tokens"
+	switch {
+	case req.Method == "GET" && req.URL.Path == base:
+		tokens := make([]graylog.UserToken, len(s.tokens))
+		for i, token := range s.tokens {
+			if s.legacy {
+				token.ID = ""
+			} else {
+				token.Token = ""
+			}
+			tokens[i] = token
+		}
+		return 200, map[string]interface{}{"tokens": tokens}, nil
+	case req.Method == "POST" && strings.HasPrefix(req.URL.Path, base+"/"):
+		s.count++
+		token := graylog.UserToken{
+			ID:    fmt.Sprintf("tok%d", s.count),
+			Name:  strings.TrimPrefix(req.URL.Path, base+"/"),
+			Token: fmt.Sprintf("secret%d", s.count),
+		}
+		s.tokens = append(s.tokens, token)
+		if s.legacy {
+			token.ID = ""
+		}
+		return 200, token, nil
+	case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, base+"/"):
+		if s.remove(strings.TrimPrefix(req.URL.Path, base+"/")) {
+			return 204, nil, nil
+		}
+	}
+	return fakeNotFound()
+}
+
+// remove removes the token by the token id or the token itself, and returns false if the token isn't found.
+// Graylog 3.0 removes the token only by the token itself.
+func (s *fakeUserTokenServer) remove(id string) bool {
+	tokens := []graylog.UserToken{}
+	for _, token := range s.tokens {
+		if token.Token == id || (!s.legacy && token.ID == id) {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	removed := len(tokens) != len(s.tokens)
+	s.tokens = tokens
+	return removed
+}
+
+func (s *fakeUserTokenServer) checkIDs(ids ...string) resource.TestCheckFunc {
+	return s.check(func() error {
+		actual := []string{}
+		for _, token := range s.tokens {
+			actual = append(actual, token.ID)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(ids) {
+			return fmt.Errorf("tokens: expected %v, got %v", ids, actual)
+		}
+		return nil
+	})
+}
+
+func checkUserTokenFile(path, token string, perm os.FileMode) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if fi.Mode().Perm() != perm {
+			return fmt.Errorf("the permission of the token file: expected %o, got %o", perm, fi.Mode().Perm())
+		}
+		if !hasUserTokenFile(path, token) {
+			return fmt.Errorf("the token file doesn't have the token %s", token)
+		}
+		return nil
+	}
+}
+
+func TestAccUserToken(t *testing.T) {
+	setEnv()
+	server := &fakeUserTokenServer{}
+	path := filepath.Join(t.TempDir(), "token")
+
+	defer server.use(t, server.handle)()
+
+	genConfig := func(keeper, perm string) string {
+		return fmt.Sprintf(`
+resource "graylog_user_token" "test" {
+  username        = "foo"
+  name            = "ci"
+  local_file      = %q
+  file_permission = %q
+  keepers = {
+    version = %q
+  }
+}
+`, path, perm, keeper)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy: func(s *terraform.State) error {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				return fmt.Errorf("the token file should be removed: %v", err)
+			}
+			return server.checkIDs()(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				PlanOnly:    true,
+				Config:      genConfig("1", "0644"),
+				ExpectError: regexp.MustCompile(`must not allow other users to access the token file`),
+			},
+			{
+				Config: genConfig("1", "0600"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_user_token.test", "token_id", "tok1"),
+					resource.TestCheckResourceAttr("graylog_user_token.test", "token", "secret1"),
+					checkUserTokenFile(path, "secret1", 0600),
+				),
+			},
+			{
+				// the token is deleted out of band, so it is created again
+				PreConfig: func() {
+					server.update(func() {
+						server.remove("tok1")
+					})
+				},
+				Config: genConfig("1", "0600"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_user_token.test", "token_id", "tok2"),
+					checkUserTokenFile(path, "secret2", 0600),
+					server.checkIDs("tok2"),
+				),
+			},
+			{
+				// the removed token file is written again
+				PreConfig: func() {
+					require.Nil(t, os.Remove(path))
+				},
+				Config: genConfig("1", "0640"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_user_token.test", "token_id", "tok2"),
+					checkUserTokenFile(path, "secret2", 0640),
+				),
+			},
+			{
+				// rotation
+				Config: genConfig("2", "0600"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_user_token.test", "token_id", "tok3"),
+					checkUserTokenFile(path, "secret3", 0600),
+					server.checkIDs("tok3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserToken_legacy(t *testing.T) {
+	setEnv()
+	server := &fakeUserTokenServer{legacy: true}
+
+	defer server.use(t, server.handle)()
+
+	genConfig := func(keeper string) string {
+		return fmt.Sprintf(`
+resource "graylog_user_token" "test" {
+  username = "foo"
+  name     = "ci"
+  keepers = {
+    version = %q
+  }
+}
+`, keeper)
+	}
+
+	// Graylog 3.0 doesn't support the token id, so the token is deleted by the token itself
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: getTestProviderFactories(),
+		CheckDestroy:      server.checkIDs(),
+		Steps: []resource.TestStep{
+			{
+				Config: genConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_user_token.test", "id", "ci"),
+					resource.TestCheckResourceAttr("graylog_user_token.test", "token_id", ""),
+					resource.TestCheckResourceAttr("graylog_user_token.test", "token", "secret1"),
+				),
+			},
+			{
+				// rotation
+				Config: genConfig("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_user_token.test", "token", "secret2"),
+					server.checkIDs("tok2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,97 @@
+package terraform
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// streamOwners records which resource type manages the rules and the outputs of each stream in a run,
+// so that the inline "rule" blocks and "output_ids" of graylog_stream can't be used
+// together with graylog_stream_rule and graylog_stream_output for the same stream.
+// Resources in other configurations can't be detected.
+type streamOwners struct {
+	mutex  sync.Mutex
+	owners map[string]string
+}
+
+// claim records that the resource type manages the attribute of the stream.
+// It returns an error if the attribute is managed by another resource type.
+// The stream id of a new stream is unknown at plan time, so the conflict is detected
+// when graylog_stream_rule or graylog_stream_output is created after the stream.
+func (o *streamOwners) claim(streamID, attr, owner string) error {
+	if streamID == "" {
+		return nil
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.owners == nil {
+		o.owners = map[string]string{}
+	}
+	key := streamID + "/" + attr
+	if other, ok := o.owners[key]; ok && other != owner {
+		return fmt.Errorf(
+			`the %s of the stream %s are managed by both %s and %s. Use either of them`,
+			streamAttrNames[attr], streamID, other, owner)
+	}
+	o.owners[key] = owner
+	return nil
+}
+
+const (
+	streamAttrRules   = "rule"
+	streamAttrOutputs = "output_ids"
+)
+
+var streamAttrNames = map[string]string{
+	streamAttrRules:   "rules",
+	streamAttrOutputs: "outputs",
+}
+
+// inlineStreamAttrs returns the attributes of graylog_stream which manage the rules and the outputs inline.
+// An attribute which is set in the configuration is authoritative.
+func inlineStreamAttrs(config cty.Value) []string {
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	attrs := []string{}
+	for _, attr := range []string{streamAttrRules, streamAttrOutputs} {
+		v := config.GetAttr(attr)
+		if v.IsNull() {
+			continue
+		}
+		if !v.IsKnown() || v.LengthInt() > 0 {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// record records that the resource type manages the attribute of the stream without the conflict check.
+// It is used for a new stream, whose id can't be claimed by other resources yet.
+func (o *streamOwners) record(streamID, attr, owner string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.owners == nil {
+		o.owners = map[string]string{}
+	}
+	o.owners[streamID+"/"+attr] = owner
+}
+
+// recordNewStream records that graylog_stream manages the attributes of the new stream inline.
+func recordNewStream(m interface{}, streamID string, config cty.Value) {
+	for _, attr := range inlineStreamAttrs(config) {
+		m.(*Config).streamOwners.record(streamID, attr, "graylog_stream")
+	}
+}
+
+// claimStream records that graylog_stream manages the attributes of the stream inline.
+func claimStream(m interface{}, streamID string, config cty.Value) error {
+	for _, attr := range inlineStreamAttrs(config) {
+		if err := m.(*Config).streamOwners.claim(streamID, attr, "graylog_stream"); err != nil {
+			return &attrError{key: attr, err: err}
+		}
+	}
+	return nil
+}
@@ -1,16 +1,26 @@
 package terraform
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/suzuki-shunsuke/go-jsoneq/jsoneq"
 
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/client"
 )
 
+const defaultTimeout = 5 * time.Minute
+
 func schemaDiffSuppressJSONString(k, oldV, newV string, d *schema.ResourceData) bool {
 	b, err := jsoneq.Equal([]byte(oldV), []byte(newV))
 	if err != nil {
@@ -19,17 +29,162 @@ func schemaDiffSuppressJSONString(k, oldV, newV string, d *schema.ResourceData)
 	return b
 }
 
-func wrapValidateFunc(f func(v interface{}, k string) error) schema.SchemaValidateFunc {
-	return func(v interface{}, k string) (s []string, es []error) {
+// wrapValidateFunc converts a function to a SchemaValidateDiagFunc, whose diagnostics have the attribute path.
+func wrapValidateFunc(f func(v interface{}, k string) error) schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(v interface{}, k string) (s []string, es []error) {
 		if err := f(v, k); err != nil {
 			es = append(es, err)
 		}
 		return
+	})
+}
+
+// crudFunc is a CRUD function of resources and data sources which returns an error instead of diagnostics.
+type crudFunc func(ctx context.Context, d *schema.ResourceData, m interface{}) error
+
+// wrapCRUD converts a crudFunc to a function which returns diagnostics.
+// If the error is an attrError, the diagnostic has the attribute path.
+func wrapCRUD(f crudFunc) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		err := f(ctx, d, m)
+		if err == nil {
+			return nil
+		}
+		var ae *attrError
+		if errors.As(err, &ae) {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       err.Error(),
+				AttributePath: attrPath(ae.key),
+			}}
+		}
+		return diag.FromErr(err)
+	}
+}
+
+// attrError is an error of an attribute. The key is joined with "." such as "config.0.series".
+type attrError struct {
+	key string
+	err error
+}
+
+func (e *attrError) Error() string {
+	return fmt.Sprintf("'%s': %v", e.key, e.err)
+}
+
+func (e *attrError) Unwrap() error {
+	return e.err
+}
+
+func attrPath(key string) cty.Path {
+	path := cty.Path{}
+	for _, k := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(k); err == nil {
+			path = path.IndexInt(i)
+			continue
+		}
+		path = path.GetAttr(k)
+	}
+	return path
+}
+
+// defaultTimeouts returns the default timeouts of resources, which can be changed with the "timeouts" block.
+func defaultTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultTimeout),
+		Read:   schema.DefaultTimeout(defaultTimeout),
+		Update: schema.DefaultTimeout(defaultTimeout),
+		Delete: schema.DefaultTimeout(defaultTimeout),
+	}
+}
+
+// validateFuncURL returns a SchemaValidateDiagFunc which checks the value is an absolute URL with one of given schemes.
+func validateFuncURL(schemes ...string) schema.SchemaValidateDiagFunc {
+	return wrapValidateFunc(func(v interface{}, k string) error {
+		u, err := url.Parse(v.(string))
+		if err != nil {
+			return fmt.Errorf("'%s' must be a URL: %w", k, err)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("'%s' must be an absolute URL: %s", k, v)
+		}
+		for _, scheme := range schemes {
+			if u.Scheme == scheme {
+				return nil
+			}
+		}
+		return fmt.Errorf("the scheme of '%s' must be one of %s: %s", k, strings.Join(schemes, ", "), v)
+	})
+}
+
+// validateFuncOneOf returns a SchemaValidateDiagFunc which checks the value is one of given values.
+func validateFuncOneOf(values ...string) schema.SchemaValidateDiagFunc {
+	return wrapValidateFunc(func(v interface{}, k string) error {
+		for _, value := range values {
+			if v.(string) == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("'%s' must be one of %s: %s", k, strings.Join(values, ", "), v)
+	})
+}
+
+func validateFuncDuration(v interface{}, k string) error {
+	d, err := parseDuration(v.(string))
+	if err != nil {
+		return fmt.Errorf("'%s' must be a duration such as \"5m\": %w", k, err)
+	}
+	if d < time.Millisecond {
+		return fmt.Errorf("'%s' must be at least 1ms", k)
+	}
+	return nil
+}
+
+func schemaDiffSuppressDuration(k, oldV, newV string, d *schema.ResourceData) bool {
+	oldD, err := parseDuration(oldV)
+	if err != nil {
+		return false
+	}
+	newD, err := parseDuration(newV)
+	if err != nil {
+		return false
 	}
+	return oldD == newD
 }
 
-func genImport(keys ...string) schema.StateFunc {
-	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+// parseDuration is like time.ParseDuration but also accepts days such as "1d" and "1d12h".
+// A day is 24 hours.
+func parseDuration(s string) (time.Duration, error) {
+	idx := strings.Index(s, "d")
+	if idx < 0 {
+		return time.ParseDuration(s)
+	}
+	days, err := strconv.Atoi(s[:idx])
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	d := time.Duration(days) * 24 * time.Hour
+	if rest := s[idx+1:]; rest != "" {
+		r, err := time.ParseDuration(rest)
+		if err != nil || r < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d += r
+	}
+	return d, nil
+}
+
+// parseDurationMS parses a duration string such as "5m" and "1d" and returns it in milliseconds.
+func parseDurationMS(s string) (int, error) {
+	d, err := parseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return int(d / time.Millisecond), nil
+}
+
+func genImport(keys ...string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 		a := strings.Split(d.Id(), "/")
 		size := len(keys)
 		if len(a) != size {
@@ -72,25 +227,7 @@ func getStringArray(src []interface{}) []string {
 }
 
 func newClient(m interface{}) (*client.Client, error) {
-	config := m.(*Config)
-	var (
-		cl  *client.Client
-		err error
-	)
-	if config.APIVersion == "v3" {
-		cl, err = client.NewClientV3(
-			config.Endpoint, config.AuthName, config.AuthPassword)
-	} else {
-		cl, err = client.NewClient(
-			config.Endpoint, config.AuthName, config.AuthPassword)
-	}
-	if err != nil {
-		return cl, err
-	}
-	if config.XRequestedBy != "" {
-		cl.SetXRequestedBy(config.XRequestedBy)
-	}
-	return cl, nil
+	return m.(*Config).Client()
 }
 
 func setEnv() {
@@ -101,24 +238,32 @@ func setEnv() {
 	os.Setenv("GRAYLOG_API_VERSION", "v3")
 }
 
+// setToRD sets the value and returns an attrError if it fails.
+func setToRD(d *schema.ResourceData, key string, val interface{}) error {
+	if err := d.Set(key, val); err != nil {
+		return &attrError{key: key, err: err}
+	}
+	return nil
+}
+
 func setStrListToRD(d *schema.ResourceData, key string, val []string) error {
-	return d.Set(key, val)
+	return setToRD(d, key, val)
 }
 
 func setMapStrToStrToRD(d *schema.ResourceData, key string, val map[string]string) error {
-	return d.Set(key, val)
+	return setToRD(d, key, val)
 }
 
 func setStrToRD(d *schema.ResourceData, key, val string) error {
-	return d.Set(key, val)
+	return setToRD(d, key, val)
 }
 
 func setIntToRD(d *schema.ResourceData, key string, val int) error {
-	return d.Set(key, val)
+	return setToRD(d, key, val)
 }
 
 func setBoolToRD(d *schema.ResourceData, key string, val bool) error {
-	return d.Set(key, val)
+	return setToRD(d, key, val)
 }
 
 func hasChange(d *schema.ResourceData, keys ...string) bool {
@@ -1,7 +1,10 @@
 package terraform
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -9,8 +12,10 @@ import (
 	"sync"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/resource"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/suzuki-shunsuke/flute/flute"
@@ -191,7 +196,7 @@ func (tc *testCase) Get() (http.RoundTripper, resource.TestCase, error) {
 				},
 			},
 		}, resource.TestCase{
-			Providers: getTestProviders(),
+			ProviderFactories: getTestProviderFactories(),
 			Steps: []resource.TestStep{
 				{
 					Config: string(createTF),
@@ -203,9 +208,11 @@ func (tc *testCase) Get() (http.RoundTripper, resource.TestCase, error) {
 		}, nil
 }
 
-func getTestProviders() map[string]terraform.ResourceProvider {
-	return map[string]terraform.ResourceProvider{
-		"graylog": Provider(),
+func getTestProviderFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"graylog": func() (*schema.Provider, error) {
+			return Provider(), nil
+		},
 	}
 }
 
@@ -241,3 +248,123 @@ func (store *bodyStore) Set(body string) {
 	store.body = body
 	store.mutex.Unlock()
 }
+
+// fakeAPI is the base of stateful fake Graylog APIs for acceptance tests.
+// Requests are handled and the state is checked with the lock.
+// Use testCase and testdata if the requests are fixed,
+// and use fakeAPI only if the test changes the state out of band or the response depends on the former requests.
+type fakeAPI struct {
+	mutex sync.Mutex
+}
+
+// fakeHandler handles a request of a fake API and returns the status code and the response body.
+// If the body isn't nil, the body is encoded as JSON.
+type fakeHandler func(req *http.Request) (int, interface{}, error)
+
+// fakeNotFound returns the response of 404 Not Found.
+func fakeNotFound() (int, interface{}, error) {
+	return http.StatusNotFound, map[string]string{"message": "not found"}, nil
+}
+
+// use replaces the transport of http.DefaultClient with the transport which passes all requests to handle,
+// and returns the function to restore the transport.
+func (api *fakeAPI) use(t *testing.T, handle fakeHandler) func() {
+	defaultTransport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = &flute.Transport{
+		T: t,
+		Services: []flute.Service{
+			{
+				Endpoint: "http://example.com",
+				Routes: []flute.Route{
+					{
+						Name: "fake API",
+						Matcher: &flute.Matcher{
+							Match: func(req *http.Request) (bool, error) {
+								return true, nil
+							},
+						},
+						Response: &flute.Response{
+							Response: func(req *http.Request) (*http.Response, error) {
+								api.mutex.Lock()
+								defer api.mutex.Unlock()
+								code, body, err := handle(req)
+								if err != nil {
+									return nil, err
+								}
+								resp := &http.Response{StatusCode: code, Body: ioutil.NopCloser(strings.NewReader(""))}
+								if body != nil {
+									b, err := json.Marshal(body)
+									if err != nil {
+										return nil, err
+									}
+									resp.Body = ioutil.NopCloser(strings.NewReader(string(b)))
+								}
+								return resp, nil
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return func() {
+		http.DefaultClient.Transport = defaultTransport
+	}
+}
+
+// check returns the TestCheckFunc which calls f with the lock.
+func (api *fakeAPI) check(f func() error) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		api.mutex.Lock()
+		defer api.mutex.Unlock()
+		return f()
+	}
+}
+
+// update calls f with the lock. It is used to change the state out of band in PreConfig.
+func (api *fakeAPI) update(f func()) {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	f()
+}
+
+func TestWrapCRUD(t *testing.T) {
+	ctx := context.Background()
+	diags := wrapCRUD(func(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+		return nil
+	})(ctx, nil, nil)
+	require.False(t, diags.HasError())
+
+	diags = wrapCRUD(func(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+		return errors.New("failed")
+	})(ctx, nil, nil)
+	require.True(t, diags.HasError())
+	require.Nil(t, diags[0].AttributePath)
+
+	diags = wrapCRUD(func(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+		return fmt.Errorf("failed to set: %w", &attrError{key: "config.0.series", err: errors.New("invalid")})
+	})(ctx, nil, nil)
+	require.True(t, diags.HasError())
+	require.Equal(t, cty.GetAttrPath("config").IndexInt(0).GetAttr("series"), diags[0].AttributePath)
+}
+
+func TestParseDurationMS(t *testing.T) {
+	data := []struct {
+		s  string
+		ms int
+	}{
+		{"500ms", 500},
+		{"5m", 5 * 60 * 1000},
+		{"1d", 24 * 60 * 60 * 1000},
+		{"1d12h", 36 * 60 * 60 * 1000},
+	}
+	for _, d := range data {
+		ms, err := parseDurationMS(d.s)
+		require.Nil(t, err, d.s)
+		require.Equal(t, d.ms, ms, d.s)
+	}
+	for _, s := range []string{"", "d", "1.5d", "-1d", "1d-1h", "1dx"} {
+		_, err := parseDurationMS(s)
+		require.NotNil(t, err, s)
+	}
+}
@@ -0,0 +1,35 @@
+resource "graylog_event_definition" "test" {
+  title    = "new-event-definition"
+  priority = 2
+  alert    = true
+
+  aggregation {
+    query         = "test"
+    streams       = ["000000000000000000000001"]
+    group_by      = ["alert"]
+    search_within = "1m"
+    execute_every = "60s"
+
+    series {
+      id       = "count-alert"
+      function = "count"
+      field    = "alert"
+    }
+
+    conditions = "count-alert > 10"
+  }
+
+  field {
+    name = "test"
+
+    provider {
+      type     = "template-v1"
+      template = "test"
+    }
+  }
+
+  notification_settings {
+    grace_period_ms = 0
+    backlog_size    = 0
+  }
+}
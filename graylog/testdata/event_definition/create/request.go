@@ -15,11 +15,11 @@ func Request() *graylog.EventDefinition {
 		FieldSpec: map[string]graylog.EventDefinitionFieldSpec{
 			"test": {
 				DataType: "string",
-				Providers: []interface{}{
-					map[string]interface{}{
-						"require_values": false,
-						"template":       "test",
-						"type":           "template-v1",
+				Providers: []graylog.EventDefinitionFieldSpecProvider{
+					{
+						Type:          "template-v1",
+						Template:      "test",
+						RequireValues: false,
 					},
 				},
 			},
@@ -34,27 +34,24 @@ func Request() *graylog.EventDefinition {
 				NotificationID: "5de5a365a1de18000cdfdf49",
 			},
 		},
-		Storage: []interface{}{
-			map[string]interface{}{
-				"streams": []interface{}{
+		Storage: []graylog.EventDefinitionStorage{
+			{
+				Type: "persist-to-streams-v1",
+				Streams: []string{
 					"000000000000000000000002",
 				},
-				"type": "persist-to-streams-v1",
 			},
 		},
-		Config: map[string]interface{}{
-			"conditions": map[string]interface{}{
-				"expression": nil,
-			},
-			"execute_every_ms": 60000,
-			"group_by":         []interface{}{}, // p0
-			"query":            "test",
-			"search_within_ms": 60000,
-			"series":           []interface{}{},
-			"streams": []interface{}{
+		Config: &graylog.EventDefinitionConfigAggregation{
+			Query: "test",
+			Streams: []string{
 				"000000000000000000000001",
 			},
-			"type": "aggregation-v1",
+			GroupBy:        []string{},
+			Series:         []graylog.EventDefinitionConfigSeries{},
+			Conditions:     &graylog.EventDefinitionConfigConditions{},
+			SearchWithinMS: 60000,
+			ExecuteEveryMS: 60000,
 		},
 	}
 }
@@ -1,6 +1,8 @@
 package get
 
 import (
+	"github.com/suzuki-shunsuke/go-ptr"
+
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
@@ -13,55 +15,54 @@ func Response() *graylog.EventDefinition {
 		FieldSpec: map[string]graylog.EventDefinitionFieldSpec{
 			"test": {
 				DataType: "string",
-				Providers: []interface{}{
-					map[string]interface{}{
-						"require_values": false,
-						"template":       "test",
-						"type":           "template-v1",
+				Providers: []graylog.EventDefinitionFieldSpecProvider{
+					{
+						Type:          "template-v1",
+						Template:      "test",
+						RequireValues: false,
 					},
 				},
 			},
 		},
 		Notifications: []graylog.EventDefinitionNotification{},
-		Storage: []interface{}{
-			map[string]interface{}{
-				"streams": []interface{}{
+		Storage: []graylog.EventDefinitionStorage{
+			{
+				Type: "persist-to-streams-v1",
+				Streams: []string{
 					"000000000000000000000002",
 				},
-				"type": "persist-to-streams-v1",
 			},
 		},
-		Config: map[string]interface{}{
-			"conditions": map[string]interface{}{
-				"expression": map[string]interface{}{
-					"expr": "<",
-					"left": map[string]interface{}{
-						"expr": "number-ref",
-						"ref":  "9dfd012c-4f4d-417b-80d8-f7ebda2020a3",
-					},
-					"right": map[string]interface{}{
-						"expr":  "number",
-						"value": 0,
-					},
-				},
+		Config: &graylog.EventDefinitionConfigAggregation{
+			Query: "test",
+			Streams: []string{
+				"000000000000000000000001",
 			},
-			"execute_every_ms": 60000,
-			"group_by": []interface{}{
+			GroupBy: []string{
 				"alert",
 			},
-			"query":            "test",
-			"search_within_ms": 60000,
-			"series": []interface{}{
-				map[string]interface{}{
-					"field":    "alert",
-					"function": "avg",
-					"id":       "9dfd012c-4f4d-417b-80d8-f7ebda2020a3",
+			Series: []graylog.EventDefinitionConfigSeries{
+				{
+					ID:       "9dfd012c-4f4d-417b-80d8-f7ebda2020a3",
+					Function: "avg",
+					Field:    "alert",
 				},
 			},
-			"streams": []interface{}{
-				"000000000000000000000001",
+			Conditions: &graylog.EventDefinitionConfigConditions{
+				Expression: &graylog.EventDefinitionConfigConditionsExpression{
+					Expr: "<",
+					Left: &graylog.EventDefinitionConfigConditionsExpression{
+						Expr: "number-ref",
+						Ref:  "9dfd012c-4f4d-417b-80d8-f7ebda2020a3",
+					},
+					Right: &graylog.EventDefinitionConfigConditionsExpression{
+						Expr:  "number",
+						Value: ptr.PFloat64(0),
+					},
+				},
 			},
-			"type": "aggregation-v1",
+			SearchWithinMS: 60000,
+			ExecuteEveryMS: 60000,
 		},
 	}
 }
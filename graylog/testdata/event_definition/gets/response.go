@@ -1,6 +1,8 @@
 package gets
 
 import (
+	"github.com/suzuki-shunsuke/go-ptr"
+
 	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/graylog"
 )
 
@@ -16,11 +18,11 @@ func Response() *graylog.EventDefinitionsBody {
 				FieldSpec: map[string]graylog.EventDefinitionFieldSpec{
 					"test": {
 						DataType: "string",
-						Providers: []interface{}{
-							map[string]interface{}{
-								"require_values": false,
-								"template":       "test",
-								"type":           "template-v1",
+						Providers: []graylog.EventDefinitionFieldSpecProvider{
+							{
+								Type:          "template-v1",
+								Template:      "test",
+								RequireValues: false,
 							},
 						},
 					},
@@ -34,27 +36,24 @@ func Response() *graylog.EventDefinitionsBody {
 						NotificationID: "5de5a365a1de18000cdfdf49",
 					},
 				},
-				Storage: []interface{}{
-					map[string]interface{}{
-						"streams": []interface{}{
+				Storage: []graylog.EventDefinitionStorage{
+					{
+						Type: "persist-to-streams-v1",
+						Streams: []string{
 							"000000000000000000000002",
 						},
-						"type": "persist-to-streams-v1",
 					},
 				},
-				Config: map[string]interface{}{
-					"conditions": map[string]interface{}{
-						"expression": nil,
-					},
-					"execute_every_ms": 60000,
-					"group_by":         []interface{}{},
-					"query":            "test",
-					"search_within_ms": 60000,
-					"series":           []interface{}{},
-					"streams": []interface{}{
+				Config: &graylog.EventDefinitionConfigAggregation{
+					Query: "test",
+					Streams: []string{
 						"000000000000000000000001",
 					},
-					"type": "aggregation-v1",
+					GroupBy:        []string{},
+					Series:         []graylog.EventDefinitionConfigSeries{},
+					Conditions:     &graylog.EventDefinitionConfigConditions{},
+					SearchWithinMS: 60000,
+					ExecuteEveryMS: 60000,
 				},
 			},
 			{
@@ -66,11 +65,11 @@ func Response() *graylog.EventDefinitionsBody {
 				FieldSpec: map[string]graylog.EventDefinitionFieldSpec{
 					"test": {
 						DataType: "string",
-						Providers: []interface{}{
-							map[string]interface{}{
-								"require_values": false,
-								"template":       "test",
-								"type":           "template-v1",
+						Providers: []graylog.EventDefinitionFieldSpecProvider{
+							{
+								Type:          "template-v1",
+								Template:      "test",
+								RequireValues: false,
 							},
 						},
 					},
@@ -84,27 +83,24 @@ func Response() *graylog.EventDefinitionsBody {
 						NotificationID: "5de5a365a1de18000cdfdf49",
 					},
 				},
-				Storage: []interface{}{
-					map[string]interface{}{
-						"streams": []interface{}{
+				Storage: []graylog.EventDefinitionStorage{
+					{
+						Type: "persist-to-streams-v1",
+						Streams: []string{
 							"000000000000000000000002",
 						},
-						"type": "persist-to-streams-v1",
 					},
 				},
-				Config: map[string]interface{}{
-					"conditions": map[string]interface{}{
-						"expression": nil,
-					},
-					"execute_every_ms": 60000,
-					"group_by":         []interface{}{},
-					"query":            "test",
-					"search_within_ms": 60000,
-					"series":           []interface{}{},
-					"streams": []interface{}{
+				Config: &graylog.EventDefinitionConfigAggregation{
+					Query: "test",
+					Streams: []string{
 						"000000000000000000000001",
 					},
-					"type": "aggregation-v1",
+					GroupBy:        []string{},
+					Series:         []graylog.EventDefinitionConfigSeries{},
+					Conditions:     &graylog.EventDefinitionConfigConditions{},
+					SearchWithinMS: 60000,
+					ExecuteEveryMS: 60000,
 				},
 			},
 			{
@@ -129,43 +125,41 @@ func Response() *graylog.EventDefinitionsBody {
 						NotificationID: "5de59d56a1de18000cdfd772",
 					},
 				},
-				Storage: []interface{}{
-					map[string]interface{}{
-						"streams": []interface{}{
+				Storage: []graylog.EventDefinitionStorage{
+					{
+						Type: "persist-to-streams-v1",
+						Streams: []string{
 							"000000000000000000000002",
 						},
-						"type": "persist-to-streams-v1",
 					},
 				},
-				Config: map[string]interface{}{
-					"conditions": map[string]interface{}{
-						"expression": map[string]interface{}{
-							"expr": ">",
-							"left": map[string]interface{}{
-								"expr": "number-ref",
-								"ref":  "40aea221-88d2-492a-a3f0-62da7aa68f56",
-							},
-							"right": map[string]interface{}{
-								"expr":  "number",
-								"value": 0,
-							},
-						},
+				Config: &graylog.EventDefinitionConfigAggregation{
+					Query: "message:\"hoge hoge\"",
+					Streams: []string{
+						"5de4fcf7a1de1800127e2fbe",
 					},
-					"execute_every_ms": 60000,
-					"group_by":         []interface{}{},
-					"query":            "message:\"hoge hoge\"",
-					"search_within_ms": 60000,
-					"series": []interface{}{
-						map[string]interface{}{
-							"field":    nil,
-							"function": "count",
-							"id":       "40aea221-88d2-492a-a3f0-62da7aa68f56",
+					GroupBy: []string{},
+					Series: []graylog.EventDefinitionConfigSeries{
+						{
+							ID:       "40aea221-88d2-492a-a3f0-62da7aa68f56",
+							Function: "count",
 						},
 					},
-					"streams": []interface{}{
-						"5de4fcf7a1de1800127e2fbe",
+					Conditions: &graylog.EventDefinitionConfigConditions{
+						Expression: &graylog.EventDefinitionConfigConditionsExpression{
+							Expr: ">",
+							Left: &graylog.EventDefinitionConfigConditionsExpression{
+								Expr: "number-ref",
+								Ref:  "40aea221-88d2-492a-a3f0-62da7aa68f56",
+							},
+							Right: &graylog.EventDefinitionConfigConditionsExpression{
+								Expr:  "number",
+								Value: ptr.PFloat64(0),
+							},
+						},
 					},
-					"type": "aggregation-v1",
+					SearchWithinMS: 60000,
+					ExecuteEveryMS: 60000,
 				},
 			},
 		},
@@ -0,0 +1,31 @@
+resource "graylog_event_definition" "test" {
+  title    = "new-event-definition"
+  priority = 2
+  alert    = true
+
+  aggregation {
+    query         = "test"
+    streams       = ["000000000000000000000001"]
+    search_within = "5m"
+    execute_every = "1m"
+
+    series {
+      id       = "count-alert"
+      function = "count"
+      field    = "alert"
+    }
+
+    series {
+      id       = "avg-took"
+      function = "avg"
+      field    = "took_ms"
+    }
+
+    conditions = "count-alert > 10 && (avg-took >= 2.5 || !(count-alert == 0))"
+  }
+
+  notification_settings {
+    grace_period_ms = 0
+    backlog_size    = 0
+  }
+}
@@ -0,0 +1,12 @@
+resource "graylog_event_notification" "email" {
+  title       = "email"
+  description = "email notification"
+
+  email {
+    sender           = "graylog@example.com"
+    subject          = "Graylog event notification"
+    body_template    = "hello"
+    email_recipients = ["graylog@example.com"]
+    user_recipients  = ["admin"]
+  }
+}
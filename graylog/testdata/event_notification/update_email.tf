@@ -0,0 +1,11 @@
+resource "graylog_event_notification" "email" {
+  title       = "email"
+  description = "email notification"
+
+  email {
+    sender           = "graylog@example.com"
+    subject          = "Graylog event notification"
+    body_template    = "hello world"
+    email_recipients = ["graylog@example.com", "admin@example.com"]
+  }
+}
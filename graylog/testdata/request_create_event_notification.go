@@ -8,9 +8,8 @@ func RequestCreateEventNotification() *graylog.EventNotification {
 	return &graylog.EventNotification{
 		Title:       "http",
 		Description: "",
-		Config: map[string]interface{}{
-			"type": "http-notification-v1",
-			"url":  "http://example.com",
+		Config: &graylog.EventNotificationConfigHTTP{
+			URL: "http://example.com",
 		},
 	}
 }
@@ -9,9 +9,8 @@ func EventNotification() *graylog.EventNotification {
 		ID:          "5de5a365a1de18000cdfdf49",
 		Title:       "http",
 		Description: "",
-		Config: map[string]interface{}{
-			"type": "http-notification-v1",
-			"url":  "http://example.com",
+		Config: &graylog.EventNotificationConfigHTTP{
+			URL: "http://example.com",
 		},
 	}
 }
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// SortedStrings returns a sorted copy of a string slice.
+// If the slice is nil, an empty slice is returned so that it is encoded to an empty JSON array.
+func SortedStrings(a []string) []string {
+	if a == nil {
+		return []string{}
+	}
+	b := make([]string, len(a))
+	copy(b, a)
+	sort.Strings(b)
+	return b
+}
+
+// ToMap converts a struct to a map through JSON.
+func ToMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,23 @@
+package util
+
+import "strconv"
+
+// FormatDurationMS formats milliseconds with the largest unit which represents it exactly, such as "5m" and "1d".
+// The format is parsed by graylog_event_definition's "aggregation".
+func FormatDurationMS(ms int) string {
+	units := []struct {
+		suffix string
+		size   int
+	}{
+		{"d", 24 * 60 * 60 * 1000},
+		{"h", 60 * 60 * 1000},
+		{"m", 60 * 1000},
+		{"s", 1000},
+	}
+	for _, u := range units {
+		if ms != 0 && ms%u.size == 0 {
+			return strconv.Itoa(ms/u.size) + u.suffix
+		}
+	}
+	return strconv.Itoa(ms) + "ms"
+}
@@ -0,0 +1,17 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/suzuki-shunsuke/go-graylog/v11/graylog/util"
+)
+
+func TestFormatDurationMS(t *testing.T) {
+	require.Equal(t, "0ms", util.FormatDurationMS(0))
+	require.Equal(t, "500ms", util.FormatDurationMS(500))
+	require.Equal(t, "90s", util.FormatDurationMS(90*1000))
+	require.Equal(t, "36h", util.FormatDurationMS(36*60*60*1000))
+	require.Equal(t, "2d", util.FormatDurationMS(2*24*60*60*1000))
+}